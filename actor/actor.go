@@ -0,0 +1,28 @@
+// Package actor carries the identity of the caller performing a request through a context.
+package actor
+
+import "context"
+
+// Anonymous is recorded when a request carries no caller identity.
+const Anonymous = "anonymous"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries the given caller identity.
+func NewContext(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, contextKey{}, name)
+}
+
+// Lookup returns the caller identity stored in ctx and whether one was set.
+func Lookup(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(contextKey{}).(string)
+	return name, ok && name != ""
+}
+
+// FromContext returns the caller identity stored in ctx, or Anonymous when there is none.
+func FromContext(ctx context.Context) string {
+	if name, ok := Lookup(ctx); ok {
+		return name
+	}
+	return Anonymous
+}
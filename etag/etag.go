@@ -0,0 +1,51 @@
+// Package etag computes strong entity tags for stored entities and evaluates
+// If-Match / If-None-Match header values against them.
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// Of returns a strong, quoted entity tag derived from the JSON encoding of v.
+// Two values have the same tag exactly when they serialize to the same document.
+func Of(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return OfBytes(data)
+}
+
+// OfBytes returns a strong, quoted entity tag for an already encoded representation.
+func OfBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// Match reports whether tag is listed in an If-Match style header value. "*" matches any
+// existing entity. Weak tags (W/"...") never match, as required for If-Match.
+func Match(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || (candidate != "" && candidate == tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchWeak reports whether tag is listed in an If-None-Match style header value using the
+// weak comparison function, under which W/"x" and "x" are equivalent.
+func MatchWeak(header, tag string) bool {
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || (candidate != "" && strings.TrimPrefix(candidate, "W/") == tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package etag
+
+import "testing"
+
+func TestOf(t *testing.T) {
+	a := Of(map[string]string{"k": "v"})
+	if a != Of(map[string]string{"k": "v"}) {
+		t.Errorf("Equal values must have equal tags")
+	}
+	if a == Of(map[string]string{"k": "w"}) {
+		t.Errorf("Different values must have different tags")
+	}
+	if len(a) < 3 || a[0] != '"' || a[len(a)-1] != '"' {
+		t.Errorf("Expected a quoted strong tag, got %s", a)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tag := `"abc"`
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{`"abc"`, true},
+		{`"x", "abc"`, true},
+		{`*`, true},
+		{`W/"abc"`, false},
+		{`"abd"`, false},
+		{``, false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.header, tag); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestMatchWeak(t *testing.T) {
+	tag := `"abc"`
+	for _, header := range []string{`"abc"`, `W/"abc"`, `"x", W/"abc"`, `*`} {
+		if !MatchWeak(header, tag) {
+			t.Errorf("MatchWeak(%q) = false, want true", header)
+		}
+	}
+	for _, header := range []string{`"abd"`, ``, `W/"x"`} {
+		if MatchWeak(header, tag) {
+			t.Errorf("MatchWeak(%q) = true, want false", header)
+		}
+	}
+}
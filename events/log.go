@@ -0,0 +1,153 @@
+// Package events keeps a bounded log of configuration change events and fans new events
+// out to subscribers, such as the server-sent events endpoint.
+package events
+
+import (
+	"alati_projekat/model"
+	"sync"
+	"time"
+)
+
+// DefaultCapacity is the number of events a log keeps for resuming subscribers.
+const DefaultCapacity = 1024
+
+// subscriberBuffer is the number of events a subscriber may fall behind before it is dropped.
+const subscriberBuffer = 64
+
+// Log is an in-memory, bounded event log. Event IDs increase by one per event, starting at 1,
+// and are only unique within a single process.
+type Log struct {
+	mu       sync.Mutex
+	capacity int
+	events   []model.Event // oldest first, at most capacity
+	lastID   uint64
+	subs     map[*Subscription]struct{}
+	closed   bool
+}
+
+func NewLog(capacity int) *Log {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Log{capacity: capacity, subs: make(map[*Subscription]struct{})}
+}
+
+// Subscription receives the events published after it was created.
+type Subscription struct {
+	// C delivers new events. It is closed when the subscription is closed, when the log is
+	// closed, or when the subscriber falls too far behind; a dropped subscriber should
+	// resume from the last event it received.
+	C <-chan model.Event
+	// Backlog holds the retained events after the ID passed to Resume.
+	Backlog []model.Event
+	// Missed reports that some events after the ID passed to Resume are no longer retained.
+	Missed bool
+
+	ch  chan model.Event
+	log *Log
+}
+
+// Close stops the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.log.mu.Lock()
+	defer s.log.mu.Unlock()
+	s.log.drop(s)
+}
+
+// drop removes s and closes its channel; the caller holds l.mu.
+func (l *Log) drop(s *Subscription) {
+	if _, ok := l.subs[s]; ok {
+		delete(l.subs, s)
+		close(s.ch)
+	}
+}
+
+// Publish assigns the next ID and the current time to e, appends it to the log and delivers
+// it to every subscriber. Subscribers that cannot keep up are dropped instead of blocking.
+func (l *Log) Publish(e model.Event) model.Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastID++
+	e.ID = l.lastID
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if len(l.events) == l.capacity {
+		l.events = append(l.events[:0], l.events[1:]...)
+	}
+	l.events = append(l.events, e)
+
+	for s := range l.subs {
+		select {
+		case s.ch <- e:
+		default:
+			l.drop(s)
+		}
+	}
+	return e
+}
+
+// Subscribe returns a subscription to events published from now on.
+func (l *Log) Subscribe() *Subscription {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.subscribe()
+}
+
+// Resume returns a subscription whose backlog holds the retained events after lastID.
+// Missed is set when events after lastID were already evicted, or when lastID is ahead of
+// the log, e.g. because it was issued before a restart; the backlog then holds every
+// retained event.
+func (l *Log) Resume(lastID uint64) *Subscription {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s := l.subscribe()
+	switch {
+	case lastID > l.lastID:
+		s.Missed = true
+		s.Backlog = append([]model.Event(nil), l.events...)
+	case len(l.events) > 0 && lastID+1 < l.events[0].ID:
+		s.Missed = true
+		s.Backlog = append([]model.Event(nil), l.events...)
+	default:
+		for i, e := range l.events {
+			if e.ID > lastID {
+				s.Backlog = append([]model.Event(nil), l.events[i:]...)
+				break
+			}
+		}
+	}
+	return s
+}
+
+func (l *Log) subscribe() *Subscription {
+	ch := make(chan model.Event, subscriberBuffer)
+	s := &Subscription{C: ch, ch: ch, log: l}
+	if l.closed {
+		close(ch)
+		return s
+	}
+	l.subs[s] = struct{}{}
+	return s
+}
+
+// Close ends every subscription and makes new ones end at once, so that streaming requests
+// return during server shutdown. Events can still be published.
+func (l *Log) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	for s := range l.subs {
+		l.drop(s)
+	}
+}
+
+// Closed reports whether Close was called, so that a subscriber whose channel was closed
+// can tell a shutdown from being dropped.
+func (l *Log) Closed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.closed
+}
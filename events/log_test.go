@@ -0,0 +1,89 @@
+package events
+
+import (
+	"alati_projekat/model"
+	"testing"
+)
+
+func TestLog_PublishAndSubscribe(t *testing.T) {
+	log := NewLog(3)
+	sub := log.Subscribe()
+	defer sub.Close()
+
+	e := log.Publish(model.Event{Type: model.EventCreated, Name: "service-api", Version: "v1"})
+	if e.ID != 1 || e.Time.IsZero() {
+		t.Errorf("Expected ID 1 and a timestamp, got %+v", e)
+	}
+	if got := <-sub.C; got.ID != 1 || got.Name != "service-api" {
+		t.Errorf("Subscriber got %+v", got)
+	}
+	if len(sub.Backlog) != 0 || sub.Missed {
+		t.Errorf("A new subscription has no backlog, got %+v", sub)
+	}
+}
+
+func TestLog_Resume(t *testing.T) {
+	log := NewLog(3)
+	for range 5 {
+		log.Publish(model.Event{Type: model.EventUpdated})
+	}
+	// Log čuva samo događaje 3, 4 i 5
+
+	tests := []struct {
+		lastID      uint64
+		wantMissed  bool
+		wantBacklog []uint64
+	}{
+		{lastID: 3, wantBacklog: []uint64{4, 5}},
+		{lastID: 2, wantBacklog: []uint64{3, 4, 5}},
+		{lastID: 5, wantBacklog: nil},
+		{lastID: 1, wantMissed: true, wantBacklog: []uint64{3, 4, 5}},
+		{lastID: 9, wantMissed: true, wantBacklog: []uint64{3, 4, 5}},
+	}
+	for _, tt := range tests {
+		sub := log.Resume(tt.lastID)
+		var ids []uint64
+		for _, e := range sub.Backlog {
+			ids = append(ids, e.ID)
+		}
+		if sub.Missed != tt.wantMissed || len(ids) != len(tt.wantBacklog) {
+			t.Errorf("Resume(%d): expected missed=%v backlog %v, got missed=%v backlog %v", tt.lastID, tt.wantMissed, tt.wantBacklog, sub.Missed, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tt.wantBacklog[i] {
+				t.Errorf("Resume(%d): expected backlog %v, got %v", tt.lastID, tt.wantBacklog, ids)
+				break
+			}
+		}
+		sub.Close()
+	}
+}
+
+func TestLog_DropsSlowSubscribers(t *testing.T) {
+	log := NewLog(0)
+	slow := log.Subscribe()
+	for range subscriberBuffer + 1 {
+		log.Publish(model.Event{Type: model.EventUpdated})
+	}
+	received := 0
+	for range slow.C {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("Expected %d buffered events before the channel was closed, got %d", subscriberBuffer, received)
+	}
+	slow.Close() // ponovno zatvaranje ne sme da panici
+}
+
+func TestLog_Close(t *testing.T) {
+	log := NewLog(0)
+	sub := log.Subscribe()
+	log.Close()
+	if _, ok := <-sub.C; ok {
+		t.Error("Expected the subscription to be closed")
+	}
+	if _, ok := <-log.Subscribe().C; ok {
+		t.Error("Expected subscriptions of a closed log to end at once")
+	}
+}
@@ -0,0 +1,159 @@
+// Package formats renders configuration parameters in the file formats consumers drop on disk
+// (YAML, TOML, dotenv, Java properties and flat JSON) and negotiates which one a request wants.
+package formats
+
+import (
+	"alati_projekat/model"
+	"errors"
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format identifies a response representation.
+type Format string
+
+const (
+	// JSON is the default representation: the full entity envelope.
+	JSON       Format = "json"
+	YAML       Format = "yaml"
+	TOML       Format = "toml"
+	Dotenv     Format = "env"
+	Properties Format = "properties"
+	// FlatJSON is a single JSON object mapping parameter keys to values.
+	FlatJSON Format = "flat"
+)
+
+var (
+	// ErrUnknownFormat is returned for a ?format= value that is not supported.
+	ErrUnknownFormat = errors.New("unknown output format")
+	// ErrKeyCollision is returned when two different keys map to the same output key,
+	// for example "db.host" and "db_host" in dotenv.
+	ErrKeyCollision = errors.New("parameter keys collide in output format")
+)
+
+// contentTypes lists the media type produced for each format.
+var contentTypes = map[Format]string{
+	JSON:       "application/json",
+	YAML:       "application/yaml",
+	TOML:       "application/toml",
+	Dotenv:     "text/x-dotenv",
+	Properties: "text/x-java-properties",
+	FlatJSON:   "application/vnd.config.flat+json",
+}
+
+// formatAliases maps ?format= values to formats.
+var formatAliases = map[string]Format{
+	"json":       JSON,
+	"yaml":       YAML,
+	"yml":        YAML,
+	"toml":       TOML,
+	"env":        Dotenv,
+	"dotenv":     Dotenv,
+	"properties": Properties,
+	"props":      Properties,
+	"flat":       FlatJSON,
+	"flat-json":  FlatJSON,
+}
+
+// mediaTypes maps Accept media types to formats, including common legacy aliases.
+var mediaTypes = map[string]Format{
+	"application/json":                 JSON,
+	"application/yaml":                 YAML,
+	"application/x-yaml":               YAML,
+	"text/yaml":                        YAML,
+	"application/toml":                 TOML,
+	"text/x-dotenv":                    Dotenv,
+	"text/x-java-properties":           Properties,
+	"application/vnd.config.flat+json": FlatJSON,
+}
+
+// ContentType returns the media type of the format, with a charset for text formats.
+func (f Format) ContentType() string {
+	ct := contentTypes[f]
+	if strings.HasPrefix(ct, "text/") {
+		ct += "; charset=utf-8"
+	}
+	return ct
+}
+
+// Negotiate picks the response format. An explicit ?format= value wins and must be known;
+// otherwise the Accept header is consulted by quality value. Requests that accept nothing
+// supported fall back to JSON, which is what the API always returned.
+func Negotiate(formatParam, accept string) (Format, error) {
+	if formatParam != "" {
+		f, ok := formatAliases[strings.ToLower(formatParam)]
+		if !ok {
+			return "", fmt.Errorf("%w %q", ErrUnknownFormat, formatParam)
+		}
+		return f, nil
+	}
+
+	type candidate struct {
+		format Format
+		q      float64
+		order  int
+	}
+	var candidates []candidate
+	for i, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(raw, 64); err != nil {
+				continue
+			}
+		}
+		if f, ok := mediaTypes[mediaType]; ok && q > 0 {
+			candidates = append(candidates, candidate{f, q, i})
+		}
+	}
+	if len(candidates) == 0 {
+		return JSON, nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].format, nil
+}
+
+// Section is a named block of parameters. A single configuration is rendered as one section
+// with an empty path; a group renders one section per member under [name, version].
+type Section struct {
+	Path   []string
+	Params []model.Parameter
+}
+
+// ConfigurationSections returns the sections of a single configuration.
+func ConfigurationSections(c model.Configuration) []Section {
+	return []Section{{Params: c.Params}}
+}
+
+// GroupSections returns one section per configuration in the group.
+func GroupSections(g model.ConfigurationGroup) []Section {
+	sections := make([]Section, 0, len(g.Configurations))
+	for _, c := range g.Configurations {
+		sections = append(sections, Section{Path: []string{c.Name, c.Version}, Params: c.Params})
+	}
+	return sections
+}
+
+// Render renders sections in the given format. JSON is not handled here because the
+// envelope is the entity itself.
+func Render(f Format, sections []Section) ([]byte, error) {
+	switch f {
+	case YAML:
+		return renderYAML(sections), nil
+	case TOML:
+		return renderTOML(sections), nil
+	case Dotenv:
+		return renderDotenv(sections)
+	case Properties:
+		return renderProperties(sections)
+	case FlatJSON:
+		return renderFlatJSON(sections)
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownFormat, f)
+}
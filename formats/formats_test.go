@@ -0,0 +1,119 @@
+package formats
+
+import (
+	"alati_projekat/model"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// tricky sadrzi vrednosti koje zahtevaju escape u svakom formatu.
+var tricky = []model.Parameter{
+	{Key: "db.host", Value: "localhost"},
+	{Key: "db.port", Value: "5432"},
+	{Key: "greeting", Value: "Hello, \"world\"\nsecond line"},
+	{Key: "path", Value: `C:\temp\app`},
+	{Key: "password", Value: "p@ss word$HOME #!=:"},
+	{Key: "unicode", Value: "čćž 😀"},
+	{Key: "empty", Value: ""},
+	{Key: "yes", Value: "true"},
+	{Key: "key with space", Value: " leading space"},
+	{Key: "tab", Value: "a\tb"},
+}
+
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("Failed to update %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file %s: %v", path, err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+	}
+}
+
+func TestRender_Golden(t *testing.T) {
+	config := model.Configuration{Name: "service-api", Version: "v1", Params: tricky}
+	group := model.ConfigurationGroup{
+		Name:    "cluster",
+		Version: "v2",
+		Configurations: []model.Configuration{
+			{Name: "service-api", Version: "v1", Params: tricky[:3]},
+			{Name: "service-api", Version: "1.2.0", Params: []model.Parameter{{Key: "db.host", Value: "db.prod"}}},
+			{Name: "worker", Version: "v1"},
+		},
+	}
+
+	extensions := map[Format]string{
+		YAML:       "yaml",
+		TOML:       "toml",
+		Dotenv:     "env",
+		Properties: "properties",
+		FlatJSON:   "flat.json",
+	}
+	for f, ext := range extensions {
+		t.Run(string(f), func(t *testing.T) {
+			out, err := Render(f, ConfigurationSections(config))
+			if err != nil {
+				t.Fatalf("Render config failed: %v", err)
+			}
+			golden(t, "config."+ext, out)
+
+			out, err = Render(f, GroupSections(group))
+			if err != nil {
+				t.Fatalf("Render group failed: %v", err)
+			}
+			golden(t, "group."+ext, out)
+		})
+	}
+}
+
+func TestRender_KeyCollision(t *testing.T) {
+	sections := []Section{{Params: []model.Parameter{{Key: "db.host", Value: "a"}, {Key: "db_host", Value: "b"}}}}
+	if _, err := Render(Dotenv, sections); !errors.Is(err, ErrKeyCollision) {
+		t.Errorf("Expected ErrKeyCollision for dotenv, got %v", err)
+	}
+	if _, err := Render(Properties, sections); err != nil {
+		t.Errorf("Distinct keys must not collide in properties: %v", err)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		format string
+		accept string
+		want   Format
+	}{
+		{"", "", JSON},
+		{"", "*/*", JSON},
+		{"", "text/html", JSON},
+		{"", "application/yaml", YAML},
+		{"", "application/json;q=0.5, application/toml", TOML},
+		{"", "text/x-java-properties;q=0.2, text/x-dotenv;q=0.9", Dotenv},
+		{"", "application/x-yaml;q=0, application/json", JSON},
+		{"yml", "application/json", YAML},
+		{"ENV", "", Dotenv},
+		{"flat", "", FlatJSON},
+	}
+	for _, tt := range tests {
+		got, err := Negotiate(tt.format, tt.accept)
+		if err != nil || got != tt.want {
+			t.Errorf("Negotiate(%q, %q) = %q, %v; want %q", tt.format, tt.accept, got, err, tt.want)
+		}
+	}
+
+	if _, err := Negotiate("xml", ""); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Expected ErrUnknownFormat, got %v", err)
+	}
+}
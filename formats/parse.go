@@ -0,0 +1,492 @@
+package formats
+
+import (
+	"alati_projekat/model"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v3"
+)
+
+// DefaultSeparator joins the keys of nested YAML, JSON and TOML documents.
+const DefaultSeparator = "."
+
+// ParseError reports a syntax error in an imported document. Line is 1-based and 0 when unknown.
+type ParseError struct {
+	Format Format
+	Line   int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s: line %d: %s", e.Format, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", e.Format, e.Msg)
+}
+
+// ErrUndetectedFormat is returned when the format of an imported document cannot be determined.
+var ErrUndetectedFormat = errors.New("cannot determine input format, use ?format=")
+
+// extensions maps file extensions to formats for imports.
+var extensions = map[string]Format{
+	".yaml":       YAML,
+	".yml":        YAML,
+	".json":       JSON,
+	".toml":       TOML,
+	".env":        Dotenv,
+	".properties": Properties,
+}
+
+// Detect determines the format of an imported document from an explicit format name,
+// then its media type, then the file name extension (".env" files are often named just ".env").
+func Detect(formatParam, contentType, filename string) (Format, error) {
+	if formatParam != "" {
+		f, ok := formatAliases[strings.ToLower(formatParam)]
+		if !ok {
+			return "", fmt.Errorf("%w %q", ErrUnknownFormat, formatParam)
+		}
+		return f, nil
+	}
+	if contentType != "" {
+		if i := strings.IndexByte(contentType, ';'); i >= 0 {
+			contentType = contentType[:i]
+		}
+		if f, ok := mediaTypes[strings.ToLower(strings.TrimSpace(contentType))]; ok {
+			return f, nil
+		}
+	}
+	if filename != "" {
+		base := strings.ToLower(path.Base(filename))
+		if f, ok := extensions[path.Ext(base)]; ok {
+			return f, nil
+		}
+		if base == ".env" || strings.HasPrefix(base, ".env.") {
+			return Dotenv, nil
+		}
+	}
+	return "", ErrUndetectedFormat
+}
+
+// Parse reads parameters from a document. Nested YAML/JSON mappings and TOML tables are
+// flattened by joining keys with sep, and list items get their index as key segment.
+// Parameters keep the order in which they appear in the document.
+func Parse(f Format, data []byte, sep string) ([]model.Parameter, error) {
+	if sep == "" {
+		sep = DefaultSeparator
+	}
+	if !utf8.Valid(data) {
+		return nil, &ParseError{Format: f, Msg: "document is not valid UTF-8"}
+	}
+	switch f {
+	case JSON, FlatJSON:
+		// YAML accepts more than JSON, so check the syntax first; the YAML parser then
+		// flattens the document while keeping key order.
+		if err := checkJSON(data); err != nil {
+			return nil, err
+		}
+		return parseYAML(f, data, sep)
+	case YAML:
+		return parseYAML(f, data, sep)
+	case TOML:
+		return parseTOML(data, sep)
+	case Dotenv:
+		return parseDotenv(data)
+	case Properties:
+		return parseProperties(data)
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownFormat, f)
+}
+
+// --- YAML / JSON ---
+
+func checkJSON(data []byte) error {
+	var v any
+	err := json.Unmarshal(data, &v)
+	if err == nil {
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return &ParseError{Format: JSON, Line: bytes.Count(data[:syntaxErr.Offset], []byte("\n")) + 1, Msg: syntaxErr.Error()}
+	}
+	return &ParseError{Format: JSON, Msg: err.Error()}
+}
+
+var yamlErrLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+func parseYAML(f Format, data []byte, sep string) ([]model.Parameter, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		msg := strings.TrimPrefix(err.Error(), "yaml: ")
+		line := 0
+		if m := yamlErrLine.FindStringSubmatch(err.Error()); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			msg = m[2]
+		}
+		return nil, &ParseError{Format: f, Line: line, Msg: msg}
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, &ParseError{Format: f, Line: root.Line, Msg: "top level must be a mapping of keys to values"}
+	}
+
+	var params []model.Parameter
+	var walk func(n *yaml.Node, prefix string) error
+	walk = func(n *yaml.Node, prefix string) error {
+		switch n.Kind {
+		case yaml.AliasNode:
+			return walk(n.Alias, prefix)
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				k, v := n.Content[i], n.Content[i+1]
+				if k.Kind != yaml.ScalarNode {
+					return &ParseError{Format: f, Line: k.Line, Msg: "mapping keys must be scalars"}
+				}
+				if k.Tag == "!!merge" {
+					return &ParseError{Format: f, Line: k.Line, Msg: "merge keys (<<) are not supported"}
+				}
+				if err := walk(v, joinKey(prefix, k.Value, sep)); err != nil {
+					return err
+				}
+			}
+		case yaml.SequenceNode:
+			for i, item := range n.Content {
+				if err := walk(item, joinKey(prefix, strconv.Itoa(i), sep)); err != nil {
+					return err
+				}
+			}
+		case yaml.ScalarNode:
+			value := n.Value
+			if n.Tag == "!!null" {
+				value = ""
+			}
+			params = append(params, model.Parameter{Key: prefix, Value: value})
+		}
+		return nil
+	}
+	if err := walk(root, ""); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func joinKey(prefix, key, sep string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + sep + key
+}
+
+// --- TOML ---
+
+func parseTOML(data []byte, sep string) ([]model.Parameter, error) {
+	var doc map[string]any
+	md, err := toml.Decode(string(data), &doc)
+	if err != nil {
+		var perr toml.ParseError
+		if errors.As(err, &perr) {
+			return nil, &ParseError{Format: TOML, Line: perr.Position.Line, Msg: perr.Message}
+		}
+		return nil, &ParseError{Format: TOML, Msg: err.Error()}
+	}
+
+	// md.Keys lists keys in document order; tables are expanded when their own key comes up.
+	var params []model.Parameter
+	done := map[string]bool{}
+	for _, key := range md.Keys() {
+		id := strings.Join(key, "\x00")
+		// Keys inside an already flattened array of tables, and the repeated [[array]] headers themselves.
+		if done[id] || (len(key) > 1 && done[strings.Join(key[:len(key)-1], "\x00")]) {
+			done[id] = true
+			continue
+		}
+		value := lookupTOML(doc, key)
+		if _, isTable := value.(map[string]any); isTable {
+			continue
+		}
+		done[id] = true
+		params = appendTOML(params, strings.Join(key, sep), value, sep)
+	}
+	return params, nil
+}
+
+func lookupTOML(doc map[string]any, key toml.Key) any {
+	var cur any = doc
+	for _, k := range key {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[k]
+	}
+	return cur
+}
+
+func appendTOML(params []model.Parameter, key string, value any, sep string) []model.Parameter {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(v) {
+			params = appendTOML(params, key+sep+k, v[k], sep)
+		}
+	case []map[string]any:
+		for i, item := range v {
+			params = appendTOML(params, key+sep+strconv.Itoa(i), item, sep)
+		}
+	case []any:
+		for i, item := range v {
+			params = appendTOML(params, key+sep+strconv.Itoa(i), item, sep)
+		}
+	case string:
+		params = append(params, model.Parameter{Key: key, Value: v})
+	case int64:
+		params = append(params, model.Parameter{Key: key, Value: strconv.FormatInt(v, 10)})
+	case float64:
+		params = append(params, model.Parameter{Key: key, Value: strconv.FormatFloat(v, 'g', -1, 64)})
+	case bool:
+		params = append(params, model.Parameter{Key: key, Value: strconv.FormatBool(v)})
+	case time.Time:
+		params = append(params, model.Parameter{Key: key, Value: v.Format(time.RFC3339Nano)})
+	default:
+		params = append(params, model.Parameter{Key: key, Value: fmt.Sprint(v)})
+	}
+	return params
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// --- dotenv ---
+
+var dotenvKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// parseDotenv accepts KEY=value lines with an optional "export " prefix, # comments,
+// single-quoted literal values and double-quoted values with escapes that may span lines.
+func parseDotenv(data []byte) ([]model.Parameter, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	var params []model.Parameter
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			return nil, &ParseError{Format: Dotenv, Line: lineNo, Msg: "expected KEY=value"}
+		}
+		key := strings.TrimSpace(line[:eq])
+		if !dotenvKeyPattern.MatchString(key) {
+			return nil, &ParseError{Format: Dotenv, Line: lineNo, Msg: fmt.Sprintf("invalid variable name %q", key)}
+		}
+		raw := strings.TrimSpace(line[eq+1:])
+
+		var value string
+		switch {
+		case strings.HasPrefix(raw, "'"):
+			end := strings.IndexByte(raw[1:], '\'')
+			if end < 0 {
+				return nil, &ParseError{Format: Dotenv, Line: lineNo, Msg: "unterminated single-quoted value"}
+			}
+			value = raw[1 : end+1]
+		case strings.HasPrefix(raw, `"`):
+			var err error
+			var consumed int
+			value, consumed, err = readDoubleQuoted(raw[1:], lines[i+1:])
+			if err != nil {
+				return nil, &ParseError{Format: Dotenv, Line: lineNo, Msg: err.Error()}
+			}
+			i += consumed
+		default:
+			if j := strings.Index(raw, " #"); j >= 0 {
+				raw = strings.TrimSpace(raw[:j])
+			}
+			value = raw
+		}
+		params = append(params, model.Parameter{Key: key, Value: value})
+	}
+	return params, nil
+}
+
+// readDoubleQuoted decodes a double-quoted dotenv value starting after the opening quote.
+// It returns how many of the following lines the value consumed.
+func readDoubleQuoted(first string, rest []string) (string, int, error) {
+	var b strings.Builder
+	s := first
+	consumed := 0
+	for {
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			switch {
+			case c == '"':
+				return b.String(), consumed, nil
+			case c == '\\' && i+1 < len(s):
+				i++
+				switch s[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(s[i])
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+		if consumed == len(rest) {
+			return "", 0, errors.New("unterminated double-quoted value")
+		}
+		b.WriteByte('\n')
+		s = rest[consumed]
+		consumed++
+	}
+}
+
+// --- Java properties ---
+
+// parseProperties follows java.util.Properties#load: # and ! comments, backslash line
+// continuations, keys ending at the first unescaped '=', ':' or whitespace, and
+// \t \n \r \f \uXXXX escapes.
+func parseProperties(data []byte) ([]model.Parameter, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	var params []model.Parameter
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		logical := strings.TrimLeft(lines[i], " \t\f")
+		if logical == "" || logical[0] == '#' || logical[0] == '!' {
+			continue
+		}
+		for continues(logical) && i+1 < len(lines) {
+			i++
+			logical = logical[:len(logical)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		if continues(logical) {
+			logical = logical[:len(logical)-1]
+		}
+
+		keyEnd, valueStart := splitProperty(logical)
+		key, err := unescapeProperty(logical[:keyEnd])
+		if err != nil {
+			return nil, &ParseError{Format: Properties, Line: lineNo, Msg: err.Error()}
+		}
+		value, err := unescapeProperty(logical[valueStart:])
+		if err != nil {
+			return nil, &ParseError{Format: Properties, Line: lineNo, Msg: err.Error()}
+		}
+		params = append(params, model.Parameter{Key: key, Value: value})
+	}
+	return params, nil
+}
+
+// continues reports whether a line ends with an odd number of backslashes.
+func continues(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty returns where the key ends and the value starts in a logical line.
+func splitProperty(line string) (int, int) {
+	keyEnd := len(line)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			keyEnd = i
+			break
+		}
+	}
+	j := keyEnd
+	for j < len(line) && (line[j] == ' ' || line[j] == '\t' || line[j] == '\f') {
+		j++
+	}
+	if j < len(line) && (line[j] == '=' || line[j] == ':') {
+		j++
+		for j < len(line) && (line[j] == ' ' || line[j] == '\t' || line[j] == '\f') {
+			j++
+		}
+	}
+	return keyEnd, j
+}
+
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var units []uint16
+	var b strings.Builder
+	flush := func() {
+		if len(units) > 0 {
+			b.WriteString(string(utf16.Decode(units)))
+			units = nil
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			flush()
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			flush()
+			b.WriteByte('\t')
+		case 'n':
+			flush()
+			b.WriteByte('\n')
+		case 'r':
+			flush()
+			b.WriteByte('\r')
+		case 'f':
+			flush()
+			b.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("malformed \\u escape in %q", s)
+			}
+			n, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\u escape in %q", s)
+			}
+			units = append(units, uint16(n))
+			i += 4
+		default:
+			flush()
+			b.WriteByte(s[i])
+		}
+	}
+	flush()
+	return b.String(), nil
+}
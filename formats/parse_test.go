@@ -0,0 +1,152 @@
+package formats
+
+import (
+	"alati_projekat/model"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParse_RoundTrip(t *testing.T) {
+	// Svaki golden fajl iz renderera mora da se ucita nazad u iste parametre.
+	for f, ext := range map[Format]string{YAML: "yaml", TOML: "toml", Properties: "properties", FlatJSON: "flat.json"} {
+		data, err := os.ReadFile(filepath.Join("testdata", "config."+ext))
+		if err != nil {
+			t.Fatalf("Failed to read golden file: %v", err)
+		}
+		params, err := Parse(f, data, ".")
+		if err != nil {
+			t.Errorf("%s: Parse failed: %v", f, err)
+			continue
+		}
+		if got, want := asMap(params), asMap(tricky); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: round trip mismatch\ngot  %v\nwant %v", f, got, want)
+		}
+	}
+
+	// dotenv menja imena kljuceva, pa se porede samo vrednosti.
+	data, _ := os.ReadFile(filepath.Join("testdata", "config.env"))
+	params, err := Parse(Dotenv, data, ".")
+	if err != nil {
+		t.Fatalf("dotenv: Parse failed: %v", err)
+	}
+	for i, p := range params {
+		if p.Value != tricky[i].Value {
+			t.Errorf("dotenv: %s = %q, want %q", p.Key, p.Value, tricky[i].Value)
+		}
+	}
+}
+
+func asMap(params []model.Parameter) map[string]string {
+	m := make(map[string]string, len(params))
+	for _, p := range params {
+		m[p.Key] = p.Value
+	}
+	return m
+}
+
+func TestParse_Flatten(t *testing.T) {
+	yamlDoc := "db:\n  host: localhost\n  ports: [5432, 5433]\nfeature:\n  enabled: true\n  note: ~\n"
+	params, err := Parse(YAML, []byte(yamlDoc), "__")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want := []model.Parameter{
+		{Key: "db__host", Value: "localhost"},
+		{Key: "db__ports__0", Value: "5432"},
+		{Key: "db__ports__1", Value: "5433"},
+		{Key: "feature__enabled", Value: "true"},
+		{Key: "feature__note", Value: ""},
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("YAML flatten:\ngot  %v\nwant %v", params, want)
+	}
+
+	tomlDoc := "title = \"x\"\n[db]\nhost = \"h\"\nport = 5432\n[db.pool]\nsize = 3\n[[servers]]\nname = \"a\"\n[[servers]]\nname = \"b\"\n"
+	params, err = Parse(TOML, []byte(tomlDoc), ".")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want = []model.Parameter{
+		{Key: "title", Value: "x"},
+		{Key: "db.host", Value: "h"},
+		{Key: "db.port", Value: "5432"},
+		{Key: "db.pool.size", Value: "3"},
+		{Key: "servers.0.name", Value: "a"},
+		{Key: "servers.1.name", Value: "b"},
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("TOML flatten:\ngot  %v\nwant %v", params, want)
+	}
+
+	envDoc := "# komentar\nexport A=1\nB='literal $x'\nC=\"multi\nline\"\nD=plain # comment\n"
+	params, err = Parse(Dotenv, []byte(envDoc), ".")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want = []model.Parameter{{Key: "A", Value: "1"}, {Key: "B", Value: "literal $x"}, {Key: "C", Value: "multi\nline"}, {Key: "D", Value: "plain"}}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("dotenv:\ngot  %v\nwant %v", params, want)
+	}
+
+	propsDoc := "! komentar\nlong = first \\\n    second\nkey\\ one : value\nbare\n"
+	params, err = Parse(Properties, []byte(propsDoc), ".")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want = []model.Parameter{{Key: "long", Value: "first second"}, {Key: "key one", Value: "value"}, {Key: "bare", Value: ""}}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("properties:\ngot  %v\nwant %v", params, want)
+	}
+}
+
+func TestParse_ErrorLines(t *testing.T) {
+	tests := []struct {
+		format Format
+		doc    string
+		line   int
+	}{
+		{YAML, "a: 1\nb:\n\tc: 3\n", 3},
+		{YAML, "- a\n- b\n", 1},
+		{JSON, "{\n  \"a\": 1,\n  \"b\": }\n", 3},
+		{TOML, "a = 1\nb = \n", 2},
+		{Dotenv, "A=1\nnot a pair\n", 2},
+		{Dotenv, "A=1\n\nB=\"open\n", 3},
+		{Properties, "a=1\nb=\\u12G4\n", 2},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.format, []byte(tt.doc), ".")
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%s %q: expected ParseError, got %v", tt.format, tt.doc, err)
+			continue
+		}
+		if perr.Line != tt.line {
+			t.Errorf("%s %q: expected line %d, got %d (%v)", tt.format, tt.doc, tt.line, perr.Line, perr)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		format, contentType, filename string
+		want                          Format
+	}{
+		{"toml", "application/yaml", "a.env", TOML},
+		{"", "application/x-yaml; charset=utf-8", "a.env", YAML},
+		{"", "application/octet-stream", "app.properties", Properties},
+		{"", "", ".env", Dotenv},
+		{"", "", ".env.local", Dotenv},
+		{"", "", "config.yml", YAML},
+	}
+	for _, tt := range tests {
+		if got, err := Detect(tt.format, tt.contentType, tt.filename); err != nil || got != tt.want {
+			t.Errorf("Detect(%q, %q, %q) = %q, %v; want %q", tt.format, tt.contentType, tt.filename, got, err, tt.want)
+		}
+	}
+	if _, err := Detect("", "text/plain", "notes.txt"); !errors.Is(err, ErrUndetectedFormat) {
+		t.Errorf("Expected ErrUndetectedFormat, got %v", err)
+	}
+}
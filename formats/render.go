@@ -0,0 +1,309 @@
+package formats
+
+import (
+	"alati_projekat/model"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+)
+
+var (
+	yamlPlainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.\-/]*$`)
+	tomlBareKey  = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	dotenvPlain  = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+)
+
+// yamlReserved are plain scalars that YAML 1.1 parsers would not read back as strings.
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true,
+	"y": true, "n": true, "null": true, "~": true,
+}
+
+// jsonString encodes s as a JSON string. JSON strings are valid YAML double-quoted scalars.
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// flatKey joins the section path and the parameter key with sep.
+func flatKey(path []string, key, sep string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return strings.Join(path, sep) + sep + key
+}
+
+// sourceKey identifies a parameter unambiguously, using the name/version#key reference syntax.
+func sourceKey(path []string, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return strings.Join(path, "/") + "#" + key
+}
+
+// keySet detects distinct source keys that produce the same output key.
+type keySet map[string]string
+
+func (s keySet) add(out, source string) error {
+	if prev, ok := s[out]; ok && prev != source {
+		return fmt.Errorf("%w: %q and %q both become %q", ErrKeyCollision, prev, source, out)
+	}
+	s[out] = source
+	return nil
+}
+
+// --- YAML ---
+
+func yamlKey(k string) string {
+	if yamlPlainKey.MatchString(k) && !yamlReserved[strings.ToLower(k)] {
+		return k
+	}
+	return jsonString(k)
+}
+
+func renderYAML(sections []Section) []byte {
+	var b strings.Builder
+	if len(sections) == 1 && len(sections[0].Path) == 0 {
+		writeYAMLParams(&b, sections[0].Params, "")
+		if len(sections[0].Params) == 0 {
+			b.WriteString("{}\n")
+		}
+		return []byte(b.String())
+	}
+	if len(sections) == 0 {
+		return []byte("{}\n")
+	}
+
+	// Versions of the same configuration share one mapping key, since YAML forbids duplicates.
+	var names []string
+	byName := map[string][]Section{}
+	for _, s := range sections {
+		if _, seen := byName[s.Path[0]]; !seen {
+			names = append(names, s.Path[0])
+		}
+		byName[s.Path[0]] = append(byName[s.Path[0]], s)
+	}
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s:\n", yamlKey(name))
+		for _, s := range byName[name] {
+			if len(s.Params) == 0 {
+				fmt.Fprintf(&b, "  %s: {}\n", yamlKey(s.Path[1]))
+				continue
+			}
+			fmt.Fprintf(&b, "  %s:\n", yamlKey(s.Path[1]))
+			writeYAMLParams(&b, s.Params, "    ")
+		}
+	}
+	return []byte(b.String())
+}
+
+func writeYAMLParams(b *strings.Builder, params []model.Parameter, indent string) {
+	for _, p := range params {
+		fmt.Fprintf(b, "%s%s: %s\n", indent, yamlKey(p.Key), jsonString(p.Value))
+	}
+}
+
+// --- TOML ---
+
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func tomlKey(k string) string {
+	if tomlBareKey.MatchString(k) {
+		return k
+	}
+	return tomlString(k)
+}
+
+func renderTOML(sections []Section) []byte {
+	var b strings.Builder
+	for i, s := range sections {
+		if len(s.Path) > 0 {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			parts := make([]string, len(s.Path))
+			for j, p := range s.Path {
+				parts[j] = tomlKey(p)
+			}
+			fmt.Fprintf(&b, "[%s]\n", strings.Join(parts, "."))
+		}
+		for _, p := range s.Params {
+			fmt.Fprintf(&b, "%s = %s\n", tomlKey(p.Key), tomlString(p.Value))
+		}
+	}
+	return []byte(b.String())
+}
+
+// --- dotenv ---
+
+// dotenvKey turns a key into an environment variable name: upper case, with every
+// character outside [A-Z0-9_] replaced by an underscore.
+func dotenvKey(k string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(k) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	out := b.String()
+	if out == "" || (out[0] >= '0' && out[0] <= '9') {
+		out = "_" + out
+	}
+	return out
+}
+
+// dotenvValue leaves simple values unquoted and double-quotes everything else, escaping
+// characters that dotenv loaders and shells would otherwise interpret.
+func dotenvValue(v string) string {
+	if dotenvPlain.MatchString(v) {
+		return v
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range v {
+		switch r {
+		case '"', '\\', '$', '`':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func renderDotenv(sections []Section) ([]byte, error) {
+	var b strings.Builder
+	seen := keySet{}
+	for _, s := range sections {
+		for _, p := range s.Params {
+			key := dotenvKey(flatKey(s.Path, p.Key, "_"))
+			if err := seen.add(key, sourceKey(s.Path, p.Key)); err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(&b, "%s=%s\n", key, dotenvValue(p.Value))
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// --- Java properties ---
+
+// propertiesEscape follows java.util.Properties#store: separators and comment characters are
+// backslash-escaped, spaces only in keys or at the start of a value, and everything outside
+// printable ASCII becomes a \uXXXX escape so the file stays ISO-8859-1 safe.
+func propertiesEscape(s string, isKey bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case ' ':
+			if isKey || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(' ')
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		default:
+			if r < 0x20 || r > 0x7e {
+				for _, unit := range utf16.Encode([]rune{r}) {
+					fmt.Fprintf(&b, `\u%04X`, unit)
+				}
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+func renderProperties(sections []Section) ([]byte, error) {
+	var b strings.Builder
+	seen := keySet{}
+	for _, s := range sections {
+		for _, p := range s.Params {
+			key := flatKey(s.Path, p.Key, ".")
+			if err := seen.add(key, sourceKey(s.Path, p.Key)); err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(&b, "%s=%s\n", propertiesEscape(key, true), propertiesEscape(p.Value, false))
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// --- flat JSON ---
+
+func renderFlatJSON(sections []Section) ([]byte, error) {
+	out := map[string]string{}
+	seen := keySet{}
+	for _, s := range sections {
+		for _, p := range s.Params {
+			key := flatKey(s.Path, p.Key, ".")
+			if err := seen.add(key, sourceKey(s.Path, p.Key)); err != nil {
+				return nil, err
+			}
+			out[key] = p.Value
+		}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,184 @@
+package grpcserver
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/proto/configpb"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProtoParams(params []model.Parameter) []*configpb.Parameter {
+	if params == nil {
+		return nil
+	}
+	out := make([]*configpb.Parameter, len(params))
+	for i, p := range params {
+		out[i] = &configpb.Parameter{Key: p.Key, Value: p.Value}
+	}
+	return out
+}
+
+func fromProtoParams(params []*configpb.Parameter) []model.Parameter {
+	if params == nil {
+		return nil
+	}
+	out := make([]model.Parameter, len(params))
+	for i, p := range params {
+		out[i] = model.Parameter{Key: p.GetKey(), Value: p.GetValue()}
+	}
+	return out
+}
+
+func toProtoRef(ref *model.ConfigurationRef) *configpb.ConfigurationRef {
+	if ref == nil {
+		return nil
+	}
+	return &configpb.ConfigurationRef{Name: ref.Name, Version: ref.Version}
+}
+
+func fromProtoRef(ref *configpb.ConfigurationRef) *model.ConfigurationRef {
+	if ref == nil {
+		return nil
+	}
+	return &model.ConfigurationRef{Name: ref.GetName(), Version: ref.GetVersion()}
+}
+
+// toTimestamp returns nil for the zero time, which the model uses for "not set".
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func toOptionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromOptionalTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func toProtoMetadata(m model.Metadata) *configpb.Metadata {
+	return &configpb.Metadata{
+		CreatedAt:  toTimestamp(m.CreatedAt),
+		CreatedBy:  m.CreatedBy,
+		UpdatedAt:  toTimestamp(m.UpdatedAt),
+		UpdatedBy:  m.UpdatedBy,
+		Revision:   m.Revision,
+		ClonedFrom: toProtoRef(m.ClonedFrom),
+	}
+}
+
+func fromProtoMetadata(m *configpb.Metadata) model.Metadata {
+	return model.Metadata{
+		CreatedAt:  fromTimestamp(m.GetCreatedAt()),
+		CreatedBy:  m.GetCreatedBy(),
+		UpdatedAt:  fromTimestamp(m.GetUpdatedAt()),
+		UpdatedBy:  m.GetUpdatedBy(),
+		Revision:   m.GetRevision(),
+		ClonedFrom: fromProtoRef(m.GetClonedFrom()),
+	}
+}
+
+func toProtoConfiguration(c model.Configuration) *configpb.Configuration {
+	return &configpb.Configuration{
+		Id:           c.ID.String(),
+		Name:         c.Name,
+		Version:      c.Version,
+		Params:       toProtoParams(c.Params),
+		Labels:       toProtoParams(c.Labels),
+		Parent:       toProtoRef(c.Parent),
+		RemoveParams: c.RemoveParams,
+		Description:  c.Description,
+		State:        string(c.CurrentState()),
+		DeprecatedAt: toOptionalTimestamp(c.DeprecatedAt),
+		SunsetAt:     toOptionalTimestamp(c.SunsetAt),
+		Metadata:     toProtoMetadata(c.Metadata),
+	}
+}
+
+// fromProtoConfiguration converts a group member sent by a client; an ID that is not a
+// UUID is left unset.
+func fromProtoConfiguration(c *configpb.Configuration) model.Configuration {
+	id, _ := uuid.Parse(c.GetId())
+	return model.Configuration{
+		ID:           id,
+		Name:         c.GetName(),
+		Version:      c.GetVersion(),
+		Params:       fromProtoParams(c.GetParams()),
+		Labels:       fromProtoParams(c.GetLabels()),
+		Parent:       fromProtoRef(c.GetParent()),
+		RemoveParams: c.GetRemoveParams(),
+		Description:  c.GetDescription(),
+		Lifecycle: model.Lifecycle{
+			State:        model.LifecycleState(c.GetState()),
+			DeprecatedAt: fromOptionalTimestamp(c.GetDeprecatedAt()),
+			SunsetAt:     fromOptionalTimestamp(c.GetSunsetAt()),
+		},
+		Metadata: fromProtoMetadata(c.GetMetadata()),
+	}
+}
+
+func toProtoConfigurations(configs []model.Configuration) []*configpb.Configuration {
+	out := make([]*configpb.Configuration, len(configs))
+	for i, c := range configs {
+		out[i] = toProtoConfiguration(c)
+	}
+	return out
+}
+
+func toProtoGroup(g model.ConfigurationGroup) *configpb.ConfigurationGroup {
+	return &configpb.ConfigurationGroup{
+		Id:             g.ID.String(),
+		Name:           g.Name,
+		Version:        g.Version,
+		Configurations: toProtoConfigurations(g.Configurations),
+		Description:    g.Description,
+		State:          string(g.CurrentState()),
+		DeprecatedAt:   toOptionalTimestamp(g.DeprecatedAt),
+		SunsetAt:       toOptionalTimestamp(g.SunsetAt),
+		Metadata:       toProtoMetadata(g.Metadata),
+	}
+}
+
+func configurationFromRequest(req *configpb.CreateConfigurationRequest) model.Configuration {
+	return model.Configuration{
+		Name:         req.GetName(),
+		Version:      req.GetVersion(),
+		Params:       fromProtoParams(req.GetParams()),
+		Labels:       fromProtoParams(req.GetLabels()),
+		Parent:       fromProtoRef(req.GetParent()),
+		RemoveParams: req.GetRemoveParams(),
+		Description:  req.GetDescription(),
+	}
+}
+
+func groupFromRequest(req *configpb.CreateGroupRequest) model.ConfigurationGroup {
+	members := make([]model.Configuration, len(req.GetConfigurations()))
+	for i, c := range req.GetConfigurations() {
+		members[i] = fromProtoConfiguration(c)
+	}
+	return model.ConfigurationGroup{
+		Name:           req.GetName(),
+		Version:        req.GetVersion(),
+		Configurations: members,
+		Description:    req.GetDescription(),
+	}
+}
@@ -0,0 +1,51 @@
+package grpcserver
+
+import (
+	"alati_projekat/services"
+	"alati_projekat/validation"
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps a service error to the gRPC status matching the REST API's HTTP status.
+// Validation errors carry every violation as a BadRequest detail.
+func toStatus(err error, kind string) error {
+	var verr *validation.Error
+	if errors.As(err, &verr) {
+		st := status.New(codes.InvalidArgument, "the request contains invalid fields")
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(verr.Violations))
+		for i, v := range verr.Violations {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Message}
+		}
+		if detailed, derr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); derr == nil {
+			st = detailed
+		}
+		return st.Err()
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, services.ErrInvalidVersion), errors.Is(err, services.ErrInvalidVersionSelector):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrImmutable),
+		errors.Is(err, services.ErrInvalidParent),
+		errors.Is(err, services.ErrInheritanceCycle):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, services.ErrRevisionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case strings.Contains(err.Error(), "already exists"):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		return status.Error(codes.NotFound, kind+" not found")
+	default:
+		log.Printf("gRPC internal error: %v", err)
+		return status.Error(codes.Internal, "the server encountered an unexpected error")
+	}
+}
@@ -0,0 +1,55 @@
+package grpcserver
+
+import (
+	"alati_projekat/actor"
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys read by the server; they match the REST headers.
+const (
+	ActorMetadata          = "x-actor"
+	IdempotencyKeyMetadata = "x-request-id"
+)
+
+// metadataValue returns the first value of key in the incoming metadata of ctx.
+func metadataValue(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// withActor stores the x-actor metadata as the caller identity, unless an authentication
+// layer already set one, like middleware.ActorMiddleware does for REST.
+func withActor(ctx context.Context) context.Context {
+	if _, ok := actor.Lookup(ctx); ok {
+		return ctx
+	}
+	if name := metadataValue(ctx, ActorMetadata); name != "" {
+		return actor.NewContext(ctx, name)
+	}
+	return ctx
+}
+
+func ActorUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withActor(ctx), req)
+}
+
+func ActorStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &actorStream{ServerStream: ss, ctx: withActor(ss.Context())})
+}
+
+// actorStream replaces the context of a server stream.
+type actorStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,290 @@
+// Package grpcserver serves the configuration service over gRPC, on top of the same
+// services.Service chain as the REST handlers.
+package grpcserver
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/proto/configpb"
+	"alati_projekat/semver"
+	"alati_projekat/services"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Server implements configpb.ConfigServiceServer.
+type Server struct {
+	configpb.UnimplementedConfigServiceServer
+	Service services.Service
+}
+
+func NewServer(service services.Service) *Server {
+	return &Server{
+		Service: service,
+	}
+}
+
+// NewGRPCServer returns a gRPC server with the configuration service and the actor
+// interceptors registered.
+func NewGRPCServer(service services.Service, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(ActorUnaryInterceptor),
+		grpc.ChainStreamInterceptor(ActorStreamInterceptor),
+	}, opts...)
+	srv := grpc.NewServer(opts...)
+	configpb.RegisterConfigServiceServer(srv, NewServer(service))
+	return srv
+}
+
+var _ configpb.ConfigServiceServer = (*Server)(nil)
+
+func requireRef(name, version string) error {
+	if name == "" || version == "" {
+		return status.Error(codes.InvalidArgument, "name and version are required")
+	}
+	return nil
+}
+
+// checkIdempotency reports whether the request carries an idempotency key that was already
+// processed, in which case the mutation must not be repeated.
+func (s *Server) checkIdempotency(ctx context.Context) (key string, processed bool, err error) {
+	key = metadataValue(ctx, IdempotencyKeyMetadata)
+	if key == "" {
+		return "", false, nil
+	}
+	processed, err = s.Service.CheckIdempotencyKey(ctx, key)
+	if err != nil {
+		return "", false, status.Error(codes.Unavailable, "the idempotency key could not be checked")
+	}
+	return key, processed, nil
+}
+
+// --- CONFIGURATIONS ---
+
+func (s *Server) AddConfiguration(ctx context.Context, req *configpb.CreateConfigurationRequest) (*configpb.Configuration, error) {
+	key, processed, err := s.checkIdempotency(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if processed {
+		return nil, status.Errorf(codes.AlreadyExists, "request with key %s was already processed", key)
+	}
+
+	config := configurationFromRequest(req)
+	config.ID = uuid.New()
+	created, err := s.Service.AddConfiguration(ctx, config, key)
+	if err != nil {
+		return nil, toStatus(err, "configuration")
+	}
+	return toProtoConfiguration(created), nil
+}
+
+// GetConfiguration also accepts version selectors such as "latest" or "^1.2", like the
+// REST API; prereleases are not considered.
+func (s *Server) GetConfiguration(ctx context.Context, req *configpb.GetConfigurationRequest) (*configpb.Configuration, error) {
+	if err := requireRef(req.GetName(), req.GetVersion()); err != nil {
+		return nil, err
+	}
+	var config model.Configuration
+	var err error
+	if semver.IsSelector(req.GetVersion()) {
+		config, err = s.Service.ResolveConfigurationVersion(ctx, req.GetName(), req.GetVersion(), false)
+	} else {
+		config, err = s.Service.GetConfiguration(ctx, req.GetName(), req.GetVersion())
+	}
+	if err != nil {
+		return nil, toStatus(err, "configuration")
+	}
+	return toProtoConfiguration(config), nil
+}
+
+// UpdateConfiguration returns the stored configuration without changing it when the
+// idempotency key was already processed.
+func (s *Server) UpdateConfiguration(ctx context.Context, req *configpb.CreateConfigurationRequest) (*configpb.Configuration, error) {
+	key, processed, err := s.checkIdempotency(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var updated model.Configuration
+	if processed {
+		updated, err = s.Service.GetConfiguration(ctx, req.GetName(), req.GetVersion())
+	} else {
+		updated, err = s.Service.UpdateConfiguration(ctx, configurationFromRequest(req), key)
+	}
+	if err != nil {
+		return nil, toStatus(err, "configuration")
+	}
+	return toProtoConfiguration(updated), nil
+}
+
+func (s *Server) DeleteConfiguration(ctx context.Context, req *configpb.DeleteConfigurationRequest) (*emptypb.Empty, error) {
+	if err := requireRef(req.GetName(), req.GetVersion()); err != nil {
+		return nil, err
+	}
+	if err := s.Service.DeleteConfiguration(ctx, req.GetName(), req.GetVersion()); err != nil {
+		return nil, toStatus(err, "configuration")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) WatchConfiguration(req *configpb.WatchRequest, stream grpc.ServerStreamingServer[configpb.WatchConfigurationResponse]) error {
+	return watch(stream.Context(), req, s.Service.WatchConfiguration, "configuration",
+		func(c model.Configuration, index uint64, deleted bool) error {
+			resp := &configpb.WatchConfigurationResponse{Index: index, Deleted: deleted}
+			if !deleted {
+				resp.Configuration = toProtoConfiguration(c)
+			}
+			return stream.Send(resp)
+		})
+}
+
+// --- CONFIGURATION GROUPS ---
+
+func (s *Server) AddConfigurationGroup(ctx context.Context, req *configpb.CreateGroupRequest) (*configpb.ConfigurationGroup, error) {
+	key, processed, err := s.checkIdempotency(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if processed {
+		return nil, status.Errorf(codes.AlreadyExists, "request with key %s was already processed", key)
+	}
+
+	group := groupFromRequest(req)
+	group.ID = uuid.New()
+	created, err := s.Service.AddConfigurationGroup(ctx, group, key)
+	if err != nil {
+		return nil, toStatus(err, "configuration group")
+	}
+	return toProtoGroup(created), nil
+}
+
+// GetConfigurationGroup also accepts version selectors, like GetConfiguration.
+func (s *Server) GetConfigurationGroup(ctx context.Context, req *configpb.GetConfigurationGroupRequest) (*configpb.ConfigurationGroup, error) {
+	if err := requireRef(req.GetName(), req.GetVersion()); err != nil {
+		return nil, err
+	}
+	var group model.ConfigurationGroup
+	var err error
+	if semver.IsSelector(req.GetVersion()) {
+		group, err = s.Service.ResolveConfigurationGroupVersion(ctx, req.GetName(), req.GetVersion(), false)
+	} else {
+		group, err = s.Service.GetConfigurationGroup(ctx, req.GetName(), req.GetVersion())
+	}
+	if err != nil {
+		return nil, toStatus(err, "configuration group")
+	}
+	return toProtoGroup(group), nil
+}
+
+// UpdateConfigurationGroup returns the stored group without changing it when the
+// idempotency key was already processed.
+func (s *Server) UpdateConfigurationGroup(ctx context.Context, req *configpb.CreateGroupRequest) (*configpb.ConfigurationGroup, error) {
+	key, processed, err := s.checkIdempotency(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var updated model.ConfigurationGroup
+	if processed {
+		updated, err = s.Service.GetConfigurationGroup(ctx, req.GetName(), req.GetVersion())
+	} else {
+		updated, err = s.Service.UpdateConfigurationGroup(ctx, groupFromRequest(req), key)
+	}
+	if err != nil {
+		return nil, toStatus(err, "configuration group")
+	}
+	return toProtoGroup(updated), nil
+}
+
+func (s *Server) DeleteConfigurationGroup(ctx context.Context, req *configpb.DeleteConfigurationGroupRequest) (*emptypb.Empty, error) {
+	if err := requireRef(req.GetName(), req.GetVersion()); err != nil {
+		return nil, err
+	}
+	if err := s.Service.DeleteConfigurationGroup(ctx, req.GetName(), req.GetVersion()); err != nil {
+		return nil, toStatus(err, "configuration group")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) WatchConfigurationGroup(req *configpb.WatchRequest, stream grpc.ServerStreamingServer[configpb.WatchConfigurationGroupResponse]) error {
+	return watch(stream.Context(), req, s.Service.WatchConfigurationGroup, "configuration group",
+		func(g model.ConfigurationGroup, index uint64, deleted bool) error {
+			resp := &configpb.WatchConfigurationGroupResponse{Index: index, Deleted: deleted}
+			if !deleted {
+				resp.Group = toProtoGroup(g)
+			}
+			return stream.Send(resp)
+		})
+}
+
+// --- LABELS ---
+
+func (s *Server) FilterConfigsByLabels(ctx context.Context, req *configpb.LabelSelectorRequest) (*configpb.FilterConfigsByLabelsResponse, error) {
+	if err := requireRef(req.GetName(), req.GetVersion()); err != nil {
+		return nil, err
+	}
+	configs, err := s.Service.FilterConfigsByLabels(ctx, req.GetName(), req.GetVersion(), req.GetLabels())
+	if err != nil {
+		return nil, toStatus(err, "configuration group")
+	}
+	return &configpb.FilterConfigsByLabelsResponse{Configurations: toProtoConfigurations(configs)}, nil
+}
+
+// DeleteConfigsByLabels refuses an empty selector, which would empty the whole group.
+func (s *Server) DeleteConfigsByLabels(ctx context.Context, req *configpb.LabelSelectorRequest) (*configpb.DeleteConfigsByLabelsResponse, error) {
+	if err := requireRef(req.GetName(), req.GetVersion()); err != nil {
+		return nil, err
+	}
+	if len(req.GetLabels()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one label is required")
+	}
+	deleted, err := s.Service.DeleteConfigsByLabels(ctx, req.GetName(), req.GetVersion(), req.GetLabels())
+	if err != nil {
+		return nil, toStatus(err, "configuration group")
+	}
+	return &configpb.DeleteConfigsByLabelsResponse{Deleted: int32(deleted)}, nil
+}
+
+// watchFunc is services.Service.WatchConfiguration or WatchConfigurationGroup.
+type watchFunc[T any] func(ctx context.Context, name, version string, index uint64, wait time.Duration) (T, uint64, error)
+
+// watch streams the entity from req.Index on: every blocking query that returns a new index
+// is sent, with deleted set when the entity no longer exists. An entity that is missing
+// when the watch starts is reported as NotFound.
+func watch[T any](ctx context.Context, req *configpb.WatchRequest, fetch watchFunc[T], kind string, send func(v T, index uint64, deleted bool) error) error {
+	if err := requireRef(req.GetName(), req.GetVersion()); err != nil {
+		return err
+	}
+	if semver.IsSelector(req.GetVersion()) {
+		return status.Error(codes.InvalidArgument, "watching requires an exact version")
+	}
+
+	index := req.GetIndex()
+	first := true
+	for {
+		v, next, err := fetch(ctx, req.GetName(), req.GetVersion(), index, services.MaxWatchWait)
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		deleted := err != nil && strings.Contains(err.Error(), "not found")
+		switch {
+		case err != nil && !deleted:
+			return toStatus(err, kind)
+		case deleted && first:
+			return toStatus(err, kind)
+		}
+		first = false
+		if next == index {
+			continue
+		}
+		index = next
+		if err := send(v, index, deleted); err != nil {
+			return err
+		}
+	}
+}
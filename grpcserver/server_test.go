@@ -0,0 +1,336 @@
+package grpcserver
+
+import (
+	"alati_projekat/actor"
+	"alati_projekat/labels"
+	"alati_projekat/model"
+	"alati_projekat/proto/configpb"
+	"alati_projekat/services"
+	"alati_projekat/validation"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeService čuva podatke u memoriji; metode koje gRPC server ne koristi ostaju nil
+type fakeService struct {
+	services.Service
+
+	mu              sync.Mutex
+	configs         map[string]model.Configuration
+	groups          map[string]model.ConfigurationGroup
+	idempotencyKeys map[string]bool
+	index           uint64
+	changed         chan struct{}
+}
+
+func newFakeService() *fakeService {
+	return &fakeService{
+		configs:         make(map[string]model.Configuration),
+		groups:          make(map[string]model.ConfigurationGroup),
+		idempotencyKeys: make(map[string]bool),
+		index:           1,
+		changed:         make(chan struct{}),
+	}
+}
+
+// touch budi sve watch pozive; poziva se pod s.mu
+func (s *fakeService) touch() {
+	s.index++
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *fakeService) CheckIdempotencyKey(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.idempotencyKeys[key], nil
+}
+
+func (s *fakeService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error) {
+	if err := validation.Configuration(config); err != nil {
+		return model.Configuration{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := config.Name + "/" + config.Version
+	if _, exists := s.configs[key]; exists {
+		return model.Configuration{}, errors.New("configuration already exists")
+	}
+	config.Metadata = model.Metadata{Revision: 1, CreatedBy: actor.FromContext(ctx)}
+	s.configs[key] = config
+	if idempotencyKey != "" {
+		s.idempotencyKeys[idempotencyKey] = true
+	}
+	s.touch()
+	return config, nil
+}
+
+func (s *fakeService) GetConfiguration(ctx context.Context, name, version string) (model.Configuration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	config, exists := s.configs[name+"/"+version]
+	if !exists {
+		return model.Configuration{}, errors.New("configuration not found")
+	}
+	return config, nil
+}
+
+func (s *fakeService) UpdateConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := config.Name + "/" + config.Version
+	existing, exists := s.configs[key]
+	if !exists {
+		return model.Configuration{}, errors.New("configuration not found")
+	}
+	config.ID, config.Metadata = existing.ID, existing.Metadata
+	config.Metadata.Revision++
+	s.configs[key] = config
+	s.touch()
+	return config, nil
+}
+
+func (s *fakeService) DeleteConfiguration(ctx context.Context, name, version string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.configs[name+"/"+version]; !exists {
+		return errors.New("configuration not found")
+	}
+	delete(s.configs, name+"/"+version)
+	s.touch()
+	return nil
+}
+
+// WatchConfiguration blokira kao Consul upit: do promene posle index, isteka wait ili otkazivanja
+func (s *fakeService) WatchConfiguration(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.Configuration, uint64, error) {
+	timeout := time.After(wait)
+	for {
+		s.mu.Lock()
+		current, changed := s.index, s.changed
+		s.mu.Unlock()
+		if index == 0 || current != index {
+			config, err := s.GetConfiguration(ctx, name, version)
+			return config, current, err
+		}
+		select {
+		case <-ctx.Done():
+			return model.Configuration{}, index, ctx.Err()
+		case <-timeout:
+			config, err := s.GetConfiguration(ctx, name, version)
+			return config, index, err
+		case <-changed:
+		}
+	}
+}
+
+func (s *fakeService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (model.ConfigurationGroup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups[group.Name+"/"+group.Version] = group
+	return group, nil
+}
+
+func (s *fakeService) FilterConfigsByLabels(ctx context.Context, name, version string, want map[string]string) ([]model.Configuration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	group, exists := s.groups[name+"/"+version]
+	if !exists {
+		return nil, errors.New("configuration group not found")
+	}
+	var out []model.Configuration
+	for _, c := range group.Configurations {
+		if labels.HasAll(c, want) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeService) DeleteConfigsByLabels(ctx context.Context, name, version string, want map[string]string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	group, exists := s.groups[name+"/"+version]
+	if !exists {
+		return 0, errors.New("configuration group not found")
+	}
+	kept := group.Configurations[:0]
+	for _, c := range group.Configurations {
+		if !labels.HasAll(c, want) {
+			kept = append(kept, c)
+		}
+	}
+	deleted := len(group.Configurations) - len(kept)
+	group.Configurations = kept
+	s.groups[name+"/"+version] = group
+	return deleted, nil
+}
+
+// newTestClient pokreće gRPC server preko bufconn-a i vraća klijenta za njega
+func newTestClient(t *testing.T, service services.Service) configpb.ConfigServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := NewGRPCServer(service)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return configpb.NewConfigServiceClient(conn)
+}
+
+func TestServer_ConfigurationCRUD(t *testing.T) {
+	client := newTestClient(t, newFakeService())
+	ctx := metadata.AppendToOutgoingContext(context.Background(), ActorMetadata, "alice")
+
+	created, err := client.AddConfiguration(ctx, &configpb.CreateConfigurationRequest{
+		Name:    "service-api",
+		Version: "v1",
+		Params:  []*configpb.Parameter{{Key: "timeout", Value: "30s"}},
+		Labels:  []*configpb.Parameter{{Key: "env", Value: "prod"}},
+	})
+	if err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	if created.GetId() == "" || created.GetState() != "draft" || created.GetMetadata().GetCreatedBy() != "alice" {
+		t.Errorf("Expected an id, draft state and the actor from metadata, got %+v", created)
+	}
+
+	got, err := client.GetConfiguration(ctx, &configpb.GetConfigurationRequest{Name: "service-api", Version: "v1"})
+	if err != nil || got.GetParams()[0].GetValue() != "30s" || got.GetLabels()[0].GetKey() != "env" {
+		t.Errorf("Unexpected configuration %+v (%v)", got, err)
+	}
+
+	updated, err := client.UpdateConfiguration(ctx, &configpb.CreateConfigurationRequest{Name: "service-api", Version: "v1", Params: []*configpb.Parameter{{Key: "timeout", Value: "60s"}}})
+	if err != nil || updated.GetMetadata().GetRevision() != 2 {
+		t.Errorf("Expected revision 2 after update, got %+v (%v)", updated.GetMetadata(), err)
+	}
+
+	if _, err := client.DeleteConfiguration(ctx, &configpb.DeleteConfigurationRequest{Name: "service-api", Version: "v1"}); err != nil {
+		t.Fatalf("DeleteConfiguration failed: %v", err)
+	}
+	_, err = client.GetConfiguration(ctx, &configpb.GetConfigurationRequest{Name: "service-api", Version: "v1"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound after delete, got %v", err)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	client := newTestClient(t, newFakeService())
+	ctx := context.Background()
+	req := &configpb.CreateConfigurationRequest{Name: "service-api", Version: "v1"}
+	if _, err := client.AddConfiguration(ctx, req); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+
+	if _, err := client.AddConfiguration(ctx, req); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for a duplicate, got %v", err)
+	}
+	if _, err := client.GetConfiguration(ctx, &configpb.GetConfigurationRequest{Name: "service-api"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a version, got %v", err)
+	}
+	if _, err := client.DeleteConfigsByLabels(ctx, &configpb.LabelSelectorRequest{Name: "cluster", Version: "v1"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an empty selector, got %v", err)
+	}
+
+	_, err := client.AddConfiguration(ctx, &configpb.CreateConfigurationRequest{Name: "bad name", Version: "v1", Params: []*configpb.Parameter{{Key: "", Value: "x"}}})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument || len(st.Details()) != 1 {
+		t.Fatalf("Expected InvalidArgument with details, got %v", err)
+	}
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok || len(badRequest.GetFieldViolations()) != 2 {
+		t.Errorf("Expected violations for name and params[0].key, got %+v", st.Details())
+	}
+
+	// Ponovljen idempotency ključ se ne izvršava ponovo
+	keyed := metadata.AppendToOutgoingContext(ctx, IdempotencyKeyMetadata, "req-1")
+	if _, err := client.AddConfiguration(keyed, &configpb.CreateConfigurationRequest{Name: "worker", Version: "v1"}); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	if _, err := client.AddConfiguration(keyed, &configpb.CreateConfigurationRequest{Name: "worker", Version: "v2"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for a processed key, got %v", err)
+	}
+}
+
+func TestServer_Labels(t *testing.T) {
+	service := newFakeService()
+	service.groups["cluster/v1"] = model.ConfigurationGroup{Name: "cluster", Version: "v1", Configurations: []model.Configuration{
+		{Name: "api", Version: "v1", Labels: []model.Parameter{{Key: "env", Value: "prod"}}},
+		{Name: "worker", Version: "v1", Labels: []model.Parameter{{Key: "env", Value: "dev"}}},
+	}}
+	client := newTestClient(t, service)
+	ctx := context.Background()
+	selector := &configpb.LabelSelectorRequest{Name: "cluster", Version: "v1", Labels: map[string]string{"env": "prod"}}
+
+	filtered, err := client.FilterConfigsByLabels(ctx, selector)
+	if err != nil || len(filtered.GetConfigurations()) != 1 || filtered.GetConfigurations()[0].GetName() != "api" {
+		t.Errorf("Expected only api, got %+v (%v)", filtered, err)
+	}
+	deleted, err := client.DeleteConfigsByLabels(ctx, selector)
+	if err != nil || deleted.GetDeleted() != 1 {
+		t.Errorf("Expected 1 deleted, got %+v (%v)", deleted, err)
+	}
+	missing := &configpb.LabelSelectorRequest{Name: "missing", Version: "v1", Labels: map[string]string{"env": "prod"}}
+	if _, err := client.FilterConfigsByLabels(ctx, missing); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a missing group, got %v", err)
+	}
+}
+
+func TestServer_WatchConfiguration(t *testing.T) {
+	service := newFakeService()
+	client := newTestClient(t, service)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.AddConfiguration(ctx, &configpb.CreateConfigurationRequest{Name: "service-api", Version: "v1"}); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	stream, err := client.WatchConfiguration(ctx, &configpb.WatchRequest{Name: "service-api", Version: "v1"})
+	if err != nil {
+		t.Fatalf("WatchConfiguration failed: %v", err)
+	}
+
+	first, err := stream.Recv()
+	if err != nil || first.GetConfiguration().GetMetadata().GetRevision() != 1 || first.GetIndex() == 0 {
+		t.Fatalf("Expected the current state first, got %+v (%v)", first, err)
+	}
+
+	client.UpdateConfiguration(ctx, &configpb.CreateConfigurationRequest{Name: "service-api", Version: "v1"})
+	second, err := stream.Recv()
+	if err != nil || second.GetConfiguration().GetMetadata().GetRevision() != 2 || second.GetIndex() <= first.GetIndex() {
+		t.Fatalf("Expected revision 2 at a later index, got %+v (%v)", second, err)
+	}
+
+	client.DeleteConfiguration(ctx, &configpb.DeleteConfigurationRequest{Name: "service-api", Version: "v1"})
+	third, err := stream.Recv()
+	if err != nil || !third.GetDeleted() || third.GetConfiguration() != nil {
+		t.Fatalf("Expected a delete notification, got %+v (%v)", third, err)
+	}
+
+	missing, _ := client.WatchConfiguration(ctx, &configpb.WatchRequest{Name: "service-api", Version: "v1"})
+	if _, err := missing.Recv(); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound when watching a missing configuration, got %v", err)
+	}
+	selector, _ := client.WatchConfiguration(ctx, &configpb.WatchRequest{Name: "service-api", Version: "latest"})
+	if _, err := selector.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a version selector, got %v", err)
+	}
+}
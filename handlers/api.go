@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Middleware wraps a route handler, e.g. with a rate limiter.
+type Middleware func(http.Handler) http.Handler
+
+// API holds the handlers and route middleware shared by every version of the REST API.
+// Each version mounts its routes with its own Register method, so a new version can change
+// request and response models in its own handlers while reusing the same services.
+type API struct {
+	Config   *ConfigHandler
+	Events   *EventsHandler
+	Webhooks *WebhookHandler
+
+	// ReadLimit and WriteLimit rate-limit reads and writes; nil means no limit.
+	ReadLimit  Middleware
+	WriteLimit Middleware
+}
+
+func (a *API) read(h http.HandlerFunc) http.Handler {
+	if a.ReadLimit == nil {
+		return h
+	}
+	return a.ReadLimit(h)
+}
+
+func (a *API) write(h http.HandlerFunc) http.Handler {
+	if a.WriteLimit == nil {
+		return h
+	}
+	return a.WriteLimit(h)
+}
+
+// RegisterAdmin mounts the unversioned administration routes on r.
+func (a *API) RegisterAdmin(r *mux.Router) {
+	// GET /admin/export
+	r.Handle("/admin/export", a.read(a.Config.HandleExport)).Methods("GET")
+	// POST /admin/import
+	r.Handle("/admin/import", a.write(a.Config.HandleImport)).Methods("POST")
+}
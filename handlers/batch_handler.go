@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/problem"
+	"alati_projekat/services"
+	"alati_projekat/validation"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// operationStatus maps the error of a single operation to the status it would have had
+// as a separate request.
+func operationStatus(op model.BatchAction, err error) int {
+	switch {
+	case err == nil && op == model.BatchCreate:
+		return http.StatusCreated
+	case err == nil && op == model.BatchDelete:
+		return http.StatusNoContent
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, services.ErrValidation), errors.Is(err, services.ErrInvalidVersion):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrImmutable), errors.Is(err, services.ErrRevisionConflict), strings.Contains(err.Error(), "already exists"):
+		return http.StatusConflict
+	case isInheritanceError(err):
+		return http.StatusUnprocessableEntity
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// HandleBatch godoc
+// @Summary Izvršava više operacija odjednom
+// @Description Prima listu create/update/delete operacija nad konfiguracijama i grupama. U "atomic" režimu (podrazumevano, najviše 64 operacije) sve operacije se upisuju jednom Consul transakcijom ili se ne upisuje nijedna. U "best-effort" režimu operacije se izvršavaju redom, a ishod svake je u rezultatima. Jedan X-Request-Id pokriva ceo batch.
+// @Tags batch
+// @Accept json
+// @Produce json
+// @Param X-Request-Id header string false "Idempotency Key (UUID/jedinstveni ID)"
+// @Param batch body model.BatchRequest true "Operacije"
+// @Success 200 {object} model.BatchResponse
+// @Failure 400 {object} problem.Problem "Invalid batch or, in atomic mode, an invalid operation"
+// @Failure 404 {object} problem.Problem "Atomic mode: an updated or deleted entity does not exist"
+// @Failure 409 {object} problem.Problem "Atomic mode: conflict or concurrent modification"
+// @Failure 413 {object} problem.Problem "Request body too large"
+// @Failure 422 {object} problem.Problem "Atomic mode: invalid parent or inheritance cycle"
+// @Router /batch [post]
+func (h *ConfigHandler) HandleBatch(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleBatch")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	var req model.BatchRequest
+	if !decodeJSONLimit(w, r, validation.MaxBatchBodySize, &req) {
+		return
+	}
+
+	resp, err := h.Service.ExecuteBatch(ctx, req, r.Header.Get("X-Request-Id"))
+	if err != nil {
+		writeBatchError(w, r, err)
+		return
+	}
+
+	for i := range resp.Results {
+		result := &resp.Results[i]
+		result.Status = operationStatus(result.Op, result.Err)
+		switch {
+		case result.Status == http.StatusInternalServerError:
+			log.Printf("Batch operation %d failed: %v", result.Index, result.Err)
+			result.Error = "internal error"
+		case result.Err != nil:
+			result.Error = result.Err.Error()
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// writeBatchError reports a rejected batch. For atomic batches the failing operation is
+// named in the problem's field errors.
+func writeBatchError(w http.ResponseWriter, r *http.Request, err error) {
+	var batchErr *services.BatchError
+	if !errors.As(err, &batchErr) {
+		if writeValidationError(w, r, http.StatusBadRequest, err) {
+			return
+		}
+		if errors.Is(err, services.ErrRevisionConflict) {
+			problem.Write(w, r, http.StatusConflict, "The batch was not applied: "+err.Error())
+			return
+		}
+		problem.Internal(w, r, err)
+		return
+	}
+
+	field := fmt.Sprintf("operations[%d]", batchErr.Index)
+	payload := field + ".configuration."
+	if batchErr.Kind == model.BatchGroup {
+		payload = field + ".group."
+	}
+	status := operationStatus(batchErr.Op, batchErr.Err)
+	if writeValidationErrorAt(w, r, status, payload, batchErr.Err) {
+		return
+	}
+	if status == http.StatusInternalServerError {
+		problem.Internal(w, r, err)
+		return
+	}
+	problem.WriteProblem(w, r, problem.Problem{
+		Status: status,
+		Detail: "The batch was not applied: " + err.Error(),
+		Errors: []problem.FieldError{{Field: field, Message: batchErr.Err.Error()}},
+	})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/problem"
+	"alati_projekat/services"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+func writeCloneError(w http.ResponseWriter, r *http.Request, kind string, err error) {
+	if writeValidationError(w, r, http.StatusBadRequest, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, services.ErrInvalidVersion):
+		problem.Write(w, r, http.StatusBadRequest, err.Error())
+	case isInheritanceError(err):
+		problem.Write(w, r, http.StatusUnprocessableEntity, err.Error())
+	case strings.Contains(err.Error(), "already exists"):
+		problem.Write(w, r, http.StatusConflict, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		problem.Write(w, r, http.StatusNotFound, kind+" not found.")
+	default:
+		problem.Internal(w, r, err)
+	}
+}
+
+// cloneLocation returns the URL of the copy, next to the source in the same collection.
+func cloneLocation(r *http.Request, name, version string) string {
+	vars := mux.Vars(r)
+	collection := strings.TrimSuffix(r.URL.Path, "/"+vars["name"]+"/"+vars["version"]+"/clone")
+	return collection + "/" + name + "/" + version
+}
+
+// HandleCloneConfiguration godoc
+// @Summary Klonira konfiguraciju
+// @Description Pravi draft kopiju konfiguracije pod novom verzijom (i opciono novim imenom), uz izmenu ili uklanjanje parametara i labela, npr. promenu labele env pri promociji sa staging na prod. Izvor se beleži u metadata.clonedFrom.
+// @Tags configurations
+// @Accept json
+// @Produce json
+// @Param X-Request-Id header string false "Idempotency Key (UUID/jedinstveni ID)"
+// @Param name path string true "Ime izvorne konfiguracije"
+// @Param version path string true "Verzija izvorne konfiguracije"
+// @Param clone body model.CloneRequest true "Ciljna verzija i izmene"
+// @Success 201 {object} model.Configuration
+// @Failure 400 {object} problem.Problem "Invalid request body, field validation errors or non-semver version in strict mode"
+// @Failure 404 {object} problem.Problem "Configuration not found"
+// @Failure 409 {object} problem.Problem "Target configuration already exists"
+// @Failure 413 {object} problem.Problem "Request body too large"
+// @Failure 422 {object} problem.Problem "Invalid parent or inheritance cycle"
+// @Router /configurations/{name}/{version}/clone [post]
+func (h *ConfigHandler) HandleCloneConfiguration(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleCloneConfiguration")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	vars := mux.Vars(r)
+	name, version := vars["name"], vars["version"]
+	if name == "" || version == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
+		return
+	}
+
+	var req model.CloneRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	clone, err := h.Service.CloneConfiguration(ctx, name, version, req, r.Header.Get("X-Request-Id"))
+	if err != nil {
+		writeCloneError(w, r, "Configuration", err)
+		return
+	}
+
+	w.Header().Set("Location", cloneLocation(r, clone.Name, clone.Version))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(clone)
+}
+
+// HandleCloneConfigurationGroup godoc
+// @Summary Klonira grupu konfiguracija
+// @Description Pravi draft kopiju grupe pod novom verzijom (i opciono novim imenom). Kopiraju se svi članovi, a izmene parametara i labela primenjuju se na svakog člana. Izvor se beleži u metadata.clonedFrom.
+// @Tags configuration_groups
+// @Accept json
+// @Produce json
+// @Param X-Request-Id header string false "Idempotency Key (UUID/jedinstveni ID)"
+// @Param name path string true "Ime izvorne grupe"
+// @Param version path string true "Verzija izvorne grupe"
+// @Param clone body model.CloneRequest true "Ciljna verzija i izmene članova"
+// @Success 201 {object} model.ConfigurationGroup
+// @Failure 400 {object} problem.Problem "Invalid request body, field validation errors or non-semver version in strict mode"
+// @Failure 404 {object} problem.Problem "Configuration group not found"
+// @Failure 409 {object} problem.Problem "Target group already exists"
+// @Failure 413 {object} problem.Problem "Request body too large"
+// @Router /configgroups/{name}/{version}/clone [post]
+func (h *ConfigHandler) HandleCloneConfigurationGroup(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleCloneConfigurationGroup")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	vars := mux.Vars(r)
+	name, version := vars["name"], vars["version"]
+	if name == "" || version == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
+		return
+	}
+
+	var req model.CloneRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	clone, err := h.Service.CloneConfigurationGroup(ctx, name, version, req, r.Header.Get("X-Request-Id"))
+	if err != nil {
+		writeCloneError(w, r, "Configuration group", err)
+		return
+	}
+
+	w.Header().Set("Location", cloneLocation(r, clone.Name, clone.Version))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(clone)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"alati_projekat/etag"
+	"alati_projekat/formats"
+	"alati_projekat/problem"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// writeJSONConditional writes v as JSON together with its validators. The ETag is the one
+// etag.Of computes for v, so it can be sent back in If-Match on PATCH.
+func writeJSONConditional(w http.ResponseWriter, r *http.Request, v any, lastModified time.Time) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		problem.Internal(w, r, err)
+		return
+	}
+	writeConditional(w, r, etag.OfBytes(data), "application/json", append(data, '\n'), lastModified)
+}
+
+// writeNegotiated writes v in the negotiated format: the JSON envelope itself, or the
+// parameter sections rendered as YAML, TOML, dotenv, properties or flat JSON.
+func writeNegotiated(w http.ResponseWriter, r *http.Request, format formats.Format, v any, sections []formats.Section, lastModified time.Time) {
+	w.Header().Add("Vary", "Accept")
+	if format == formats.JSON {
+		writeJSONConditional(w, r, v, lastModified)
+		return
+	}
+
+	body, err := formats.Render(format, sections)
+	if err != nil {
+		if errors.Is(err, formats.ErrKeyCollision) {
+			problem.Write(w, r, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		problem.Internal(w, r, err)
+		return
+	}
+	writeConditional(w, r, etag.OfBytes(body), format.ContentType(), body, lastModified)
+}
+
+// writeConditional writes body with a strong ETag and, when lastModified is known,
+// Last-Modified. If the request's If-None-Match or If-Modified-Since header shows the client
+// already holds this representation, it answers 304 Not Modified without a body instead.
+func writeConditional(w http.ResponseWriter, r *http.Request, tag, contentType string, body []byte, lastModified time.Time) {
+	w.Header().Set("ETag", tag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, tag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// notModified evaluates the GET preconditions of RFC 9110 §13.2.2: If-None-Match takes
+// precedence, and If-Modified-Since is only consulted when it is absent.
+func notModified(r *http.Request, tag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etag.MatchWeak(inm, tag)
+	}
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// HTTP dates have one-second resolution.
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// negotiateFormat resolves ?format= and Accept, answering 400 for an unknown format.
+func negotiateFormat(w http.ResponseWriter, r *http.Request) (formats.Format, bool) {
+	format, err := formats.Negotiate(r.URL.Query().Get("format"), r.Header.Get("Accept"))
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	return format, true
+}
@@ -1,13 +1,20 @@
 package handlers
 
 import (
+	"alati_projekat/formats"
+	"alati_projekat/interpolate"
 	"alati_projekat/labels"
 	"alati_projekat/model"
+	"alati_projekat/problem"
+	"alati_projekat/semver"
 	"alati_projekat/services"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -38,63 +45,102 @@ var tracer = otel.Tracer("config-service-handler-tracer")
 // @Param X-Request-Id header string false "Idempotency Key (UUID/jedinstveni ID)"
 // @Param config body model.CreateConfigurationRequest true "Telo konfiguracije"
 // @Success 201 {object} model.Configuration
-// @Failure 400 {string} string "Invalid request body"
-// @Failure 409 {string} string "Conflict (već postoji)"
+// @Failure 400 {object} problem.Problem "Invalid request body, field validation errors or non-semver version in strict mode"
+// @Failure 413 {object} problem.Problem "Request body too large"
+// @Failure 409 {object} problem.Problem "Conflict (već postoji)"
+// @Failure 422 {object} problem.Problem "Invalid parent or inheritance cycle"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
 // @Router /configurations [post]
 func (h *ConfigHandler) HandleAddConfiguration(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(r.Context(), "HandleAddConfiguration")
 	defer span.End()
 
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
 		return
 	}
 
 	var req model.CreateConfigurationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if !decodeJSON(w, r, &req) {
 		span.SetAttributes(attribute.String("error.message", "Invalid request body"))
-		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	newConfig := model.Configuration{
-		ID:      uuid.New(),
-		Name:    req.Name,
-		Version: req.Version,
-		Params:  req.Params,
-		Labels:  req.Labels,
+		ID:           uuid.New(),
+		Name:         req.Name,
+		Version:      req.Version,
+		Params:       req.Params,
+		Labels:       req.Labels,
+		Parent:       req.Parent,
+		RemoveParams: req.RemoveParams,
+		Description:  req.Description,
 	}
 
 	idempotencyKey := r.Header.Get("X-Request-Id")
 
-	if err := h.Service.AddConfiguration(ctx, newConfig, idempotencyKey); err != nil {
+	created, err := h.Service.AddConfiguration(ctx, newConfig, idempotencyKey)
+	if err != nil {
 		span.SetAttributes(attribute.String("error.message", "Conflict or Internal Error"))
-		http.Error(w, err.Error(), http.StatusConflict)
+		writeAddConfigurationError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	_ = json.NewEncoder(w).Encode(newConfig)
+	_ = json.NewEncoder(w).Encode(created)
+}
+
+func writeAddConfigurationError(w http.ResponseWriter, r *http.Request, err error) {
+	if writeValidationError(w, r, http.StatusBadRequest, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, services.ErrInvalidVersion):
+		problem.Write(w, r, http.StatusBadRequest, err.Error())
+	case isInheritanceError(err):
+		problem.Write(w, r, http.StatusUnprocessableEntity, err.Error())
+	case strings.Contains(err.Error(), "already exists"):
+		problem.Write(w, r, http.StatusConflict, err.Error())
+	default:
+		problem.Internal(w, r, err)
+	}
 }
 
 // HandleGetConfiguration godoc
 // @Summary Vraća konfiguraciju po imenu i verziji
-// @Description Vraća specifičnu konfiguraciju.
+// @Description Vraća specifičnu konfiguraciju. Umesto verzije moguće je zadati "latest" ili semver ograničenje (^1.2, ~1.2.3, >=1.0.0 <2.0.0, 1.x); razrešena verzija se vraća u X-Resolved-Version headeru.
+// @Description Sa index i wait parametrima zahtev čeka (long polling) dok se konfiguracija ne promeni posle zadatog indeksa ili dok ne istekne wait; novi indeks se vraća u X-Config-Index headeru. Prvi zahtev šalje index=0 i odmah dobija trenutno stanje.
 // @Tags configurations
 // @Produce json
+// @Produce application/yaml
+// @Produce application/toml
+// @Produce text/x-dotenv
+// @Produce text/x-java-properties
+// @Produce application/vnd.config.flat+json
 // @Param name path string true "Ime konfiguracije"
-// @Param version path string true "Verzija konfiguracije"
+// @Param version path string true "Verzija konfiguracije ili semver ograničenje"
+// @Param prerelease query bool false "Ograničenje može da izabere i pre-release verzije"
+// @Param interpolate query bool false "Razrešava ${...} reference u vrednostima parametara"
+// @Param strict query bool false "Greška ako neka referenca ne može da se razreši (podrazumeva interpolate)"
+// @Param If-None-Match header string false "ETag iz prethodnog odgovora"
+// @Param If-Modified-Since header string false "Last-Modified iz prethodnog odgovora"
+// @Param format query string false "Format odgovora (json, yaml, toml, env, properties, flat); ima prednost nad Accept headerom"
+// @Param index query int false "Indeks iz X-Config-Index prethodnog odgovora; čeka se promena posle njega"
+// @Param wait query string false "Najduže čekanje na promenu (npr. 30s, 5m; podrazumevano 5m, najviše 10m)"
 // @Success 200 {object} model.Configuration
-// @Failure 400 {string} string "Missing path parameters"
-// @Failure 404 {string} string "Configuration not found"
+// @Success 304 "Not Modified"
+// @Header 200 {string} X-Config-Index "Indeks za sledeći zahtev sa index parametrom"
+// @Failure 400 {object} problem.Problem "Missing path parameters, unknown format, invalid index/wait or watch on a version selector"
+// @Failure 404 {object} problem.Problem "Configuration not found"
+// @Failure 422 {object} problem.Problem "Unresolved reference, reference cycle or keys colliding in the requested format"
 // @Router /configurations/{name}/{version} [get]
 func (h *ConfigHandler) HandleGetConfiguration(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(r.Context(), "HandleGetConfiguration")
 	defer span.End()
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
 		return
 	}
 
@@ -103,20 +149,162 @@ func (h *ConfigHandler) HandleGetConfiguration(w http.ResponseWriter, r *http.Re
 	version := vars["version"]
 
 	if name == "" || version == "" {
-		http.Error(w, "Path parameters 'name' and 'version' are required.", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
+		return
+	}
+
+	format, ok := negotiateFormat(w, r)
+	if !ok {
+		return
+	}
+
+	watch, index, wait, ok := watchOptions(w, r)
+	if !ok {
 		return
 	}
 
-	config, err := h.Service.GetConfiguration(ctx, name, version)
+	var config model.Configuration
+	var err error
+	if watch {
+		if semver.IsSelector(version) {
+			problem.Write(w, r, http.StatusBadRequest, "Watching requires an exact version.")
+			return
+		}
+		extendWriteDeadline(w, wait)
+		var next uint64
+		config, next, err = h.Service.WatchConfiguration(ctx, name, version, index, wait)
+		setWatchIndex(w, next)
+	} else if semver.IsSelector(version) {
+		config, err = h.Service.ResolveConfigurationVersion(ctx, name, version, prereleaseRequested(r))
+	} else {
+		config, err = h.Service.GetConfiguration(ctx, name, version)
+	}
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidVersionSelector) {
+			problem.Write(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
 		log.Printf("Error retrieving config %s/%s: %v", name, version, err)
-		http.Error(w, "Configuration not found.", http.StatusNotFound)
+		problem.Write(w, r, http.StatusNotFound, "Configuration not found.")
 		return
 	}
+	if config.Version != version {
+		w.Header().Set("X-Resolved-Version", config.Version)
+		version = config.Version
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(config)
+	// Interpolated values may come from other configurations, so only the stored record
+	// can be validated by its modification time.
+	lastModified := config.Metadata.UpdatedAt
+	if interpolateRequested, strict := interpolationOptions(r); interpolateRequested {
+		lastModified = time.Time{}
+		resolved, err := h.Service.InterpolateConfiguration(ctx, name, version, strict)
+		if err != nil {
+			writeResolveError(w, r, name, version, err)
+			return
+		}
+		values := make(map[string]string, len(resolved.Params))
+		for _, p := range resolved.Params {
+			values[p.Key] = p.Value
+		}
+		params := make([]model.Parameter, len(config.Params))
+		for i, p := range config.Params {
+			params[i] = model.Parameter{Key: p.Key, Value: values[p.Key]}
+		}
+		config.Params = params
+	}
+
+	setLifecycleHeaders(w, config.Lifecycle)
+	// Without interpolation the JSON ETag identifies the stored record and is what PATCH expects in If-Match.
+	writeNegotiated(w, r, format, config, formats.ConfigurationSections(config), lastModified)
+}
+
+// HandleGetEffectiveConfiguration godoc
+// @Summary Vraća efektivnu (spojenu) konfiguraciju
+// @Description Spaja konfiguraciju sa svim roditeljima i za svaki parametar navodi sloj iz kog potiče konačna vrednost.
+// @Tags configurations
+// @Produce json
+// @Param name path string true "Ime konfiguracije"
+// @Param version path string true "Verzija konfiguracije"
+// @Param interpolate query bool false "Razrešava ${...} reference u vrednostima parametara"
+// @Param strict query bool false "Greška ako neka referenca ne može da se razreši (podrazumeva interpolate)"
+// @Param If-None-Match header string false "ETag iz prethodnog odgovora"
+// @Success 200 {object} model.EffectiveConfiguration
+// @Success 304 "Not Modified"
+// @Failure 400 {object} problem.Problem "Missing path parameters"
+// @Failure 404 {object} problem.Problem "Configuration not found"
+// @Failure 422 {object} problem.Problem "Broken parent chain, inheritance cycle, unresolved reference or reference cycle"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
+// @Router /configurations/{name}/{version}/effective [get]
+func (h *ConfigHandler) HandleGetEffectiveConfiguration(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleGetEffectiveConfiguration")
+	defer span.End()
+
+	if r.Method != http.MethodGet {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+	version := vars["version"]
+
+	if name == "" || version == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
+		return
+	}
+
+	var effective model.EffectiveConfiguration
+	var err error
+	if interpolateRequested, strict := interpolationOptions(r); interpolateRequested {
+		effective, err = h.Service.InterpolateConfiguration(ctx, name, version, strict)
+	} else {
+		effective, err = h.Service.GetEffectiveConfiguration(ctx, name, version)
+	}
+	if err != nil {
+		writeResolveError(w, r, name, version, err)
+		return
+	}
+
+	writeJSONConditional(w, r, effective, time.Time{})
+}
+
+func isInheritanceError(err error) bool {
+	return errors.Is(err, services.ErrInvalidParent) || errors.Is(err, services.ErrInheritanceCycle)
+}
+
+func isInterpolationError(err error) bool {
+	return errors.Is(err, services.ErrReferenceCycle) ||
+		errors.Is(err, services.ErrUnresolvedReference) ||
+		errors.Is(err, interpolate.ErrSyntax)
+}
+
+// interpolationOptions reads the ?interpolate and ?strict query flags. Strict implies interpolation.
+func interpolationOptions(r *http.Request) (interpolateRequested bool, strict bool) {
+	query := r.URL.Query()
+	interpolateRequested, _ = strconv.ParseBool(query.Get("interpolate"))
+	strict, _ = strconv.ParseBool(query.Get("strict"))
+	return interpolateRequested || strict, strict
+}
+
+// prereleaseRequested reads the ?prerelease flag that lets version selectors match pre-release versions.
+func prereleaseRequested(r *http.Request) bool {
+	include, _ := strconv.ParseBool(r.URL.Query().Get("prerelease"))
+	return include
+}
+
+// writeResolveError maps errors from effective/interpolated reads to HTTP responses.
+func writeResolveError(w http.ResponseWriter, r *http.Request, name, version string, err error) {
+	if isInheritanceError(err) || isInterpolationError(err) {
+		problem.Write(w, r, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if strings.Contains(err.Error(), "not found") {
+		problem.Write(w, r, http.StatusNotFound, "Configuration not found.")
+		return
+	}
+	log.Printf("Error resolving config %s/%s: %v", name, version, err)
+	problem.Internal(w, r, err)
 }
 
 // HandleUpdateConfiguration godoc
@@ -128,41 +316,57 @@ func (h *ConfigHandler) HandleGetConfiguration(w http.ResponseWriter, r *http.Re
 // @Param X-Request-Id header string false "Idempotency Key (UUID/jedinstveni ID)"
 // @Param config body model.CreateConfigurationRequest true "Ažurirano telo konfiguracije (mora uključiti ime i verziju)"
 // @Success 200 {object} model.Configuration
-// @Failure 400 {string} string "Invalid request body"
-// @Failure 404 {string} string "Configuration not found"
-// @Failure 500 {string} string "Internal Server Error"
+// @Failure 400 {object} problem.Problem "Invalid request body or field validation errors"
+// @Failure 413 {object} problem.Problem "Request body too large"
+// @Failure 404 {object} problem.Problem "Configuration not found"
+// @Failure 409 {object} problem.Problem "Configuration is not a draft"
+// @Failure 422 {object} problem.Problem "Invalid parent or inheritance cycle"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
 // @Router /configurations [put]
 func (h *ConfigHandler) HandleUpdateConfiguration(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(r.Context(), "HandleUpdateConfiguration")
 	defer span.End()
 
 	if r.Method != http.MethodPut {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
 		return
 	}
 
 	var req model.CreateConfigurationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
 	configToUpdate := model.Configuration{
-		Name:    req.Name,
-		Version: req.Version,
-		Params:  req.Params,
-		Labels:  req.Labels,
+		Name:         req.Name,
+		Version:      req.Version,
+		Params:       req.Params,
+		Labels:       req.Labels,
+		Parent:       req.Parent,
+		RemoveParams: req.RemoveParams,
+		Description:  req.Description,
 	}
 
 	idempotencyKey := r.Header.Get("X-Request-Id")
 	finalConfig, err := h.Service.UpdateConfiguration(ctx, configToUpdate, idempotencyKey)
 
 	if err != nil {
+		if writeValidationError(w, r, http.StatusBadRequest, err) {
+			return
+		}
+		if errors.Is(err, services.ErrImmutable) {
+			problem.Write(w, r, http.StatusConflict, err.Error())
+			return
+		}
+		if isInheritanceError(err) {
+			problem.Write(w, r, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Configuration not found for update.", http.StatusNotFound)
+			problem.Write(w, r, http.StatusNotFound, "Configuration not found for update.")
 			return
 		}
-		http.Error(w, "Error updating configuration: "+err.Error(), http.StatusInternalServerError)
+		problem.Internal(w, r, err)
 		return
 	}
 
@@ -172,21 +376,22 @@ func (h *ConfigHandler) HandleUpdateConfiguration(w http.ResponseWriter, r *http
 
 // HandleDeleteConfiguration godoc
 // @Summary Briše konfiguraciju
-// @Description Briše specifičnu konfiguraciju po imenu i verziji.
+// @Description Briše specifičnu konfiguraciju po imenu i verziji. Brisati se mogu samo nacrti i arhivirane konfiguracije.
 // @Tags configurations
 // @Param name path string true "Ime konfiguracije"
 // @Param version path string true "Verzija konfiguracije"
 // @Success 204 "No Content"
-// @Failure 400 {string} string "Missing path parameters"
-// @Failure 404 {string} string "Configuration not found"
-// @Failure 500 {string} string "Internal Server Error"
+// @Failure 400 {object} problem.Problem "Missing path parameters"
+// @Failure 404 {object} problem.Problem "Configuration not found"
+// @Failure 409 {object} problem.Problem "Published or deprecated entities must be archived first"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
 // @Router /configurations/{name}/{version} [delete]
 func (h *ConfigHandler) HandleDeleteConfiguration(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(r.Context(), "HandleDeleteConfiguration")
 	defer span.End()
 
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
 		return
 	}
 
@@ -195,17 +400,21 @@ func (h *ConfigHandler) HandleDeleteConfiguration(w http.ResponseWriter, r *http
 	version := vars["version"]
 
 	if name == "" || version == "" {
-		http.Error(w, "Path parameters 'name' and 'version' are required.", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
 		return
 	}
 
 	err := h.Service.DeleteConfiguration(ctx, name, version)
 	if err != nil {
+		if errors.Is(err, services.ErrImmutable) {
+			problem.Write(w, r, http.StatusConflict, err.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Configuration not found for deletion.", http.StatusNotFound)
+			problem.Write(w, r, http.StatusNotFound, "Configuration not found for deletion.")
 			return
 		}
-		http.Error(w, "Internal server error during deletion.", http.StatusInternalServerError)
+		problem.Internal(w, r, err)
 		return
 	}
 
@@ -223,21 +432,22 @@ func (h *ConfigHandler) HandleDeleteConfiguration(w http.ResponseWriter, r *http
 // @Param X-Request-Id header string false "Idempotency Key (UUID/jedinstveni ID)"
 // @Param group body model.CreateGroupRequest true "Telo grupe konfiguracija"
 // @Success 201 {object} model.ConfigurationGroup
-// @Failure 400 {string} string "Invalid request body"
-// @Failure 409 {string} string "Group creation failed (Conflict)"
+// @Failure 400 {object} problem.Problem "Invalid request body, field validation errors or non-semver version in strict mode"
+// @Failure 413 {object} problem.Problem "Request body too large"
+// @Failure 409 {object} problem.Problem "Group already exists"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
 // @Router /configgroups [post]
 func (h *ConfigHandler) HandleAddConfigurationGroup(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(r.Context(), "HandleAddConfigurationGroup")
 	defer span.End()
 
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
 		return
 	}
 
 	var req model.CreateGroupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -246,36 +456,63 @@ func (h *ConfigHandler) HandleAddConfigurationGroup(w http.ResponseWriter, r *ht
 		Name:           req.Name,
 		Version:        req.Version,
 		Configurations: req.Configurations,
+		Description:    req.Description,
 	}
 
 	idempotencyKey := r.Header.Get("X-Request-Id")
-	if err := h.Service.AddConfigurationGroup(ctx, newGroup, idempotencyKey); err != nil {
-		http.Error(w, "Group creation failed: "+err.Error(), http.StatusConflict)
+	created, err := h.Service.AddConfigurationGroup(ctx, newGroup, idempotencyKey)
+	if err != nil {
+		if writeValidationError(w, r, http.StatusBadRequest, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, services.ErrInvalidVersion):
+			problem.Write(w, r, http.StatusBadRequest, err.Error())
+		case strings.Contains(err.Error(), "already exists"):
+			problem.Write(w, r, http.StatusConflict, "Group creation failed: "+err.Error())
+		default:
+			problem.Internal(w, r, err)
+		}
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	_ = json.NewEncoder(w).Encode(newGroup)
+	_ = json.NewEncoder(w).Encode(created)
 }
 
 // HandleGetConfigurationGroup godoc
 // @Summary Vraća grupu konfiguracija po imenu i verziji
-// @Description Vraća specifičnu grupu konfiguracija.
+// @Description Vraća specifičnu grupu konfiguracija. Umesto verzije moguće je zadati "latest" ili semver ograničenje; razrešena verzija se vraća u X-Resolved-Version headeru.
+// @Description Sa index i wait parametrima zahtev čeka (long polling) dok se grupa ne promeni posle zadatog indeksa ili dok ne istekne wait; novi indeks se vraća u X-Config-Index headeru.
 // @Tags configuration_groups
 // @Produce json
+// @Produce application/yaml
+// @Produce application/toml
+// @Produce text/x-dotenv
+// @Produce text/x-java-properties
+// @Produce application/vnd.config.flat+json
 // @Param name path string true "Ime grupe"
-// @Param version path string true "Verzija grupe"
+// @Param version path string true "Verzija grupe ili semver ograničenje"
+// @Param prerelease query bool false "Ograničenje može da izabere i pre-release verzije"
+// @Param If-None-Match header string false "ETag iz prethodnog odgovora"
+// @Param If-Modified-Since header string false "Last-Modified iz prethodnog odgovora"
+// @Param format query string false "Format odgovora (json, yaml, toml, env, properties, flat); ima prednost nad Accept headerom"
+// @Param index query int false "Indeks iz X-Config-Index prethodnog odgovora; čeka se promena posle njega"
+// @Param wait query string false "Najduže čekanje na promenu (npr. 30s, 5m; podrazumevano 5m, najviše 10m)"
 // @Success 200 {object} model.ConfigurationGroup
-// @Failure 400 {string} string "Missing path parameters"
-// @Failure 404 {string} string "Configuration group not found"
+// @Success 304 "Not Modified"
+// @Header 200 {string} X-Config-Index "Indeks za sledeći zahtev sa index parametrom"
+// @Failure 400 {object} problem.Problem "Missing path parameters, unknown format, invalid index/wait or watch on a version selector"
+// @Failure 404 {object} problem.Problem "Configuration group not found"
+// @Failure 422 {object} problem.Problem "Keys colliding in the requested format"
 // @Router /configgroups/{name}/{version} [get]
 func (h *ConfigHandler) HandleGetConfigurationGroup(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(r.Context(), "HandleGetConfigurationGroup")
 	defer span.End()
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
 		return
 	}
 
@@ -284,23 +521,55 @@ func (h *ConfigHandler) HandleGetConfigurationGroup(w http.ResponseWriter, r *ht
 	version := vars["version"]
 
 	if name == "" || version == "" {
-		http.Error(w, "Path parameters 'name' and 'version' are required.", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
 		return
 	}
 
-	group, err := h.Service.GetConfigurationGroup(ctx, name, version)
+	format, ok := negotiateFormat(w, r)
+	if !ok {
+		return
+	}
+
+	watch, index, wait, ok := watchOptions(w, r)
+	if !ok {
+		return
+	}
+
+	var group model.ConfigurationGroup
+	var err error
+	if watch {
+		if semver.IsSelector(version) {
+			problem.Write(w, r, http.StatusBadRequest, "Watching requires an exact version.")
+			return
+		}
+		extendWriteDeadline(w, wait)
+		var next uint64
+		group, next, err = h.Service.WatchConfigurationGroup(ctx, name, version, index, wait)
+		setWatchIndex(w, next)
+	} else if semver.IsSelector(version) {
+		group, err = h.Service.ResolveConfigurationGroupVersion(ctx, name, version, prereleaseRequested(r))
+	} else {
+		group, err = h.Service.GetConfigurationGroup(ctx, name, version)
+	}
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidVersionSelector) {
+			problem.Write(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Configuration group not found.", http.StatusNotFound)
+			problem.Write(w, r, http.StatusNotFound, "Configuration group not found.")
 			return
 		}
-		http.Error(w, "Internal server error.", http.StatusInternalServerError)
+		problem.Internal(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(group)
+	if group.Version != version {
+		w.Header().Set("X-Resolved-Version", group.Version)
+	}
+
+	setLifecycleHeaders(w, group.Lifecycle)
+	writeNegotiated(w, r, format, group, formats.GroupSections(group), group.Metadata.UpdatedAt)
 }
 
 // HandleUpdateConfigurationGroup godoc
@@ -312,22 +581,23 @@ func (h *ConfigHandler) HandleGetConfigurationGroup(w http.ResponseWriter, r *ht
 // @Param X-Request-Id header string false "Idempotency Key (UUID/jedinstveni ID)"
 // @Param group body model.CreateGroupRequest true "Ažurirano telo grupe konfiguracija"
 // @Success 200 {object} model.ConfigurationGroup
-// @Failure 400 {string} string "Invalid request body"
-// @Failure 404 {string} string "Configuration group not found"
-// @Failure 500 {string} string "Internal Server Error"
+// @Failure 400 {object} problem.Problem "Invalid request body or field validation errors"
+// @Failure 413 {object} problem.Problem "Request body too large"
+// @Failure 404 {object} problem.Problem "Configuration group not found"
+// @Failure 409 {object} problem.Problem "Configuration group is not a draft"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
 // @Router /configgroups [put]
 func (h *ConfigHandler) HandleUpdateConfigurationGroup(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(r.Context(), "HandleUpdateConfigurationGroup")
 	defer span.End()
 
 	if r.Method != http.MethodPut {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
 		return
 	}
 
 	var req model.CreateGroupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -335,16 +605,24 @@ func (h *ConfigHandler) HandleUpdateConfigurationGroup(w http.ResponseWriter, r
 		Name:           req.Name,
 		Version:        req.Version,
 		Configurations: req.Configurations,
+		Description:    req.Description,
 	}
 
 	idempotencyKey := r.Header.Get("X-Request-Id")
 	finalGroup, err := h.Service.UpdateConfigurationGroup(ctx, groupToUpdate, idempotencyKey)
 	if err != nil {
+		if writeValidationError(w, r, http.StatusBadRequest, err) {
+			return
+		}
+		if errors.Is(err, services.ErrImmutable) {
+			problem.Write(w, r, http.StatusConflict, err.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Configuration group not found for update.", http.StatusNotFound)
+			problem.Write(w, r, http.StatusNotFound, "Configuration group not found for update.")
 			return
 		}
-		http.Error(w, "Error updating configuration group: "+err.Error(), http.StatusInternalServerError)
+		problem.Internal(w, r, err)
 		return
 	}
 
@@ -354,21 +632,22 @@ func (h *ConfigHandler) HandleUpdateConfigurationGroup(w http.ResponseWriter, r
 
 // HandleDeleteConfigurationGroup godoc
 // @Summary Briše grupu konfiguracija
-// @Description Briše specifičnu grupu konfiguracija po imenu i verziji.
+// @Description Briše specifičnu grupu konfiguracija po imenu i verziji. Brisati se mogu samo nacrti i arhivirane grupe.
 // @Tags configuration_groups
 // @Param name path string true "Ime grupe"
 // @Param version path string true "Verzija grupe"
 // @Success 204 "No Content"
-// @Failure 400 {string} string "Missing path parameters"
-// @Failure 404 {string} string "Configuration group not found"
-// @Failure 500 {string} string "Internal Server Error"
+// @Failure 400 {object} problem.Problem "Missing path parameters"
+// @Failure 404 {object} problem.Problem "Configuration group not found"
+// @Failure 409 {object} problem.Problem "Published or deprecated entities must be archived first"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
 // @Router /configgroups/{name}/{version} [delete]
 func (h *ConfigHandler) HandleDeleteConfigurationGroup(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(r.Context(), "HandleDeleteConfigurationGroup")
 	defer span.End()
 
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
 		return
 	}
 
@@ -377,17 +656,21 @@ func (h *ConfigHandler) HandleDeleteConfigurationGroup(w http.ResponseWriter, r
 	version := vars["version"]
 
 	if name == "" || version == "" {
-		http.Error(w, "Path parameters 'name' and 'version' are required.", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
 		return
 	}
 
 	err := h.Service.DeleteConfigurationGroup(ctx, name, version)
 	if err != nil {
+		if errors.Is(err, services.ErrImmutable) {
+			problem.Write(w, r, http.StatusConflict, err.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Configuration group not found for deletion.", http.StatusNotFound)
+			problem.Write(w, r, http.StatusNotFound, "Configuration group not found for deletion.")
 			return
 		}
-		http.Error(w, "Internal server error during deletion.", http.StatusInternalServerError)
+		problem.Internal(w, r, err)
 		return
 	}
 
@@ -402,17 +685,19 @@ func (h *ConfigHandler) HandleDeleteConfigurationGroup(w http.ResponseWriter, r
 // @Param name path string true "Ime grupe"
 // @Param version path string true "Verzija grupe"
 // @Param labels query string true "Labeli (k:v;k2:v2)"
+// @Param If-None-Match header string false "ETag iz prethodnog odgovora"
 // @Success 200 {array} model.Configuration "Filtrirana lista konfiguracija"
-// @Failure 400 {string} string "Missing path/query parameters or invalid labels format"
-// @Failure 404 {string} string "Configuration Group not found"
-// @Failure 500 {string} string "Internal Server Error"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} problem.Problem "Missing path/query parameters or invalid labels format"
+// @Failure 404 {object} problem.Problem "Configuration Group not found"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
 // @Router /configgroups/{name}/{version}/configurations [get]
 func (h *ConfigHandler) HandleGetGroupConfigsByLabels(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(r.Context(), "HandleGetGroupConfigsByLabels")
 	defer span.End()
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
 		return
 	}
 
@@ -422,32 +707,31 @@ func (h *ConfigHandler) HandleGetGroupConfigsByLabels(w http.ResponseWriter, r *
 	labelsRaw := r.URL.Query().Get("labels")
 
 	if name == "" || version == "" {
-		http.Error(w, "Path parameters 'name' and 'version' are required.", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
 		return
 	}
 	if labelsRaw == "" {
-		http.Error(w, "Query parameter 'labels' is required.", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "Query parameter 'labels' is required.")
 		return
 	}
 
 	want, err := labels.Parse(labelsRaw)
 	if err != nil {
-		http.Error(w, "Invalid 'labels' query: "+err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "Invalid 'labels' query: "+err.Error())
 		return
 	}
 
 	list, err := h.Service.FilterConfigsByLabels(ctx, name, version, want)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Configuration Group not found.", http.StatusNotFound)
+			problem.Write(w, r, http.StatusNotFound, "Configuration Group not found.")
 			return
 		}
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		problem.Internal(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(list)
+	writeJSONConditional(w, r, list, time.Time{})
 }
 
 // HandleDeleteGroupConfigsByLabels godoc
@@ -459,16 +743,17 @@ func (h *ConfigHandler) HandleGetGroupConfigsByLabels(w http.ResponseWriter, r *
 // @Param version path string true "Verzija grupe"
 // @Param labels query string true "Labeli (k:v;k2:v2)"
 // @Success 200 {object} object{deleted=int}
-// @Failure 400 {string} string "Missing path/query parameters or invalid labels format"
-// @Failure 404 {string} string "Configuration Group not found"
-// @Failure 500 {string} string "Internal Server Error"
+// @Failure 400 {object} problem.Problem "Missing path/query parameters or invalid labels format"
+// @Failure 404 {object} problem.Problem "Configuration Group not found"
+// @Failure 409 {object} problem.Problem "Configuration Group is not a draft or was modified concurrently"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
 // @Router /configgroups/{name}/{version}/configurations [delete]
 func (h *ConfigHandler) HandleDeleteGroupConfigsByLabels(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(r.Context(), "HandleDeleteGroupConfigsByLabels")
 	defer span.End()
 
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
 		return
 	}
 
@@ -478,27 +763,31 @@ func (h *ConfigHandler) HandleDeleteGroupConfigsByLabels(w http.ResponseWriter,
 	labelsRaw := r.URL.Query().Get("labels")
 
 	if name == "" || version == "" {
-		http.Error(w, "Path parameters 'name' and 'version' are required.", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
 		return
 	}
 	if labelsRaw == "" {
-		http.Error(w, "Query parameter 'labels' is required (format: k:v;k2:v2).", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "Query parameter 'labels' is required (format: k:v;k2:v2).")
 		return
 	}
 
 	want, err := labels.Parse(labelsRaw)
 	if err != nil || len(want) == 0 {
-		http.Error(w, "Invalid 'labels' query: expected k:v;k2:v2", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "Invalid 'labels' query: expected k:v;k2:v2")
 		return
 	}
 
 	deleted, err := h.Service.DeleteConfigsByLabels(ctx, name, version, want)
 	if err != nil {
+		if errors.Is(err, services.ErrImmutable) || errors.Is(err, services.ErrRevisionConflict) {
+			problem.Write(w, r, http.StatusConflict, err.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Configuration Group not found.", http.StatusNotFound)
+			problem.Write(w, r, http.StatusNotFound, "Configuration Group not found.")
 			return
 		}
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		problem.Internal(w, r, err)
 		return
 	}
 
@@ -1,15 +1,29 @@
 package handlers
 
 import (
+	"alati_projekat/actor"
+	"alati_projekat/etag"
+	"alati_projekat/events"
+	"alati_projekat/interpolate"
+	"alati_projekat/labels"
 	"alati_projekat/model"
+	"alati_projekat/problem"
+	"alati_projekat/services"
+	"alati_projekat/validation"
+	"alati_projekat/webhooks"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux" // MORAMO KORISTITI MUX ZA PRAVILNU EMULACIJU VARS
 )
@@ -44,13 +58,17 @@ func (m *MockService) SaveIdempotencyKey(ctx context.Context, key string) {
 	// Nema return statement
 }
 
-func (m *MockService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) error {
+func (m *MockService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error) {
+	if err := validation.Configuration(config); err != nil {
+		return model.Configuration{}, err
+	}
 	key := m.makeConfigKey(config.Name, config.Version)
 	if _, exists := m.configs[key]; exists {
-		return errors.New("configuration already exists")
+		return model.Configuration{}, errors.New("configuration already exists")
 	}
+	config.Metadata = model.Metadata{CreatedBy: actor.FromContext(ctx), Revision: 1}
 	m.configs[key] = config
-	return nil
+	return config, nil
 }
 
 // ISPRAVLJENA METODA
@@ -63,6 +81,40 @@ func (m *MockService) GetConfiguration(ctx context.Context, name, version string
 	return config, nil
 }
 
+// mockPatch podrzava samo merge patch, sto je dovoljno za testiranje mapiranja gresaka u handleru.
+// Mock ne blokira: vraća trenutno stanje, a revizija služi kao indeks
+func (m *MockService) WatchConfiguration(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.Configuration, uint64, error) {
+	config, err := m.GetConfiguration(ctx, name, version)
+	return config, uint64(config.Metadata.Revision), err
+}
+
+func mockPatch(current any, patchType string, patch []byte, ifMatch string, out any) error {
+	if patchType != services.MergePatchType {
+		return services.ErrUnsupportedPatchType
+	}
+	if ifMatch != "" && !etag.Match(ifMatch, etag.Of(current)) {
+		return services.ErrPreconditionFailed
+	}
+	original, _ := json.Marshal(current)
+	patched, err := jsonpatch.MergePatch(original, patch)
+	if err != nil {
+		return services.ErrMalformedPatch
+	}
+	return json.Unmarshal(patched, out)
+}
+
+func (m *MockService) PatchConfiguration(ctx context.Context, name, version, patchType string, patch []byte, ifMatch string) (model.Configuration, error) {
+	config, err := m.GetConfiguration(ctx, name, version)
+	if err != nil {
+		return model.Configuration{}, err
+	}
+	if err := mockPatch(config, patchType, patch, ifMatch, &config); err != nil {
+		return model.Configuration{}, err
+	}
+	m.configs[m.makeConfigKey(name, version)] = config
+	return config, nil
+}
+
 func (m *MockService) UpdateConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error) {
 	key := m.makeConfigKey(config.Name, config.Version)
 
@@ -82,20 +134,115 @@ func (m *MockService) UpdateConfiguration(ctx context.Context, config model.Conf
 // ISPRAVLJENA METODA
 func (m *MockService) DeleteConfiguration(ctx context.Context, name, version string) error {
 	key := m.makeConfigKey(name, version)
-	if _, exists := m.configs[key]; !exists {
+	config, exists := m.configs[key]
+	if !exists {
 		return errors.New("configuration not found")
 	}
+	if state := config.CurrentState(); state != model.StateDraft && state != model.StateArchived {
+		return fmt.Errorf("%w: configuration %s/%s is %s", services.ErrImmutable, name, version, state)
+	}
 	delete(m.configs, key)
 	return nil
 }
 
-func (m *MockService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) error {
+func (m *MockService) GetEffectiveConfiguration(ctx context.Context, name, version string) (model.EffectiveConfiguration, error) {
+	config, err := m.GetConfiguration(ctx, name, version)
+	if err != nil {
+		return model.EffectiveConfiguration{}, err
+	}
+	// Mock implementacija bez roditelja, svi parametri poticu iz same konfiguracije
+	ref := model.ConfigurationRef{Name: name, Version: version}
+	effective := model.EffectiveConfiguration{Name: name, Version: version, Layers: []model.ConfigurationRef{ref}}
+	for _, p := range config.Params {
+		effective.Params = append(effective.Params, model.EffectiveParameter{Key: p.Key, Value: p.Value, Source: ref})
+	}
+	return effective, nil
+}
+
+func (m *MockService) InterpolateConfiguration(ctx context.Context, name, version string, strict bool) (model.EffectiveConfiguration, error) {
+	effective, err := m.GetEffectiveConfiguration(ctx, name, version)
+	if err != nil {
+		return model.EffectiveConfiguration{}, err
+	}
+	// Mock implementacija razresava samo reference unutar iste konfiguracije
+	raw := make(map[string]string, len(effective.Params))
+	for _, p := range effective.Params {
+		raw[p.Key] = p.Value
+	}
+	visiting := map[string]bool{}
+	var lookup interpolate.LookupFunc
+	lookup = func(ref interpolate.Reference) (string, bool, error) {
+		value, ok := raw[ref.Key]
+		if ref.Config != nil || !ok {
+			return "", false, nil
+		}
+		if visiting[ref.Key] {
+			return "", false, fmt.Errorf("%w: %s", services.ErrReferenceCycle, ref.Key)
+		}
+		visiting[ref.Key] = true
+		defer delete(visiting, ref.Key)
+		value, err := interpolate.Expand(value, lookup, strict)
+		return value, true, err
+	}
+	for i, p := range effective.Params {
+		if effective.Params[i].Value, err = interpolate.Expand(p.Value, lookup, strict); err != nil {
+			return model.EffectiveConfiguration{}, err
+		}
+	}
+	return effective, nil
+}
+
+func (m *MockService) ResolveConfigurationVersion(ctx context.Context, name, selector string, includePrerelease bool) (model.Configuration, error) {
+	// Mock implementacija, "latest" vraća bilo koju verziju sa datim imenom
+	for _, config := range m.configs {
+		if config.Name == name && selector == "latest" {
+			return config, nil
+		}
+	}
+	return model.Configuration{}, errors.New("configuration not found")
+}
+
+func (m *MockService) TransitionConfiguration(ctx context.Context, name, version string, target model.LifecycleState, sunset *time.Time) (model.Configuration, error) {
+	config, err := m.GetConfiguration(ctx, name, version)
+	if err != nil {
+		return model.Configuration{}, err
+	}
+	// Mock implementacija ne proverava dozvoljene prelaze
+	config.State = target
+	m.configs[m.makeConfigKey(name, version)] = config
+	return config, nil
+}
+
+func (m *MockService) CloneConfiguration(ctx context.Context, name, version string, req model.CloneRequest, idempotencyKey string) (model.Configuration, error) {
+	source, err := m.GetConfiguration(ctx, name, version)
+	if err != nil {
+		return model.Configuration{}, err
+	}
+	// Mock implementacija samo dodaje labele iz zahteva
+	clone := source
+	clone.ID = uuid.New()
+	clone.Version = req.Version
+	if req.Name != "" {
+		clone.Name = req.Name
+	}
+	clone.Labels = append(append([]model.Parameter(nil), source.Labels...), req.Labels...)
+	created, err := m.AddConfiguration(ctx, clone, idempotencyKey)
+	if err != nil {
+		return model.Configuration{}, err
+	}
+	created.Metadata.ClonedFrom = &model.ConfigurationRef{Name: name, Version: version}
+	m.configs[m.makeConfigKey(created.Name, created.Version)] = created
+	return created, nil
+}
+
+func (m *MockService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (model.ConfigurationGroup, error) {
 	key := m.makeGroupKey(group.Name, group.Version)
 	if _, exists := m.groups[key]; exists {
-		return errors.New("configuration group already exists")
+		return model.ConfigurationGroup{}, errors.New("configuration group already exists")
 	}
+	group.Metadata = model.Metadata{CreatedBy: actor.FromContext(ctx), Revision: 1}
 	m.groups[key] = group
-	return nil
+	return group, nil
 }
 
 func (m *MockService) GetConfigurationGroup(ctx context.Context, name, version string) (model.ConfigurationGroup, error) {
@@ -107,6 +254,23 @@ func (m *MockService) GetConfigurationGroup(ctx context.Context, name, version s
 	return group, nil
 }
 
+func (m *MockService) WatchConfigurationGroup(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.ConfigurationGroup, uint64, error) {
+	group, err := m.GetConfigurationGroup(ctx, name, version)
+	return group, uint64(group.Metadata.Revision), err
+}
+
+func (m *MockService) PatchConfigurationGroup(ctx context.Context, name, version, patchType string, patch []byte, ifMatch string) (model.ConfigurationGroup, error) {
+	group, err := m.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	if err := mockPatch(group, patchType, patch, ifMatch, &group); err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	m.groups[m.makeGroupKey(name, version)] = group
+	return group, nil
+}
+
 func (m *MockService) UpdateConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (model.ConfigurationGroup, error) {
 	key := m.makeGroupKey(group.Name, group.Version)
 	originalGroup, exists := m.groups[key]
@@ -125,13 +289,212 @@ func (m *MockService) UpdateConfigurationGroup(ctx context.Context, group model.
 
 func (m *MockService) DeleteConfigurationGroup(ctx context.Context, name, version string) error {
 	key := m.makeGroupKey(name, version)
-	if _, exists := m.groups[key]; !exists {
+	group, exists := m.groups[key]
+	if !exists {
 		return errors.New("configuration group not found")
 	}
+	if state := group.CurrentState(); state != model.StateDraft && state != model.StateArchived {
+		return fmt.Errorf("%w: configuration group %s/%s is %s", services.ErrImmutable, name, version, state)
+	}
 	delete(m.groups, key)
 	return nil
 }
 
+func (m *MockService) AddGroupMember(ctx context.Context, name, version string, member model.Configuration) (model.ConfigurationGroup, error) {
+	group, err := m.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	for _, c := range group.Configurations {
+		if c.Name == member.Name && c.Version == member.Version {
+			return model.ConfigurationGroup{}, services.ErrDuplicateMember
+		}
+	}
+	group.Configurations = append(group.Configurations, member)
+	m.groups[m.makeGroupKey(name, version)] = group
+	return group, nil
+}
+
+func (m *MockService) UpdateGroupMember(ctx context.Context, name, version string, member model.Configuration) (model.ConfigurationGroup, error) {
+	group, err := m.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	for i, c := range group.Configurations {
+		if c.Name == member.Name && c.Version == member.Version {
+			group.Configurations[i] = member
+			return group, nil
+		}
+	}
+	return model.ConfigurationGroup{}, services.ErrMemberNotFound
+}
+
+func (m *MockService) RemoveGroupMember(ctx context.Context, name, version, memberName, memberVersion string) (model.ConfigurationGroup, error) {
+	group, err := m.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	for i, c := range group.Configurations {
+		if c.Name == memberName && c.Version == memberVersion {
+			group.Configurations = append(group.Configurations[:i], group.Configurations[i+1:]...)
+			m.groups[m.makeGroupKey(name, version)] = group
+			return group, nil
+		}
+	}
+	return model.ConfigurationGroup{}, services.ErrMemberNotFound
+}
+
+func (m *MockService) ResolveConfigurationGroupVersion(ctx context.Context, name, selector string, includePrerelease bool) (model.ConfigurationGroup, error) {
+	for _, group := range m.groups {
+		if group.Name == name && selector == "latest" {
+			return group, nil
+		}
+	}
+	return model.ConfigurationGroup{}, errors.New("configuration group not found")
+}
+
+func (m *MockService) TransitionConfigurationGroup(ctx context.Context, name, version string, target model.LifecycleState, sunset *time.Time) (model.ConfigurationGroup, error) {
+	group, err := m.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	group.State = target
+	m.groups[m.makeGroupKey(name, version)] = group
+	return group, nil
+}
+
+// ExecuteBatch podrzava samo konfiguracije; atomicni rezim vraca prethodno stanje ako neka operacija ne uspe.
+func (m *MockService) CloneConfigurationGroup(ctx context.Context, name, version string, req model.CloneRequest, idempotencyKey string) (model.ConfigurationGroup, error) {
+	source, err := m.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	clone := source
+	clone.ID = uuid.New()
+	clone.Version = req.Version
+	if req.Name != "" {
+		clone.Name = req.Name
+	}
+	clone.Configurations = append([]model.Configuration(nil), source.Configurations...)
+	created, err := m.AddConfigurationGroup(ctx, clone, idempotencyKey)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	created.Metadata.ClonedFrom = &model.ConfigurationRef{Name: name, Version: version}
+	m.groups[m.makeGroupKey(created.Name, created.Version)] = created
+	return created, nil
+}
+
+func (m *MockService) ExecuteBatch(ctx context.Context, req model.BatchRequest, idempotencyKey string) (model.BatchResponse, error) {
+	if req.Mode == "" {
+		req.Mode = model.BatchAtomic
+	}
+	snapshot := make(map[string]model.Configuration, len(m.configs))
+	for k, v := range m.configs {
+		snapshot[k] = v
+	}
+
+	resp := model.BatchResponse{Mode: req.Mode}
+	for i, op := range req.Operations {
+		result := model.BatchResult{Index: i, Op: op.Op, Kind: op.Kind, Name: op.Name, Version: op.Version}
+		switch op.Op {
+		case model.BatchCreate:
+			var created model.Configuration
+			created, result.Err = m.AddConfiguration(ctx, model.Configuration{Name: op.Configuration.Name, Version: op.Configuration.Version, Params: op.Configuration.Params}, "")
+			result.Name, result.Version, result.Configuration = created.Name, created.Version, &created
+		case model.BatchDelete:
+			result.Err = m.DeleteConfiguration(ctx, op.Name, op.Version)
+		}
+		if result.Err != nil && req.Mode == model.BatchAtomic {
+			m.configs = snapshot
+			return model.BatchResponse{}, &services.BatchError{Index: i, Op: op.Op, Kind: op.Kind, Err: result.Err}
+		}
+		if result.Err != nil {
+			result.Configuration = nil
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+func (m *MockService) ExportSnapshot(ctx context.Context, opts model.ExportOptions) (model.Snapshot, error) {
+	snap := model.Snapshot{Header: model.SnapshotHeader{ExportedAt: time.Now(), Revisions: opts.Revisions}}
+	for _, c := range m.configs {
+		snap.Configurations = append(snap.Configurations, c)
+	}
+	for _, g := range m.groups {
+		snap.Groups = append(snap.Groups, g)
+	}
+	return snap, nil
+}
+
+// ImportSnapshot u mock-u uvozi samo konfiguracije.
+func (m *MockService) ImportSnapshot(ctx context.Context, snap model.Snapshot, opts model.ImportOptions) (model.ImportSummary, error) {
+	if opts.Mode == "" {
+		opts.Mode = model.ImportFailOnConflict
+	}
+	summary := model.ImportSummary{Mode: opts.Mode, DryRun: opts.DryRun}
+	for _, c := range snap.Configurations {
+		item := model.ImportItem{Kind: model.EventConfiguration, Name: c.Name, Version: c.Version, Action: model.ImportCreated}
+		if _, exists := m.configs[m.makeConfigKey(c.Name, c.Version)]; exists {
+			item.Action = model.ImportSkipped
+			if opts.Mode == model.ImportFailOnConflict {
+				item.Action, item.Reason = model.ImportConflict, "already exists"
+			}
+		}
+		summary.Configurations.Add(item.Action)
+		summary.Items = append(summary.Items, item)
+	}
+	if summary.Configurations.Conflicts > 0 {
+		return summary, services.ErrImportConflict
+	}
+	if !opts.DryRun {
+		for _, c := range snap.Configurations {
+			if _, exists := m.configs[m.makeConfigKey(c.Name, c.Version)]; !exists {
+				m.configs[m.makeConfigKey(c.Name, c.Version)] = c
+			}
+		}
+	}
+	return summary, nil
+}
+
+func (m *MockService) RenderConfiguration(ctx context.Context, name, version string, selector map[string]string, includePrerelease bool) (model.RenderedConfiguration, error) {
+	group, err := m.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		return model.RenderedConfiguration{}, err
+	}
+	// Mock implementacija spaja članove redom iz grupe, bez roditelja
+	rendered := model.RenderedConfiguration{Group: model.ConfigurationRef{Name: name, Version: version}, Selector: selector}
+	values := map[string][]model.ConflictingValue{}
+	for _, c := range group.Configurations {
+		if !labels.HasAll(c, selector) {
+			continue
+		}
+		source := model.ConfigurationRef{Name: c.Name, Version: c.Version}
+		rendered.Members = append(rendered.Members, source)
+		for _, p := range c.Params {
+			if _, seen := values[p.Key]; !seen {
+				rendered.Params = append(rendered.Params, model.EffectiveParameter{Key: p.Key})
+			}
+			values[p.Key] = append(values[p.Key], model.ConflictingValue{Value: p.Value, Source: source})
+		}
+	}
+	if len(rendered.Members) == 0 {
+		return model.RenderedConfiguration{}, services.ErrNoMatchingMembers
+	}
+	for i, p := range rendered.Params {
+		v := values[p.Key]
+		rendered.Params[i] = model.EffectiveParameter{Key: p.Key, Value: v[len(v)-1].Value, Source: v[len(v)-1].Source}
+		if len(v) > 1 {
+			rendered.Conflicts = append(rendered.Conflicts, model.ParameterConflict{Key: p.Key, Values: v})
+		}
+	}
+	return rendered, nil
+}
+
 func (m *MockService) FilterConfigsByLabels(ctx context.Context, name, version string, want map[string]string) ([]model.Configuration, error) {
 	group, err := m.GetConfigurationGroup(ctx, name, version)
 	if err != nil {
@@ -185,6 +548,29 @@ func TestConfigHandler_AddConfiguration(t *testing.T) {
 	}
 }
 
+func TestConfigHandler_AddConfiguration_ReturnsMetadata(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+
+	body, _ := json.Marshal(model.CreateConfigurationRequest{Name: "audited", Version: "v1", Description: "opis"})
+	req := httptest.NewRequest("POST", "/configurations", bytes.NewReader(body))
+	req = req.WithContext(actor.NewContext(req.Context(), "alice"))
+	rr := httptest.NewRecorder()
+
+	handler.HandleAddConfiguration(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", rr.Code)
+	}
+	var response model.Configuration
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Description != "opis" || response.Metadata.CreatedBy != "alice" || response.Metadata.Revision != 1 {
+		t.Errorf("Expected description and metadata from the service, got %+v", response)
+	}
+}
+
 func TestConfigHandler_AddConfiguration_BadRequest(t *testing.T) {
 	mockService := NewMockService()
 	handler := NewConfigHandler(mockService)
@@ -202,6 +588,51 @@ func TestConfigHandler_AddConfiguration_BadRequest(t *testing.T) {
 }
 
 // ISPRAVLJENI TEST ZA GET: Koristi mux.Vars
+func TestConfigHandler_AddConfiguration_Validation(t *testing.T) {
+	handler := NewConfigHandler(NewMockService())
+
+	body := `{"name":"team/api","version":"","params":[{"key":"a","value":"1"},{"key":"a","value":"2"}],"labels":[{"key":"env","value":"a;b"}]}`
+	req := httptest.NewRequest("POST", "/configurations", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	handler.HandleAddConfiguration(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var p problem.Problem
+	if err := json.NewDecoder(rr.Body).Decode(&p); err != nil {
+		t.Fatalf("Invalid problem body: %v", err)
+	}
+	// Sve greske se prijavljuju odjednom
+	fields := map[string]bool{}
+	for _, e := range p.Errors {
+		fields[e.Field] = true
+	}
+	for _, f := range []string{"name", "version", "params[1].key", "labels[0].value"} {
+		if !fields[f] {
+			t.Errorf("Expected a violation for %s, got %+v", f, p.Errors)
+		}
+	}
+	if p.Type != problem.TypeValidation {
+		t.Errorf("Expected type %s, got %s", problem.TypeValidation, p.Type)
+	}
+
+	req = httptest.NewRequest("POST", "/configurations", strings.NewReader(`{"name":"api","version":"v1","parms":[]}`))
+	rr = httptest.NewRecorder()
+	handler.HandleAddConfiguration(rr, req)
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "parms") {
+		t.Errorf("Expected 400 for unknown field, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	huge := `{"name":"api","version":"v1","description":"` + strings.Repeat("x", validation.MaxBodySize) + `"}`
+	req = httptest.NewRequest("POST", "/configurations", strings.NewReader(huge))
+	rr = httptest.NewRecorder()
+	handler.HandleAddConfiguration(rr, req)
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for oversized body, got %d", rr.Code)
+	}
+}
+
 func TestConfigHandler_GetConfiguration(t *testing.T) {
 	mockService := NewMockService()
 	handler := NewConfigHandler(mockService)
@@ -354,6 +785,340 @@ func TestConfigHandler_DeleteConfiguration(t *testing.T) {
 	}
 }
 
+func TestConfigHandler_GetEffectiveConfiguration(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+
+	mockService.configs["effective-test:v1.0.0"] = model.Configuration{
+		ID:      uuid.New(),
+		Name:    "effective-test",
+		Version: "v1.0.0",
+		Params:  []model.Parameter{{Key: "port", Value: "8080"}},
+	}
+
+	req := httptest.NewRequest("GET", "/configurations/effective-test/v1.0.0/effective", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"name":    "effective-test",
+		"version": "v1.0.0",
+	})
+	rr := httptest.NewRecorder()
+	handler.HandleGetEffectiveConfiguration(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+
+	var response model.EffectiveConfiguration
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Params) != 1 || response.Params[0].Source.Name != "effective-test" {
+		t.Errorf("Unexpected effective params: %+v", response.Params)
+	}
+
+	// Nepostojeca konfiguracija
+	req = httptest.NewRequest("GET", "/configurations/missing/v1.0.0/effective", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"name":    "missing",
+		"version": "v1.0.0",
+	})
+	rr = httptest.NewRecorder()
+	handler.HandleGetEffectiveConfiguration(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestConfigHandler_GetConfiguration_Latest(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+
+	mockService.configs["latest-test:v1.2.0"] = model.Configuration{ID: uuid.New(), Name: "latest-test", Version: "v1.2.0"}
+
+	req := httptest.NewRequest("GET", "/configurations/latest-test/latest", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"name":    "latest-test",
+		"version": "latest",
+	})
+	rr := httptest.NewRecorder()
+	handler.HandleGetConfiguration(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("X-Resolved-Version"); got != "v1.2.0" {
+		t.Errorf("Expected X-Resolved-Version v1.2.0, got %q", got)
+	}
+}
+
+func TestConfigHandler_TransitionConfiguration(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+
+	mockService.configs["lifecycle-test:v1.0.0"] = model.Configuration{ID: uuid.New(), Name: "lifecycle-test", Version: "v1.0.0"}
+
+	req := httptest.NewRequest("POST", "/configurations/lifecycle-test/v1.0.0/publish", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"name":    "lifecycle-test",
+		"version": "v1.0.0",
+		"action":  "publish",
+	})
+	rr := httptest.NewRecorder()
+	handler.HandleTransitionConfiguration(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	if state := mockService.configs["lifecycle-test:v1.0.0"].State; state != model.StatePublished {
+		t.Errorf("Expected state published, got %q", state)
+	}
+}
+
+func TestConfigHandler_GetConfiguration_Interpolated(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+	mockService.configs[mockService.makeConfigKey("app", "v1")] = model.Configuration{
+		Name:    "app",
+		Version: "v1",
+		Params: []model.Parameter{
+			{Key: "host", Value: "db"},
+			{Key: "url", Value: "postgres://${host}:${port:-5432}/${database}"},
+		},
+	}
+	mockService.configs[mockService.makeConfigKey("loop", "v1")] = model.Configuration{
+		Name:    "loop",
+		Version: "v1",
+		Params:  []model.Parameter{{Key: "a", Value: "${b}"}, {Key: "b", Value: "${a}"}},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		status  int
+		wantURL string
+	}{
+		{"stored", "/configurations/app/v1", http.StatusOK, "postgres://${host}:${port:-5432}/${database}"},
+		{"interpolated", "/configurations/app/v1?interpolate=true", http.StatusOK, "postgres://db:5432/${database}"},
+		{"strict unresolved", "/configurations/app/v1?strict=true", http.StatusUnprocessableEntity, ""},
+		{"reference cycle", "/configurations/loop/v1?interpolate=true", http.StatusUnprocessableEntity, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := strings.Split(strings.TrimPrefix(tt.path, "/configurations/"), "/")[0]
+			req := mux.SetURLVars(httptest.NewRequest("GET", tt.path, nil), map[string]string{"name": name, "version": "v1"})
+			rr := httptest.NewRecorder()
+			handler.HandleGetConfiguration(rr, req)
+
+			if rr.Code != tt.status {
+				t.Fatalf("Expected %d, got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				if ct := rr.Header().Get("Content-Type"); ct != problem.ContentType {
+					t.Errorf("Expected a problem response, got %s", ct)
+				}
+				return
+			}
+			var config model.Configuration
+			if err := json.NewDecoder(rr.Body).Decode(&config); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(config.Params) != 2 || config.Params[0].Value != "db" || config.Params[1].Value != tt.wantURL {
+				t.Errorf("Expected url %q, got %+v", tt.wantURL, config.Params)
+			}
+		})
+	}
+
+	// Efektivna konfiguracija koristi istu obradu gresaka
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/configurations/app/v1/effective?strict=true", nil), map[string]string{"name": "app", "version": "v1"})
+	rr := httptest.NewRecorder()
+	handler.HandleGetEffectiveConfiguration(rr, req)
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a strict effective read, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestConfigHandler_GetConfiguration_DeprecationHeaders(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+
+	deprecatedAt := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := model.Configuration{ID: uuid.New(), Name: "old-api", Version: "v1.0.0"}
+	config.Lifecycle = model.Lifecycle{State: model.StateDeprecated, DeprecatedAt: &deprecatedAt, SunsetAt: &sunset}
+	mockService.configs["old-api:v1.0.0"] = config
+
+	req := httptest.NewRequest("GET", "/configurations/old-api/v1.0.0", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"name":    "old-api",
+		"version": "v1.0.0",
+	})
+	rr := httptest.NewRecorder()
+	handler.HandleGetConfiguration(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Deprecation"); got != "@1759276800" {
+		t.Errorf("Unexpected Deprecation header %q", got)
+	}
+	if got := rr.Header().Get("Sunset"); got != "Thu, 01 Jan 2026 00:00:00 GMT" {
+		t.Errorf("Unexpected Sunset header %q", got)
+	}
+}
+
+func TestConfigHandler_GetConfiguration_Conditional(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+
+	updatedAt := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+	mockService.configs["polled:v1"] = model.Configuration{
+		Name:     "polled",
+		Version:  "v1",
+		Params:   []model.Parameter{{Key: "k", Value: "v"}},
+		Metadata: model.Metadata{UpdatedAt: updatedAt, Revision: 4},
+	}
+
+	get := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/configurations/polled/v1", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		req = mux.SetURLVars(req, map[string]string{"name": "polled", "version": "v1"})
+		rr := httptest.NewRecorder()
+		handler.HandleGetConfiguration(rr, req)
+		return rr
+	}
+
+	first := get(nil)
+	tag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || tag == "" {
+		t.Fatalf("Expected 200 with ETag, got %d and %q", first.Code, tag)
+	}
+	if lm := first.Header().Get("Last-Modified"); lm != updatedAt.Format(http.TimeFormat) {
+		t.Errorf("Expected Last-Modified %q, got %q", updatedAt.Format(http.TimeFormat), lm)
+	}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"matching ETag", map[string]string{"If-None-Match": tag}, http.StatusNotModified},
+		{"weak matching ETag", map[string]string{"If-None-Match": "W/" + tag}, http.StatusNotModified},
+		{"stale ETag", map[string]string{"If-None-Match": `"stale"`}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": updatedAt.Add(time.Minute).Format(http.TimeFormat)}, http.StatusNotModified},
+		{"modified since", map[string]string{"If-Modified-Since": updatedAt.Add(-time.Minute).Format(http.TimeFormat)}, http.StatusOK},
+		{"If-None-Match wins", map[string]string{"If-None-Match": `"stale"`, "If-Modified-Since": updatedAt.Format(http.TimeFormat)}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		rr := get(tt.headers)
+		if rr.Code != tt.want {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.want, rr.Code)
+		}
+		if rr.Code == http.StatusNotModified && rr.Body.Len() != 0 {
+			t.Errorf("%s: 304 must not have a body", tt.name)
+		}
+	}
+}
+
+func TestConfigHandler_GetConfiguration_Formats(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+	mockService.configs["fmt:v1"] = model.Configuration{
+		Name:    "fmt",
+		Version: "v1",
+		Params:  []model.Parameter{{Key: "db.host", Value: "localhost"}},
+	}
+
+	tests := []struct {
+		query       string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"", "application/yaml", http.StatusOK, "application/yaml", "db.host: \"localhost\"\n"},
+		{"?format=env", "application/yaml", http.StatusOK, "text/x-dotenv; charset=utf-8", "DB_HOST=localhost\n"},
+		{"?format=properties", "", http.StatusOK, "text/x-java-properties; charset=utf-8", "db.host=localhost\n"},
+		{"", "text/html", http.StatusOK, "application/json", ""},
+		{"?format=xml", "", http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/configurations/fmt/v1"+tt.query, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		req = mux.SetURLVars(req, map[string]string{"name": "fmt", "version": "v1"})
+		rr := httptest.NewRecorder()
+		handler.HandleGetConfiguration(rr, req)
+
+		if rr.Code != tt.status {
+			t.Errorf("%s %s: expected status %d, got %d", tt.query, tt.accept, tt.status, rr.Code)
+			continue
+		}
+		if tt.contentType != "" && rr.Header().Get("Content-Type") != tt.contentType {
+			t.Errorf("%s %s: expected Content-Type %q, got %q", tt.query, tt.accept, tt.contentType, rr.Header().Get("Content-Type"))
+		}
+		if tt.body != "" && rr.Body.String() != tt.body {
+			t.Errorf("%s %s: expected body %q, got %q", tt.query, tt.accept, tt.body, rr.Body.String())
+		}
+	}
+}
+
+func TestConfigHandler_ImportConfiguration(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+
+	// Telo zahteva u YAML formatu
+	req := httptest.NewRequest("POST", "/configurations/import?name=imported&version=v1&labels=env:dev&separator=_", bytes.NewBufferString("db:\n  host: localhost\n  port: 5432\n"))
+	req.Header.Set("Content-Type", "application/yaml")
+	rr := httptest.NewRecorder()
+	handler.HandleImportConfiguration(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var response model.Configuration
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Params) != 2 || response.Params[0].Key != "db_host" || response.Params[1].Value != "5432" {
+		t.Errorf("Unexpected params: %+v", response.Params)
+	}
+	if len(response.Labels) != 1 || response.Labels[0].Key != "env" {
+		t.Errorf("Unexpected labels: %+v", response.Labels)
+	}
+
+	// Multipart upload, format se odredjuje iz imena fajla
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("name", "from-file")
+	_ = mw.WriteField("version", "v1")
+	part, _ := mw.CreateFormFile("file", "app.properties")
+	_, _ = part.Write([]byte("a=1\nb = two\n"))
+	_ = mw.Close()
+
+	req = httptest.NewRequest("POST", "/configurations/import", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rr = httptest.NewRecorder()
+	handler.HandleImportConfiguration(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201 for multipart import, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if cfg := mockService.configs["from-file:v1"]; len(cfg.Params) != 2 || cfg.Params[1].Value != "two" {
+		t.Errorf("Unexpected imported params: %+v", cfg.Params)
+	}
+
+	// Greska parsiranja sadrzi broj linije
+	req = httptest.NewRequest("POST", "/configurations/import?name=bad&version=v1&format=env", bytes.NewBufferString("A=1\nbroken line\n"))
+	rr = httptest.NewRecorder()
+	handler.HandleImportConfiguration(rr, req)
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "line 2") {
+		t.Errorf("Expected 400 mentioning line 2, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
 func TestConfigHandler_WrongMethod(t *testing.T) {
 	mockService := NewMockService()
 	handler := NewConfigHandler(mockService)
@@ -376,10 +1141,657 @@ func TestConfigHandler_WrongMethod(t *testing.T) {
 	}
 }
 
+func TestConfigHandler_Batch(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+
+	body := `{"mode":"best-effort","operations":[
+		{"op":"create","kind":"configuration","configuration":{"name":"a","version":"v1","params":[]}},
+		{"op":"delete","kind":"configuration","name":"missing","version":"v1"}]}`
+	req := httptest.NewRequest("POST", "/batch", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	handler.HandleBatch(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.BatchResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Results) != 2 || resp.Results[0].Status != http.StatusCreated || resp.Results[1].Status != http.StatusNotFound || resp.Results[1].Error == "" {
+		t.Errorf("Unexpected results: %+v", resp.Results)
+	}
+
+	// Atomicni batch se ponistava i prijavljuje operaciju koja nije uspela
+	body = `{"operations":[
+		{"op":"create","kind":"configuration","configuration":{"name":"b","version":"v1","params":[]}},
+		{"op":"create","kind":"configuration","configuration":{"name":"a","version":"v1","params":[]}}]}`
+	req = httptest.NewRequest("POST", "/batch", strings.NewReader(body))
+	rr = httptest.NewRecorder()
+	handler.HandleBatch(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("Expected 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var p problem.Problem
+	if err := json.NewDecoder(rr.Body).Decode(&p); err != nil {
+		t.Fatalf("Invalid problem body: %v", err)
+	}
+	if len(p.Errors) != 1 || p.Errors[0].Field != "operations[1]" {
+		t.Errorf("Expected the failing operation to be reported, got %+v", p.Errors)
+	}
+	if _, exists := mockService.configs[mockService.makeConfigKey("b", "v1")]; exists {
+		t.Error("Configuration from a failed atomic batch must not be stored")
+	}
+}
+
+// vanishingService simulira zapis obrisan izmedju pripreme i upisa batch-a.
+type vanishingService struct {
+	*MockService
+}
+
+func (v vanishingService) ExecuteBatch(ctx context.Context, req model.BatchRequest, idempotencyKey string) (model.BatchResponse, error) {
+	return model.BatchResponse{}, fmt.Errorf("%w: configuration a/v1 not found", services.ErrRevisionConflict)
+}
+
+func TestConfigHandler_BatchCommitMissingRecord(t *testing.T) {
+	handler := NewConfigHandler(vanishingService{NewMockService()})
+
+	body := `{"operations":[{"op":"update","kind":"configuration","configuration":{"name":"a","version":"v1","params":[]}}]}`
+	rr := httptest.NewRecorder()
+	handler.HandleBatch(rr, httptest.NewRequest("POST", "/batch", strings.NewReader(body)))
+
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("Expected 409 for a record deleted before commit, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestConfigHandler_GroupMembership(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+	mockService.groups[mockService.makeGroupKey("cluster", "v1")] = model.ConfigurationGroup{Name: "cluster", Version: "v1"}
+
+	add := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/configgroups/cluster/v1/configurations", strings.NewReader(`{"name":"api","version":"v1","params":[{"key":"port","value":"80"}]}`))
+		req = mux.SetURLVars(req, map[string]string{"name": "cluster", "version": "v1"})
+		rr := httptest.NewRecorder()
+		handler.HandleAddGroupMember(rr, req)
+		return rr
+	}
+	if rr := add(); rr.Code != http.StatusCreated || rr.Header().Get("Location") != "/configgroups/cluster/v1/configurations/api/v1" {
+		t.Fatalf("Expected 201 with Location, got %d %q: %s", rr.Code, rr.Header().Get("Location"), rr.Body.String())
+	}
+	if rr := add(); rr.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for duplicate member, got %d", rr.Code)
+	}
+
+	memberVars := map[string]string{"name": "cluster", "version": "v1", "cfgName": "api", "cfgVersion": "v1"}
+	req := httptest.NewRequest("PUT", "/configgroups/cluster/v1/configurations/api/v1", strings.NewReader(`{"name":"other","params":[]}`))
+	rr := httptest.NewRecorder()
+	handler.HandleUpdateGroupMember(rr, mux.SetURLVars(req, memberVars))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for name mismatch, got %d", rr.Code)
+	}
+
+	req = httptest.NewRequest("PUT", "/configgroups/cluster/v1/configurations/api/v1", strings.NewReader(`{"params":[{"key":"port","value":"8080"}]}`))
+	rr = httptest.NewRecorder()
+	handler.HandleUpdateGroupMember(rr, mux.SetURLVars(req, memberVars))
+	var group model.ConfigurationGroup
+	if err := json.NewDecoder(rr.Body).Decode(&group); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200 with group, got %d (%v)", rr.Code, err)
+	}
+	if len(group.Configurations) != 1 || group.Configurations[0].Params[0].Value != "8080" {
+		t.Errorf("Member was not updated: %+v", group.Configurations)
+	}
+
+	req = httptest.NewRequest("DELETE", "/configgroups/cluster/v1/configurations/api/v1", nil)
+	rr = httptest.NewRecorder()
+	handler.HandleRemoveGroupMember(rr, mux.SetURLVars(req, memberVars))
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", rr.Code)
+	}
+	rr = httptest.NewRecorder()
+	handler.HandleRemoveGroupMember(rr, mux.SetURLVars(httptest.NewRequest("DELETE", "/configgroups/cluster/v1/configurations/api/v1", nil), memberVars))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for removed member, got %d", rr.Code)
+	}
+}
+
+func TestConfigHandler_Clone(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+	mockService.configs[mockService.makeConfigKey("service-api", "v1")] = model.Configuration{
+		Name: "service-api", Version: "v1",
+		Params: []model.Parameter{{Key: "port", Value: "8080"}},
+	}
+	mockService.groups[mockService.makeGroupKey("cluster", "v1")] = model.ConfigurationGroup{
+		Name: "cluster", Version: "v1",
+		Configurations: []model.Configuration{{Name: "service-api", Version: "v1"}},
+	}
+
+	clone := func(handle http.HandlerFunc, path, name, version, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"name": name, "version": version})
+		rr := httptest.NewRecorder()
+		handle(rr, req)
+		return rr
+	}
+
+	rr := clone(handler.HandleCloneConfiguration, "/configurations/service-api/v1/clone", "service-api", "v1",
+		`{"version":"v2","labels":[{"key":"env","value":"prod"}]}`)
+	if rr.Code != http.StatusCreated || rr.Header().Get("Location") != "/configurations/service-api/v2" {
+		t.Fatalf("Expected 201 with Location, got %d %q: %s", rr.Code, rr.Header().Get("Location"), rr.Body.String())
+	}
+	var config model.Configuration
+	if err := json.NewDecoder(rr.Body).Decode(&config); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if config.Version != "v2" || len(config.Labels) != 1 || config.Metadata.ClonedFrom == nil || config.Metadata.ClonedFrom.Version != "v1" {
+		t.Errorf("Unexpected clone: %+v", config)
+	}
+
+	tests := []struct {
+		name string
+		rr   *httptest.ResponseRecorder
+		want int
+	}{
+		{"target exists", clone(handler.HandleCloneConfiguration, "/configurations/service-api/v1/clone", "service-api", "v1", `{"version":"v2"}`), http.StatusConflict},
+		{"missing version", clone(handler.HandleCloneConfiguration, "/configurations/service-api/v1/clone", "service-api", "v1", `{}`), http.StatusBadRequest},
+		{"unknown field", clone(handler.HandleCloneConfiguration, "/configurations/service-api/v1/clone", "service-api", "v1", `{"version":"v3","env":"prod"}`), http.StatusBadRequest},
+		{"source not found", clone(handler.HandleCloneConfiguration, "/configurations/missing/v1/clone", "missing", "v1", `{"version":"v2"}`), http.StatusNotFound},
+		{"group", clone(handler.HandleCloneConfigurationGroup, "/configgroups/cluster/v1/clone", "cluster", "v1", `{"name":"cluster-prod","version":"v1"}`), http.StatusCreated},
+		{"group not found", clone(handler.HandleCloneConfigurationGroup, "/configgroups/missing/v1/clone", "missing", "v1", `{"version":"v2"}`), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if tt.rr.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, tt.rr.Code, tt.rr.Body.String())
+		}
+	}
+	if _, err := mockService.GetConfigurationGroup(context.Background(), "cluster-prod", "v1"); err != nil {
+		t.Errorf("Cloned group was not stored: %v", err)
+	}
+}
+
+func TestConfigHandler_Render(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+	mockService.groups[mockService.makeGroupKey("service-api", "v1")] = model.ConfigurationGroup{
+		Name: "service-api", Version: "v1",
+		Configurations: []model.Configuration{
+			{Name: "base", Version: "v1", Labels: []model.Parameter{{Key: "env", Value: "prod"}},
+				Params: []model.Parameter{{Key: "db.host", Value: "db"}, {Key: "timeout", Value: "30s"}}},
+			{Name: "eu", Version: "v1", Labels: []model.Parameter{{Key: "env", Value: "prod"}, {Key: "region", Value: "eu"}},
+				Params: []model.Parameter{{Key: "db.host", Value: "db.eu"}}},
+		},
+	}
+
+	render := func(query string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/render?"+query, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rr := httptest.NewRecorder()
+		handler.HandleRender(rr, req)
+		return rr
+	}
+
+	rr := render("group=service-api/v1&labels=env:prod", nil)
+	if rr.Code != http.StatusOK || rr.Header().Get("X-Config-Conflicts") != "db.host" {
+		t.Fatalf("Expected 200 with X-Config-Conflicts, got %d %q: %s", rr.Code, rr.Header().Get("X-Config-Conflicts"), rr.Body.String())
+	}
+	var rendered model.RenderedConfiguration
+	if err := json.NewDecoder(rr.Body).Decode(&rendered); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(rendered.Members) != 2 || len(rendered.Params) != 2 || len(rendered.Conflicts) != 1 {
+		t.Errorf("Unexpected render: %+v", rendered)
+	}
+
+	rr = render("group=service-api/v1&labels=env:prod&format=env", nil)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "DB_HOST=db.eu") || !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/x-dotenv") {
+		t.Errorf("Expected dotenv document, got %d %q: %s", rr.Code, rr.Header().Get("Content-Type"), rr.Body.String())
+	}
+
+	rr = render("group=service-api/v1&labels=region:eu", http.Header{"Accept": {"application/vnd.config.flat+json"}})
+	var flat map[string]string
+	if err := json.NewDecoder(rr.Body).Decode(&flat); err != nil || flat["db.host"] != "db.eu" || len(flat) != 1 {
+		t.Errorf("Expected flat JSON document, got %v (%v)", flat, err)
+	}
+
+	rr = render("group=service-api/v1&labels=env:prod&conflicts=fail", nil)
+	var p problem.Problem
+	if err := json.NewDecoder(rr.Body).Decode(&p); err != nil || rr.Code != http.StatusConflict || len(p.Errors) != 1 || p.Errors[0].Field != "params.db.host" {
+		t.Errorf("Expected 409 listing the conflict, got %d %+v (%v)", rr.Code, p, err)
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"labels=env:prod", http.StatusBadRequest},
+		{"group=service-api", http.StatusBadRequest},
+		{"group=service-api/v1&labels=env", http.StatusBadRequest},
+		{"group=service-api/v1&conflicts=ignore", http.StatusBadRequest},
+		{"group=service-api/v1&format=xml", http.StatusBadRequest},
+		{"group=service-api/v2", http.StatusNotFound},
+		{"group=service-api/v1&labels=env:dev", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if rr := render(tt.query, nil); rr.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.query, tt.want, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestConfigHandler_Watch(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+	mockService.configs[mockService.makeConfigKey("service-api", "v1")] = model.Configuration{Name: "service-api", Version: "v1", Metadata: model.Metadata{Revision: 7}}
+	mockService.groups[mockService.makeGroupKey("cluster", "v1")] = model.ConfigurationGroup{Name: "cluster", Version: "v1", Metadata: model.Metadata{Revision: 3}}
+
+	get := func(handle http.HandlerFunc, path, name, version string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req = mux.SetURLVars(req, map[string]string{"name": name, "version": version})
+		rr := httptest.NewRecorder()
+		handle(rr, req)
+		return rr
+	}
+
+	rr := get(handler.HandleGetConfiguration, "/configurations/service-api/v1?index=0&wait=30s", "service-api", "v1")
+	if rr.Code != http.StatusOK || rr.Header().Get("X-Config-Index") != "7" {
+		t.Errorf("Expected 200 with X-Config-Index 7, got %d %q", rr.Code, rr.Header().Get("X-Config-Index"))
+	}
+	rr = get(handler.HandleGetConfigurationGroup, "/configgroups/cluster/v1?index=2", "cluster", "v1")
+	if rr.Code != http.StatusOK || rr.Header().Get("X-Config-Index") != "3" {
+		t.Errorf("Expected 200 with X-Config-Index 3, got %d %q", rr.Code, rr.Header().Get("X-Config-Index"))
+	}
+	rr = get(handler.HandleGetConfiguration, "/configurations/service-api/v1", "service-api", "v1")
+	if rr.Header().Get("X-Config-Index") != "" {
+		t.Error("Plain reads must not report a watch index")
+	}
+
+	tests := []struct {
+		path, version string
+		want          int
+	}{
+		{"/configurations/service-api/v1?index=-1", "v1", http.StatusBadRequest},
+		{"/configurations/service-api/v1?index=1&wait=soon", "v1", http.StatusBadRequest},
+		{"/configurations/service-api/v1?index=1&wait=-5s", "v1", http.StatusBadRequest},
+		{"/configurations/service-api/latest?index=1", "latest", http.StatusBadRequest},
+		{"/configurations/service-api/v2?index=1", "v2", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if rr := get(handler.HandleGetConfiguration, tt.path, "service-api", tt.version); rr.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.path, tt.want, rr.Code)
+		}
+	}
+}
+
+func TestEventsHandler_HandleEvents(t *testing.T) {
+	log := events.NewLog(0)
+	handler := NewEventsHandler(log)
+	log.Publish(model.Event{Type: model.EventCreated, Kind: model.EventConfiguration, Name: "service-api", Version: "v1", Revision: 1, Labels: []model.Parameter{{Key: "env", Value: "prod"}}})
+	log.Publish(model.Event{Type: model.EventCreated, Kind: model.EventConfiguration, Name: "worker", Version: "v1", Revision: 1, Labels: []model.Parameter{{Key: "env", Value: "dev"}}})
+	log.Publish(model.Event{Type: model.EventDeleted, Kind: model.EventGroup, Name: "cluster", Version: "v1", Revision: 4})
+
+	// Otkazan kontekst: handler pošalje propuštene događaje i odmah se vrati
+	stream := func(target, lastEventID string) *httptest.ResponseRecorder {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req := httptest.NewRequest("GET", target, nil).WithContext(ctx)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		rr := httptest.NewRecorder()
+		handler.HandleEvents(rr, req)
+		return rr
+	}
+
+	rr := stream("/events", "1")
+	if rr.Code != http.StatusOK || rr.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %d %q", rr.Code, rr.Header().Get("Content-Type"))
+	}
+	body := rr.Body.String()
+	if !strings.HasPrefix(body, "id: 2\nevent: created\ndata: {") || !strings.Contains(body, "id: 3\nevent: deleted\n") || strings.Contains(body, "id: 1\n") {
+		t.Errorf("Expected events 2 and 3 after Last-Event-ID 1, got:\n%s", body)
+	}
+
+	body = stream("/events?labels=env:prod&lastEventId=0", "").Body.String()
+	if !strings.Contains(body, `"name":"service-api"`) || strings.Contains(body, `"name":"worker"`) || strings.Contains(body, `"name":"cluster"`) {
+		t.Errorf("Expected only the prod configuration, got:\n%s", body)
+	}
+
+	if body := stream("/events", "").Body.String(); body != "" {
+		t.Errorf("A new stream must not replay the log, got:\n%s", body)
+	}
+	if body := stream("/events", "42").Body.String(); !strings.HasPrefix(body, "event: reset\n") {
+		t.Errorf("Expected a reset event for an unknown id, got:\n%s", body)
+	}
+
+	for _, tt := range []struct{ target, lastEventID string }{
+		{"/events", "abc"},
+		{"/events?lastEventId=-1", ""},
+		{"/events?labels=env", ""},
+	} {
+		if rr := stream(tt.target, tt.lastEventID); rr.Code != http.StatusBadRequest {
+			t.Errorf("%s (Last-Event-ID %q): expected 400, got %d", tt.target, tt.lastEventID, rr.Code)
+		}
+	}
+}
+
+// webhookStore čuva webhook-ove u mapi, za testove WebhookHandler-a
+type webhookStore map[uuid.UUID]model.Webhook
+
+func (s webhookStore) AddWebhook(ctx context.Context, hook model.Webhook) error {
+	s[hook.ID] = hook
+	return nil
+}
+
+func (s webhookStore) GetWebhook(ctx context.Context, id uuid.UUID) (model.Webhook, error) {
+	hook, ok := s[id]
+	if !ok {
+		return model.Webhook{}, errors.New("webhook not found")
+	}
+	return hook, nil
+}
+
+func (s webhookStore) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	delete(s, id)
+	return nil
+}
+
+func (s webhookStore) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	hooks := make([]model.Webhook, 0, len(s))
+	for _, hook := range s {
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
+
+func TestWebhookHandler(t *testing.T) {
+	handler := NewWebhookHandler(webhooks.NewDispatcher(webhookStore{}))
+
+	do := func(handle http.HandlerFunc, method, path, id, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rr := httptest.NewRecorder()
+		handle(rr, req)
+		return rr
+	}
+
+	rr := do(handler.HandleCreateWebhook, "POST", "/webhooks", "", `{"url":"https://deploy.example.com/hook","events":["updated"],"labels":"app:service-api","secret":"s3cr3t"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var created model.Webhook
+	json.NewDecoder(rr.Body).Decode(&created)
+	id := created.ID.String()
+	if created.Secret != "s3cr3t" || rr.Header().Get("Location") != "/webhooks/"+id {
+		t.Errorf("Expected the secret and Location /webhooks/%s, got %+v %q", id, created, rr.Header().Get("Location"))
+	}
+
+	rr = do(handler.HandleGetWebhook, "GET", "/webhooks/"+id, id, "")
+	if rr.Code != http.StatusOK || strings.Contains(rr.Body.String(), "s3cr3t") {
+		t.Errorf("Expected 200 without the secret, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr = do(handler.HandleListWebhooks, "GET", "/webhooks", "", ""); rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), id) {
+		t.Errorf("Expected the webhook in the list, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr = do(handler.HandleWebhookDeliveries, "GET", "/webhooks/"+id+"/deliveries", id, ""); rr.Code != http.StatusOK || strings.TrimSpace(rr.Body.String()) != "[]" {
+		t.Errorf("Expected an empty delivery log, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr = do(handler.HandleDeadLetters, "GET", "/webhooks/deadletters", "", ""); rr.Code != http.StatusOK || strings.TrimSpace(rr.Body.String()) != "[]" {
+		t.Errorf("Expected no dead letters, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = do(handler.HandleCreateWebhook, "POST", "/webhooks", "", `{"url":"ftp://example.com","events":["renamed"]}`)
+	var p problem.Problem
+	json.NewDecoder(rr.Body).Decode(&p)
+	if rr.Code != http.StatusBadRequest || len(p.Errors) != 3 {
+		t.Errorf("Expected 400 with errors for url, events[0] and secret, got %d %+v", rr.Code, p.Errors)
+	}
+
+	if rr = do(handler.HandleDeleteWebhook, "DELETE", "/webhooks/"+id, id, ""); rr.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", rr.Code)
+	}
+	tests := []struct {
+		handle http.HandlerFunc
+		method string
+		id     string
+		want   int
+	}{
+		{handler.HandleGetWebhook, "GET", id, http.StatusNotFound},
+		{handler.HandleDeleteWebhook, "DELETE", id, http.StatusNotFound},
+		{handler.HandleWebhookDeliveries, "GET", id, http.StatusNotFound},
+		{handler.HandleGetWebhook, "GET", "not-a-uuid", http.StatusBadRequest},
+		{handler.HandleGetWebhook, "POST", id, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		if rr := do(tt.handle, tt.method, "/webhooks/"+tt.id, tt.id, ""); rr.Code != tt.want {
+			t.Errorf("%s /webhooks/%s: expected %d, got %d", tt.method, tt.id, tt.want, rr.Code)
+		}
+	}
+}
+
+func TestAPI_RegisterV1(t *testing.T) {
+	mockService := NewMockService()
+	mockService.configs[mockService.makeConfigKey("service-api", "v1")] = model.Configuration{Name: "service-api", Version: "v1"}
+	api := &API{
+		Config:   NewConfigHandler(mockService),
+		Events:   NewEventsHandler(events.NewLog(0)),
+		Webhooks: NewWebhookHandler(webhooks.NewDispatcher(webhookStore{})),
+	}
+
+	// Iste rute pod /v1 i kao zastareli alias u korenu
+	router := mux.NewRouter()
+	api.RegisterV1(router.PathPrefix("/v1").Subrouter())
+	legacy := router.NewRoute().Subrouter()
+	legacy.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			next.ServeHTTP(w, r)
+		})
+	})
+	api.RegisterV1(legacy)
+
+	tests := []struct {
+		method, path string
+		want         int
+		deprecated   bool
+	}{
+		{"GET", "/v1/configurations/service-api/v1", http.StatusOK, false},
+		{"GET", "/configurations/service-api/v1", http.StatusOK, true},
+		{"GET", "/v1/webhooks", http.StatusOK, false},
+		{"GET", "/webhooks/deadletters", http.StatusOK, true},
+		{"DELETE", "/v1/configurations/service-api/v1", http.StatusNoContent, false},
+		{"GET", "/v1/configurations/service-api/v1", http.StatusNotFound, false},
+		{"GET", "/v2/configurations/service-api/v1", http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, nil))
+		if rr.Code != tt.want {
+			t.Errorf("%s %s: expected %d, got %d", tt.method, tt.path, tt.want, rr.Code)
+		}
+		if deprecated := rr.Header().Get("Deprecation") != ""; deprecated != tt.deprecated {
+			t.Errorf("%s %s: expected deprecated=%v, got headers %v", tt.method, tt.path, tt.deprecated, rr.Header())
+		}
+	}
+}
+
+// failingService simulira nedostupan repozitorijum.
+type failingService struct {
+	*MockService
+}
+
+func (f failingService) DeleteConfiguration(ctx context.Context, name, version string) error {
+	return errors.New("failed to delete configuration from Consul: dial tcp 127.0.0.1:8500: connect: connection refused")
+}
+
+func (f failingService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error) {
+	return model.Configuration{}, errors.New("failed to put configuration into Consul: Unexpected response code: 500")
+}
+
+func (f failingService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (model.ConfigurationGroup, error) {
+	return model.ConfigurationGroup{}, errors.New("failed to decode configuration group JSON from Consul")
+}
+
+func TestConfigHandler_ProblemResponses(t *testing.T) {
+	handler := NewConfigHandler(NewMockService())
+
+	req := httptest.NewRequest("GET", "/configurations/missing/v1", nil)
+	req = mux.SetURLVars(req, map[string]string{"name": "missing", "version": "v1"})
+	rr := httptest.NewRecorder()
+	handler.HandleGetConfiguration(rr, req)
+
+	if ct := rr.Header().Get("Content-Type"); ct != problem.ContentType {
+		t.Fatalf("Expected Content-Type %s, got %s", problem.ContentType, ct)
+	}
+	var p problem.Problem
+	if err := json.NewDecoder(rr.Body).Decode(&p); err != nil {
+		t.Fatalf("Invalid problem body: %v", err)
+	}
+	if p.Status != http.StatusNotFound || p.Title != "Not Found" || p.Type != problem.TypeDefault || p.Instance != "/configurations/missing/v1" {
+		t.Errorf("Unexpected problem: %+v", p)
+	}
+
+	// Interne greske se loguju, ali ne salju klijentu
+	handler = NewConfigHandler(failingService{NewMockService()})
+	req = httptest.NewRequest("DELETE", "/configurations/app/v1", nil)
+	req = mux.SetURLVars(req, map[string]string{"name": "app", "version": "v1"})
+	rr = httptest.NewRecorder()
+	handler.HandleDeleteConfiguration(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", rr.Code)
+	}
+	if strings.Contains(rr.Body.String(), "Consul") {
+		t.Errorf("Internal error leaked to the client: %s", rr.Body.String())
+	}
+}
+
+// Samo postojeci entitet je konflikt; ostale greske pri dodavanju su interne
+func TestConfigHandler_AddInternalErrors(t *testing.T) {
+	handler := NewConfigHandler(failingService{NewMockService()})
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		handle http.HandlerFunc
+	}{
+		{"Configuration", "/configurations", `{"name":"app","version":"v1.0.0","params":[{"key":"port","value":"8080"}]}`, handler.HandleAddConfiguration},
+		{"Group", "/configgroups", `{"name":"cluster","version":"v1.0.0","configurations":[]}`, handler.HandleAddConfigurationGroup},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			tt.handle(rr, httptest.NewRequest("POST", tt.path, strings.NewReader(tt.body)))
+
+			if rr.Code != http.StatusInternalServerError {
+				t.Fatalf("Expected 500, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if strings.Contains(rr.Body.String(), "Consul") {
+				t.Errorf("Internal error leaked to the client: %s", rr.Body.String())
+			}
+		})
+	}
+
+	// Postojeca konfiguracija i dalje daje 409
+	mock := NewMockService()
+	mock.configs[mock.makeConfigKey("app", "v1.0.0")] = model.Configuration{Name: "app", Version: "v1.0.0"}
+	rr := httptest.NewRecorder()
+	NewConfigHandler(mock).HandleAddConfiguration(rr, httptest.NewRequest("POST", "/configurations", strings.NewReader(tests[0].body)))
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for an existing configuration, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
 // -------------------------------------------------------------------
 // Group Tests
 // -------------------------------------------------------------------
 
+func TestConfigHandler_ExportImport(t *testing.T) {
+	source := NewMockService()
+	source.configs[source.makeConfigKey("service-api", "v1")] = model.Configuration{Name: "service-api", Version: "v1", Params: []model.Parameter{{Key: "port", Value: "8080"}}}
+	source.configs[source.makeConfigKey("service-web", "v1")] = model.Configuration{Name: "service-web", Version: "v1"}
+	target := NewMockService()
+	target.configs[target.makeConfigKey("service-web", "v1")] = model.Configuration{Name: "service-web", Version: "v1"}
+
+	for _, format := range []string{"jsonl", "tar.gz"} {
+		t.Run(format, func(t *testing.T) {
+			sourceRouter, targetRouter := mux.NewRouter(), mux.NewRouter()
+			(&API{Config: NewConfigHandler(source)}).RegisterAdmin(sourceRouter)
+			(&API{Config: NewConfigHandler(target)}).RegisterAdmin(targetRouter)
+
+			rr := httptest.NewRecorder()
+			sourceRouter.ServeHTTP(rr, httptest.NewRequest("GET", "/admin/export?format="+format, nil))
+			if rr.Code != http.StatusOK {
+				t.Fatalf("Export: expected 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if cd := rr.Header().Get("Content-Disposition"); !strings.HasSuffix(cd, "."+format+`"`) {
+				t.Errorf("Unexpected Content-Disposition %q", cd)
+			}
+			archive := rr.Body.Bytes()
+
+			// Podrazumevani režim odbija uvoz jer service-web već postoji
+			rr = httptest.NewRecorder()
+			targetRouter.ServeHTTP(rr, httptest.NewRequest("POST", "/admin/import", bytes.NewReader(archive)))
+			if rr.Code != http.StatusConflict || !strings.Contains(rr.Body.String(), "configuration service-web/v1") {
+				t.Fatalf("Import: expected 409 naming service-web/v1, got %d: %s", rr.Code, rr.Body.String())
+			}
+
+			rr = httptest.NewRecorder()
+			targetRouter.ServeHTTP(rr, httptest.NewRequest("POST", "/admin/import?mode=skip&dryRun=true", bytes.NewReader(archive)))
+			var summary model.ImportSummary
+			if err := json.NewDecoder(rr.Body).Decode(&summary); err != nil || rr.Code != http.StatusOK {
+				t.Fatalf("Dry run: expected 200 with a summary, got %d (%v)", rr.Code, err)
+			}
+			if !summary.DryRun || summary.Configurations.Created != 1 || summary.Configurations.Skipped != 1 {
+				t.Errorf("Unexpected dry run summary: %+v", summary)
+			}
+			if _, exists := target.configs[target.makeConfigKey("service-api", "v1")]; exists {
+				t.Fatal("Dry run must not write anything")
+			}
+
+			rr = httptest.NewRecorder()
+			targetRouter.ServeHTTP(rr, httptest.NewRequest("POST", "/admin/import?mode=skip", bytes.NewReader(archive)))
+			if rr.Code != http.StatusOK {
+				t.Fatalf("Import: expected 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if got := target.configs[target.makeConfigKey("service-api", "v1")]; len(got.Params) != 1 {
+				t.Errorf("service-api/v1 was not imported: %+v", got)
+			}
+			delete(target.configs, target.makeConfigKey("service-api", "v1"))
+		})
+	}
+
+	tests := []struct {
+		name, path, body string
+		want             int
+	}{
+		{"unknown format", "/admin/export?format=zip", "", http.StatusBadRequest},
+		{"not a snapshot", "/admin/import", `{"name":"service-api"}`, http.StatusBadRequest},
+		{"truncated", "/admin/import", `{"kind":"header","header":{"format":"alati-config-snapshot","version":1,"counts":{"configurations":2}}}`, http.StatusBadRequest},
+	}
+	router := mux.NewRouter()
+	(&API{Config: NewConfigHandler(target)}).RegisterAdmin(router)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := "POST"
+			if tt.body == "" {
+				method = "GET"
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(method, tt.path, strings.NewReader(tt.body)))
+			if rr.Code != tt.want {
+				t.Errorf("Expected %d, got %d: %s", tt.want, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
 func TestConfigHandler_AddConfigurationGroup(t *testing.T) {
 	mockService := NewMockService()
 	handler := NewConfigHandler(mockService)
@@ -537,3 +1949,87 @@ func TestConfigHandler_DeleteConfigurationGroup(t *testing.T) {
 		t.Error("Configuration group was not deleted from mock service")
 	}
 }
+
+// Objavljene i zastarele verzije moraju prvo da se arhiviraju
+func TestConfigHandler_DeleteRequiresDraftOrArchived(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+
+	tests := []struct {
+		state  model.LifecycleState
+		status int
+	}{
+		{model.StateDraft, http.StatusNoContent},
+		{model.StatePublished, http.StatusConflict},
+		{model.StateDeprecated, http.StatusConflict},
+		{model.StateArchived, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.state), func(t *testing.T) {
+			vars := map[string]string{"name": "app", "version": "v1"}
+			mockService.configs[mockService.makeConfigKey("app", "v1")] = model.Configuration{Name: "app", Version: "v1", Lifecycle: model.Lifecycle{State: tt.state}}
+			mockService.groups[mockService.makeGroupKey("app", "v1")] = model.ConfigurationGroup{Name: "app", Version: "v1", Lifecycle: model.Lifecycle{State: tt.state}}
+
+			rr := httptest.NewRecorder()
+			handler.HandleDeleteConfiguration(rr, mux.SetURLVars(httptest.NewRequest("DELETE", "/configurations/app/v1", nil), vars))
+			if rr.Code != tt.status {
+				t.Errorf("Configuration: expected %d, got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+
+			rr = httptest.NewRecorder()
+			handler.HandleDeleteConfigurationGroup(rr, mux.SetURLVars(httptest.NewRequest("DELETE", "/configgroups/app/v1", nil), vars))
+			if rr.Code != tt.status {
+				t.Errorf("Group: expected %d, got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestConfigHandler_PatchConfiguration(t *testing.T) {
+	mockService := NewMockService()
+	handler := NewConfigHandler(mockService)
+	mockService.configs[mockService.makeConfigKey("svc", "v1")] = model.Configuration{
+		Name:    "svc",
+		Version: "v1",
+		Params:  []model.Parameter{{Key: "port", Value: "8080"}},
+		Labels:  []model.Parameter{{Key: "env", Value: "dev"}},
+	}
+
+	patch := func(contentType, ifMatch, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PATCH", "/configurations/svc/v1", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		req = mux.SetURLVars(req, map[string]string{"name": "svc", "version": "v1"})
+		rr := httptest.NewRecorder()
+		handler.HandlePatchConfiguration(rr, req)
+		return rr
+	}
+
+	rr := patch("application/merge-patch+json; charset=utf-8", "", `{"description":"izmenjeno"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var response model.Configuration
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Description != "izmenjeno" || len(response.Labels) != 1 {
+		t.Errorf("Expected description to change and labels to be kept, got %+v", response)
+	}
+	tag := rr.Header().Get("ETag")
+	if tag != etag.Of(response) {
+		t.Errorf("Expected ETag of the patched entity, got %q", tag)
+	}
+
+	if rr := patch(services.MergePatchType, `"stale"`, `{"description":"x"}`); rr.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected status 412 for stale If-Match, got %d", rr.Code)
+	}
+	if rr := patch(services.MergePatchType, tag, `{"description":"x"}`); rr.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for matching If-Match, got %d", rr.Code)
+	}
+	if rr := patch("application/json", "", `{}`); rr.Code != http.StatusUnsupportedMediaType || rr.Header().Get("Accept-Patch") == "" {
+		t.Errorf("Expected status 415 with Accept-Patch, got %d", rr.Code)
+	}
+}
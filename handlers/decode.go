@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"alati_projekat/problem"
+	"alati_projekat/validation"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// decodeJSON decodes a JSON request body of at most validation.MaxBodySize bytes into v.
+// Unknown fields and trailing data are rejected. On failure the problem response has
+// already been written and false is returned.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	return decodeJSONLimit(w, r, validation.MaxBodySize, v)
+}
+
+// decodeJSONLimit is decodeJSON with a custom body size limit.
+func decodeJSONLimit(w http.ResponseWriter, r *http.Request, limit int64, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(v)
+	if err == nil && dec.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("request body must contain a single JSON value")
+	}
+	if err == nil {
+		return true
+	}
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		problem.Write(w, r, http.StatusRequestEntityTooLarge, "Request body must not exceed "+strconv.FormatInt(tooLarge.Limit, 10)+" bytes.")
+		return false
+	}
+	problem.Write(w, r, http.StatusBadRequest, "Invalid request body: "+err.Error())
+	return false
+}
+
+// writeValidationError writes a validation problem with every violation of err and
+// reports whether err was a validation error at all.
+func writeValidationError(w http.ResponseWriter, r *http.Request, status int, err error) bool {
+	return writeValidationErrorAt(w, r, status, "", err)
+}
+
+// writeValidationErrorAt is writeValidationError with every field path prefixed by prefix.
+func writeValidationErrorAt(w http.ResponseWriter, r *http.Request, status int, prefix string, err error) bool {
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		return false
+	}
+	fields := make([]problem.FieldError, len(verr.Violations))
+	for i, v := range verr.Violations {
+		fields[i] = problem.FieldError{Field: prefix + v.Field, Message: v.Message}
+	}
+	problem.WriteProblem(w, r, problem.Problem{
+		Type:   problem.TypeValidation,
+		Status: status,
+		Detail: "The request contains invalid fields.",
+		Errors: fields,
+	})
+	return true
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"alati_projekat/events"
+	"alati_projekat/labels"
+	"alati_projekat/model"
+	"alati_projekat/problem"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultHeartbeat is how often an idle event stream sends a comment to keep proxies from
+// closing the connection.
+const DefaultHeartbeat = 15 * time.Second
+
+// EventsHandler streams change events as server-sent events.
+type EventsHandler struct {
+	Log       *events.Log
+	Heartbeat time.Duration
+}
+
+func NewEventsHandler(log *events.Log) *EventsHandler {
+	return &EventsHandler{
+		Log:       log,
+		Heartbeat: DefaultHeartbeat,
+	}
+}
+
+// eventMatches applies the label selector of a stream. Configuration events match on their
+// labels; group events have none and are only sent to streams without a selector.
+func eventMatches(e model.Event, selector map[string]string) bool {
+	return labels.HasAll(model.Configuration{Labels: e.Labels}, selector)
+}
+
+// writeEvent writes e in the text/event-stream format, using its ID as the SSE id.
+func writeEvent(w http.ResponseWriter, e model.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
+
+// HandleEvents godoc
+// @Summary Strim promena konfiguracija (Server-Sent Events)
+// @Description Šalje događaje created, updated i deleted za konfiguracije i grupe kao text/event-stream. Svaki događaj ima id; klijent koji se ponovo poveže šalje poslednji primljeni id u Last-Event-ID headeru (ili lastEventId parametru) i dobija propuštene događaje iz ograničenog loga. Ako su neki događaji već izbačeni iz loga, prvo se šalje događaj reset. Selektor labela filtrira događaje konfiguracija; događaji grupa šalju se samo kada selektor nije zadat.
+// @Tags events
+// @Produce text/event-stream
+// @Param labels query string false "Selektor labela (k:v;k2:v2)"
+// @Param Last-Event-ID header string false "Id poslednjeg primljenog događaja"
+// @Param lastEventId query string false "Id poslednjeg primljenog događaja, za klijente koji ne mogu da postave header"
+// @Success 200 {object} model.Event "Strim događaja"
+// @Failure 400 {object} problem.Problem "Invalid labels or event id"
+// @Router /events [get]
+func (h *EventsHandler) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleEvents")
+	defer span.End()
+
+	if r.Method != http.MethodGet {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	selector, err := labels.Parse(r.URL.Query().Get("labels"))
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "Invalid 'labels' query: "+err.Error())
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+	var sub *events.Subscription
+	if lastEventID == "" {
+		sub = h.Log.Subscribe()
+	} else {
+		id, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			problem.Write(w, r, http.StatusBadRequest, "Last-Event-ID must be a non-negative integer.")
+			return
+		}
+		sub = h.Log.Resume(id)
+	}
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+	// The stream outlives the server's write timeout.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if sub.Missed {
+		fmt.Fprint(w, "event: reset\ndata: {\"reason\":\"events after the given id are no longer available\"}\n\n")
+	}
+	for _, e := range sub.Backlog {
+		if eventMatches(e, selector) {
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.C:
+			if !ok {
+				// Dropped for falling behind or closed on shutdown; the client reconnects with Last-Event-ID.
+				return
+			}
+			if !eventMatches(e, selector) {
+				continue
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"alati_projekat/formats"
+	"alati_projekat/labels"
+	"alati_projekat/model"
+	"alati_projekat/problem"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// maxImportSize limits the size of an imported file or raw body.
+const maxImportSize = 4 << 20
+
+// readImport returns the imported document together with its declared content type and file name.
+// Multipart requests carry the document in the "file" part; otherwise the body is the document.
+func readImport(w http.ResponseWriter, r *http.Request) ([]byte, string, string, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(maxImportSize); err != nil {
+			return nil, "", "", err
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, "", "", errors.New("multipart field 'file' is required")
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		return data, header.Header.Get("Content-Type"), header.Filename, err
+	}
+
+	data, err := io.ReadAll(r.Body)
+	return data, r.Header.Get("Content-Type"), "", err
+}
+
+// HandleImportConfiguration godoc
+// @Summary Uvozi konfiguraciju iz fajla
+// @Description Kreira konfiguraciju iz YAML, JSON, TOML, .env ili .properties dokumenta, poslatog kao multipart polje "file" ili kao telo zahteva. Ugnježdeni ključevi se spajaju separatorom (podrazumevano "."). Format se određuje iz parametra format, Content-Type-a ili ekstenzije fajla. Greške parsiranja navode broj linije.
+// @Tags configurations
+// @Accept mpfd
+// @Accept application/yaml
+// @Accept application/toml
+// @Accept text/x-dotenv
+// @Accept text/x-java-properties
+// @Produce json
+// @Param X-Request-Id header string false "Idempotency Key (UUID/jedinstveni ID)"
+// @Param name query string true "Ime konfiguracije"
+// @Param version query string true "Verzija konfiguracije"
+// @Param labels query string false "Labeli (k:v;k2:v2)"
+// @Param description query string false "Opis konfiguracije"
+// @Param format query string false "Format ulaza (yaml, json, toml, env, properties)"
+// @Param separator query string false "Separator za ugnježdene ključeve"
+// @Param file formData file false "Fajl sa konfiguracijom"
+// @Success 201 {object} model.Configuration
+// @Failure 400 {object} problem.Problem "Missing name/version, unknown format or parse error with line number"
+// @Failure 409 {object} problem.Problem "Conflict (već postoji)"
+// @Failure 413 {object} problem.Problem "Document too large"
+// @Router /configurations/import [post]
+func (h *ConfigHandler) HandleImportConfiguration(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleImportConfiguration")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	data, contentType, filename, err := readImport(w, r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			problem.Write(w, r, http.StatusRequestEntityTooLarge, "Imported document is too large.")
+			return
+		}
+		problem.Write(w, r, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// FormValue covers both the query string and multipart fields.
+	name := r.FormValue("name")
+	version := r.FormValue("version")
+	if name == "" || version == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Parameters 'name' and 'version' are required.")
+		return
+	}
+
+	wantLabels, err := labels.Parse(r.FormValue("labels"))
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "Invalid 'labels' parameter: "+err.Error())
+		return
+	}
+
+	format, err := formats.Detect(r.FormValue("format"), contentType, filename)
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	params, err := formats.Parse(format, data, r.FormValue("separator"))
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "Parse error: "+err.Error())
+		return
+	}
+
+	newConfig := model.Configuration{
+		ID:          uuid.New(),
+		Name:        name,
+		Version:     version,
+		Params:      params,
+		Labels:      labelParams(wantLabels),
+		Description: r.FormValue("description"),
+	}
+
+	created, err := h.Service.AddConfiguration(ctx, newConfig, r.Header.Get("X-Request-Id"))
+	if err != nil {
+		writeAddConfigurationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(created)
+}
+
+// labelParams converts a parsed label selector into sorted label parameters.
+func labelParams(m map[string]string) []model.Parameter {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]model.Parameter, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, model.Parameter{Key: k, Value: m[k]})
+	}
+	return out
+}
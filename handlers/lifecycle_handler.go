@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/problem"
+	"alati_projekat/services"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// transitionActions maps the action path segment to the target lifecycle state.
+var transitionActions = map[string]model.LifecycleState{
+	"publish":   model.StatePublished,
+	"deprecate": model.StateDeprecated,
+	"archive":   model.StateArchived,
+}
+
+// setLifecycleHeaders announces deprecation on reads using the Deprecation (RFC 9745)
+// and Sunset (RFC 8594) headers.
+func setLifecycleHeaders(w http.ResponseWriter, l model.Lifecycle) {
+	if l.CurrentState() != model.StateDeprecated {
+		return
+	}
+	if l.DeprecatedAt != nil {
+		w.Header().Set("Deprecation", "@"+strconv.FormatInt(l.DeprecatedAt.Unix(), 10))
+	} else {
+		w.Header().Set("Deprecation", "true")
+	}
+	if l.SunsetAt != nil {
+		w.Header().Set("Sunset", l.SunsetAt.UTC().Format(http.TimeFormat))
+	}
+}
+
+// decodeTransition reads the optional transition body and the target state from the path.
+func decodeTransition(r *http.Request) (model.LifecycleState, model.TransitionRequest, error) {
+	var req model.TransitionRequest
+	target, ok := transitionActions[mux.Vars(r)["action"]]
+	if !ok {
+		return "", req, errors.New("unknown lifecycle action, expected publish, deprecate or archive")
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		return "", req, errors.New("Invalid request body: " + err.Error())
+	}
+	return target, req, nil
+}
+
+func writeTransitionError(w http.ResponseWriter, r *http.Request, kind string, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidTransition):
+		problem.Write(w, r, http.StatusConflict, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		problem.Write(w, r, http.StatusNotFound, kind+" not found.")
+	default:
+		log.Printf("Error changing lifecycle state of %s: %v", kind, err)
+		problem.Internal(w, r, err)
+	}
+}
+
+// HandleTransitionConfiguration godoc
+// @Summary Menja stanje životnog ciklusa konfiguracije
+// @Description Prelazi između stanja draft, published, deprecated i archived. Samo draft verzije mogu da se menjaju; deprecated verzije pri čitanju vraćaju Deprecation/Sunset headere.
+// @Tags configurations
+// @Accept json
+// @Produce json
+// @Param name path string true "Ime konfiguracije"
+// @Param version path string true "Verzija konfiguracije"
+// @Param action path string true "Akcija" Enums(publish, deprecate, archive)
+// @Param transition body model.TransitionRequest false "Sunset datum (samo za deprecate)"
+// @Success 200 {object} model.Configuration
+// @Failure 400 {object} problem.Problem "Invalid action or request body"
+// @Failure 404 {object} problem.Problem "Configuration not found"
+// @Failure 409 {object} problem.Problem "Transition not allowed"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
+// @Router /configurations/{name}/{version}/{action} [post]
+func (h *ConfigHandler) HandleTransitionConfiguration(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleTransitionConfiguration")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+	version := vars["version"]
+
+	if name == "" || version == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
+		return
+	}
+
+	target, req, err := decodeTransition(r)
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	config, err := h.Service.TransitionConfiguration(ctx, name, version, target, req.Sunset)
+	if err != nil {
+		writeTransitionError(w, r, "Configuration", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(config)
+}
+
+// HandleTransitionConfigurationGroup godoc
+// @Summary Menja stanje životnog ciklusa grupe konfiguracija
+// @Description Prelazi između stanja draft, published, deprecated i archived. Samo draft verzije mogu da se menjaju.
+// @Tags configuration_groups
+// @Accept json
+// @Produce json
+// @Param name path string true "Ime grupe"
+// @Param version path string true "Verzija grupe"
+// @Param action path string true "Akcija" Enums(publish, deprecate, archive)
+// @Param transition body model.TransitionRequest false "Sunset datum (samo za deprecate)"
+// @Success 200 {object} model.ConfigurationGroup
+// @Failure 400 {object} problem.Problem "Invalid action or request body"
+// @Failure 404 {object} problem.Problem "Configuration group not found"
+// @Failure 409 {object} problem.Problem "Transition not allowed"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
+// @Router /configgroups/{name}/{version}/{action} [post]
+func (h *ConfigHandler) HandleTransitionConfigurationGroup(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleTransitionConfigurationGroup")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+	version := vars["version"]
+
+	if name == "" || version == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
+		return
+	}
+
+	target, req, err := decodeTransition(r)
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	group, err := h.Service.TransitionConfigurationGroup(ctx, name, version, target, req.Sunset)
+	if err != nil {
+		writeTransitionError(w, r, "Configuration group", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(group)
+}
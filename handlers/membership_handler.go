@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/problem"
+	"alati_projekat/services"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func writeMembershipError(w http.ResponseWriter, r *http.Request, err error) {
+	if writeValidationError(w, r, http.StatusBadRequest, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, services.ErrDuplicateMember), errors.Is(err, services.ErrImmutable), errors.Is(err, services.ErrRevisionConflict):
+		problem.Write(w, r, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrMemberNotFound):
+		problem.Write(w, r, http.StatusNotFound, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		problem.Write(w, r, http.StatusNotFound, "Configuration group not found.")
+	default:
+		problem.Internal(w, r, err)
+	}
+}
+
+// HandleAddGroupMember godoc
+// @Summary Dodaje konfiguraciju u grupu
+// @Description Dodaje jednu konfiguraciju u grupu bez slanja cele grupe. Grupa mora biti u draft stanju i ne sme već sadržati konfiguraciju sa istim imenom i verzijom.
+// @Tags configuration_groups
+// @Accept json
+// @Produce json
+// @Param name path string true "Ime grupe"
+// @Param version path string true "Verzija grupe"
+// @Param config body model.CreateConfigurationRequest true "Konfiguracija koja se dodaje"
+// @Success 201 {object} model.ConfigurationGroup
+// @Failure 400 {object} problem.Problem "Invalid request body or field validation errors"
+// @Failure 404 {object} problem.Problem "Configuration group not found"
+// @Failure 409 {object} problem.Problem "Duplicate member, group is not a draft or was modified concurrently"
+// @Router /configgroups/{name}/{version}/configurations [post]
+func (h *ConfigHandler) HandleAddGroupMember(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleAddGroupMember")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	vars := mux.Vars(r)
+	name, version := vars["name"], vars["version"]
+	if name == "" || version == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
+		return
+	}
+
+	var req model.CreateConfigurationRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	member := model.Configuration{
+		ID:           uuid.New(),
+		Name:         req.Name,
+		Version:      req.Version,
+		Params:       req.Params,
+		Labels:       req.Labels,
+		Parent:       req.Parent,
+		RemoveParams: req.RemoveParams,
+		Description:  req.Description,
+	}
+
+	group, err := h.Service.AddGroupMember(ctx, name, version, member)
+	if err != nil {
+		writeMembershipError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", r.URL.Path+"/"+member.Name+"/"+member.Version)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(group)
+}
+
+// HandleUpdateGroupMember godoc
+// @Summary Menja konfiguraciju unutar grupe
+// @Description Zamenjuje člana grupe sa zadatim imenom i verzijom. Ime i verzija u telu su opcioni, ali ako su navedeni moraju odgovarati putanji.
+// @Tags configuration_groups
+// @Accept json
+// @Produce json
+// @Param name path string true "Ime grupe"
+// @Param version path string true "Verzija grupe"
+// @Param cfgName path string true "Ime konfiguracije"
+// @Param cfgVersion path string true "Verzija konfiguracije"
+// @Param config body model.CreateConfigurationRequest true "Nova vrednost člana"
+// @Success 200 {object} model.ConfigurationGroup
+// @Failure 400 {object} problem.Problem "Invalid request body, name/version mismatch or field validation errors"
+// @Failure 404 {object} problem.Problem "Configuration group or member not found"
+// @Failure 409 {object} problem.Problem "Group is not a draft or was modified concurrently"
+// @Router /configgroups/{name}/{version}/configurations/{cfgName}/{cfgVersion} [put]
+func (h *ConfigHandler) HandleUpdateGroupMember(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleUpdateGroupMember")
+	defer span.End()
+
+	if r.Method != http.MethodPut {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	vars := mux.Vars(r)
+	name, version := vars["name"], vars["version"]
+	cfgName, cfgVersion := vars["cfgName"], vars["cfgVersion"]
+	if name == "" || version == "" || cfgName == "" || cfgVersion == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name', 'version', 'cfgName' and 'cfgVersion' are required.")
+		return
+	}
+
+	var req model.CreateConfigurationRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if (req.Name != "" && req.Name != cfgName) || (req.Version != "" && req.Version != cfgVersion) {
+		problem.Write(w, r, http.StatusBadRequest, "Name and version in the body must match the path.")
+		return
+	}
+	member := model.Configuration{
+		Name:         cfgName,
+		Version:      cfgVersion,
+		Params:       req.Params,
+		Labels:       req.Labels,
+		Parent:       req.Parent,
+		RemoveParams: req.RemoveParams,
+		Description:  req.Description,
+	}
+
+	group, err := h.Service.UpdateGroupMember(ctx, name, version, member)
+	if err != nil {
+		writeMembershipError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(group)
+}
+
+// HandleRemoveGroupMember godoc
+// @Summary Uklanja konfiguraciju iz grupe
+// @Description Uklanja člana grupe sa zadatim imenom i verzijom.
+// @Tags configuration_groups
+// @Param name path string true "Ime grupe"
+// @Param version path string true "Verzija grupe"
+// @Param cfgName path string true "Ime konfiguracije"
+// @Param cfgVersion path string true "Verzija konfiguracije"
+// @Success 204 "No Content"
+// @Failure 404 {object} problem.Problem "Configuration group or member not found"
+// @Failure 409 {object} problem.Problem "Group is not a draft or was modified concurrently"
+// @Router /configgroups/{name}/{version}/configurations/{cfgName}/{cfgVersion} [delete]
+func (h *ConfigHandler) HandleRemoveGroupMember(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleRemoveGroupMember")
+	defer span.End()
+
+	if r.Method != http.MethodDelete {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	vars := mux.Vars(r)
+	name, version := vars["name"], vars["version"]
+	cfgName, cfgVersion := vars["cfgName"], vars["cfgVersion"]
+	if name == "" || version == "" || cfgName == "" || cfgVersion == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name', 'version', 'cfgName' and 'cfgVersion' are required.")
+		return
+	}
+
+	if _, err := h.Service.RemoveGroupMember(ctx, name, version, cfgName, cfgVersion); err != nil {
+		writeMembershipError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
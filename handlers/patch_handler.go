@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"alati_projekat/etag"
+	"alati_projekat/problem"
+	"alati_projekat/services"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// maxPatchBodySize limits the size of a patch document.
+const maxPatchBodySize = 1 << 20
+
+// acceptPatch is advertised on 415 responses, as recommended by RFC 5789.
+var acceptPatch = services.MergePatchType + ", " + services.JSONPatchType
+
+// readPatch returns the patch media type and document of a PATCH request.
+func readPatch(w http.ResponseWriter, r *http.Request) (string, []byte, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType = ""
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchBodySize))
+	if err != nil {
+		return "", nil, err
+	}
+	return mediaType, body, nil
+}
+
+func writePatchError(w http.ResponseWriter, r *http.Request, kind string, err error) {
+	if writeValidationError(w, r, http.StatusUnprocessableEntity, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, services.ErrUnsupportedPatchType):
+		w.Header().Set("Accept-Patch", acceptPatch)
+		problem.Write(w, r, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, services.ErrMalformedPatch):
+		problem.Write(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrPreconditionFailed):
+		problem.Write(w, r, http.StatusPreconditionFailed, err.Error())
+	case errors.Is(err, services.ErrImmutable), errors.Is(err, services.ErrRevisionConflict):
+		problem.Write(w, r, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidPatch), isInheritanceError(err):
+		problem.Write(w, r, http.StatusUnprocessableEntity, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		problem.Write(w, r, http.StatusNotFound, kind+" not found.")
+	default:
+		problem.Internal(w, r, err)
+	}
+}
+
+// HandlePatchConfiguration godoc
+// @Summary Delimično ažurira konfiguraciju
+// @Description Primenjuje JSON Merge Patch (RFC 7396) ili JSON Patch (RFC 6902) na sačuvanu konfiguraciju. Ime, verzija, id, stanje i metapodaci se ne menjaju. Sa If-Match headerom izmena se primenjuje samo ako se ETag poklapa.
+// @Tags configurations
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param name path string true "Ime konfiguracije"
+// @Param version path string true "Verzija konfiguracije"
+// @Param If-Match header string false "ETag sačuvane konfiguracije"
+// @Param patch body object true "Patch dokument"
+// @Success 200 {object} model.Configuration
+// @Failure 400 {object} problem.Problem "Malformed patch document"
+// @Failure 404 {object} problem.Problem "Configuration not found"
+// @Failure 409 {object} problem.Problem "Configuration is not a draft or was modified concurrently"
+// @Failure 412 {object} problem.Problem "If-Match does not match"
+// @Failure 415 {object} problem.Problem "Unsupported patch media type"
+// @Failure 422 {object} problem.Problem "Patch cannot be applied or produces invalid fields"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
+// @Router /configurations/{name}/{version} [patch]
+func (h *ConfigHandler) HandlePatchConfiguration(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandlePatchConfiguration")
+	defer span.End()
+
+	if r.Method != http.MethodPatch {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+	version := vars["version"]
+
+	if name == "" || version == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
+		return
+	}
+
+	patchType, patch, err := readPatch(w, r)
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	config, err := h.Service.PatchConfiguration(ctx, name, version, patchType, patch, r.Header.Get("If-Match"))
+	if err != nil {
+		writePatchError(w, r, "Configuration", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag.Of(config))
+	_ = json.NewEncoder(w).Encode(config)
+}
+
+// HandlePatchConfigurationGroup godoc
+// @Summary Delimično ažurira grupu konfiguracija
+// @Description Primenjuje JSON Merge Patch (RFC 7396) ili JSON Patch (RFC 6902) na sačuvanu grupu. Ime, verzija, id, stanje i metapodaci se ne menjaju. Sa If-Match headerom izmena se primenjuje samo ako se ETag poklapa.
+// @Tags configuration_groups
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param name path string true "Ime grupe"
+// @Param version path string true "Verzija grupe"
+// @Param If-Match header string false "ETag sačuvane grupe"
+// @Param patch body object true "Patch dokument"
+// @Success 200 {object} model.ConfigurationGroup
+// @Failure 400 {object} problem.Problem "Malformed patch document"
+// @Failure 404 {object} problem.Problem "Configuration group not found"
+// @Failure 409 {object} problem.Problem "Group is not a draft or was modified concurrently"
+// @Failure 412 {object} problem.Problem "If-Match does not match"
+// @Failure 415 {object} problem.Problem "Unsupported patch media type"
+// @Failure 422 {object} problem.Problem "Patch cannot be applied or produces invalid fields"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
+// @Router /configgroups/{name}/{version} [patch]
+func (h *ConfigHandler) HandlePatchConfigurationGroup(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandlePatchConfigurationGroup")
+	defer span.End()
+
+	if r.Method != http.MethodPatch {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+	version := vars["version"]
+
+	if name == "" || version == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameters 'name' and 'version' are required.")
+		return
+	}
+
+	patchType, patch, err := readPatch(w, r)
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	group, err := h.Service.PatchConfigurationGroup(ctx, name, version, patchType, patch, r.Header.Get("If-Match"))
+	if err != nil {
+		writePatchError(w, r, "Configuration group", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag.Of(group))
+	_ = json.NewEncoder(w).Encode(group)
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"alati_projekat/formats"
+	"alati_projekat/labels"
+	"alati_projekat/model"
+	"alati_projekat/problem"
+	"alati_projekat/services"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Values of the ?conflicts query parameter of GET /render.
+const (
+	conflictsPrecedence = "precedence"
+	conflictsFail       = "fail"
+)
+
+func writeRenderError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidVersionSelector):
+		problem.Write(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrNoMatchingMembers):
+		problem.Write(w, r, http.StatusNotFound, err.Error())
+	case isInheritanceError(err):
+		problem.Write(w, r, http.StatusUnprocessableEntity, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		problem.Write(w, r, http.StatusNotFound, "Configuration group not found.")
+	default:
+		problem.Internal(w, r, err)
+	}
+}
+
+// conflictErrors lists every conflicting key with its competing values.
+func conflictErrors(conflicts []model.ParameterConflict) []problem.FieldError {
+	errs := make([]problem.FieldError, len(conflicts))
+	for i, c := range conflicts {
+		values := make([]string, len(c.Values))
+		for j, v := range c.Values {
+			values[j] = fmt.Sprintf("%q from %s/%s", v.Value, v.Source.Name, v.Source.Version)
+		}
+		errs[i] = problem.FieldError{Field: "params." + c.Key, Message: "set to different values: " + strings.Join(values, ", ")}
+	}
+	return errs
+}
+
+// HandleRender godoc
+// @Summary Vraća efektivnu ravnu konfiguraciju instance servisa
+// @Description Bira članove grupe čiji labeli odgovaraju selektoru, spaja svakog sa njegovim roditeljima i zatim spaja njihove parametre u jedan ravan dokument. Redosled prednosti: članovi sa manje labela su opštiji i primenjuju se prvi, a članovi sa istim brojem labela primenjuju se redom kojim su u grupi, pa poslednji pobeđuje. Ključevi kojima članovi daju različite vrednosti navode se kao konflikti (u JSON odgovoru i u X-Config-Conflicts headeru); sa conflicts=fail odgovor je 409.
+// @Tags render
+// @Produce json
+// @Produce application/yaml
+// @Produce application/toml
+// @Produce text/x-dotenv
+// @Produce text/x-java-properties
+// @Produce application/vnd.config.flat+json
+// @Param group query string true "Grupa u obliku ime/verzija; verzija može biti latest ili semver ograničenje"
+// @Param labels query string false "Selektor labela (k:v;k2:v2); bez selektora biraju se svi članovi"
+// @Param conflicts query string false "Ponašanje pri konfliktima" Enums(precedence, fail)
+// @Param prerelease query bool false "Ograničenje verzije grupe može da izabere i pre-release verzije"
+// @Param format query string false "Format odgovora (json, yaml, toml, env, properties, flat); ima prednost nad Accept headerom"
+// @Param If-None-Match header string false "ETag iz prethodnog odgovora"
+// @Success 200 {object} model.RenderedConfiguration
+// @Success 304 "Not Modified"
+// @Failure 400 {object} problem.Problem "Missing or malformed query parameters or unknown format"
+// @Failure 404 {object} problem.Problem "Group not found or no member matches the selector"
+// @Failure 409 {object} problem.Problem "Members conflict and conflicts=fail was requested"
+// @Failure 422 {object} problem.Problem "Broken parent chain, inheritance cycle or keys colliding in the requested format"
+// @Router /render [get]
+func (h *ConfigHandler) HandleRender(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleRender")
+	defer span.End()
+
+	if r.Method != http.MethodGet {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	query := r.URL.Query()
+	name, version, ok := strings.Cut(query.Get("group"), "/")
+	if !ok || name == "" || version == "" {
+		problem.Write(w, r, http.StatusBadRequest, "Query parameter 'group' is required in the form name/version.")
+		return
+	}
+	selector, err := labels.Parse(query.Get("labels"))
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "Invalid 'labels' query: "+err.Error())
+		return
+	}
+	onConflict := query.Get("conflicts")
+	if onConflict != "" && onConflict != conflictsPrecedence && onConflict != conflictsFail {
+		problem.Write(w, r, http.StatusBadRequest, "Query parameter 'conflicts' must be 'precedence' or 'fail'.")
+		return
+	}
+
+	format, ok := negotiateFormat(w, r)
+	if !ok {
+		return
+	}
+
+	rendered, err := h.Service.RenderConfiguration(ctx, name, version, selector, prereleaseRequested(r))
+	if err != nil {
+		writeRenderError(w, r, err)
+		return
+	}
+	if rendered.Group.Version != version {
+		w.Header().Set("X-Resolved-Version", rendered.Group.Version)
+	}
+
+	if len(rendered.Conflicts) > 0 {
+		if onConflict == conflictsFail {
+			problem.WriteProblem(w, r, problem.Problem{
+				Status: http.StatusConflict,
+				Detail: fmt.Sprintf("%d parameter(s) are set to different values by the selected configurations.", len(rendered.Conflicts)),
+				Errors: conflictErrors(rendered.Conflicts),
+			})
+			return
+		}
+		keys := make([]string, len(rendered.Conflicts))
+		for i, c := range rendered.Conflicts {
+			keys[i] = c.Key
+		}
+		w.Header().Set("X-Config-Conflicts", strings.Join(keys, ","))
+	}
+
+	params := make([]model.Parameter, len(rendered.Params))
+	for i, p := range rendered.Params {
+		params[i] = model.Parameter{Key: p.Key, Value: p.Value}
+	}
+	// Members may inherit from other configurations, so there is no single modification time.
+	writeNegotiated(w, r, format, rendered, []formats.Section{{Params: params}}, time.Time{})
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/problem"
+	"alati_projekat/services"
+	"alati_projekat/snapshot"
+	"alati_projekat/validation"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// snapshotTransferTimeout replaces the server's read and write timeouts for exports and
+// imports, which move the whole store in one request.
+const snapshotTransferTimeout = 10 * time.Minute
+
+// HandleExport godoc
+// @Summary Izvozi sve konfiguracije i grupe
+// @Description Vraća snimak celog skladišta: zaglavlje sa verzijom formata i brojem zapisa, zatim sve konfiguracije i grupe sortirane po imenu i verziji. Format "jsonl" (podrazumevano) ima jedan JSON zapis po liniji; "tar.gz" je arhiva sa jednim JSON fajlom po entitetu. Revizije i idempotency ključevi se izvoze samo na zahtev. Snimak se pre slanja u celosti učitava u memoriju, jer zaglavlje navodi broj zapisa.
+// @Tags admin
+// @Produce application/x-ndjson
+// @Produce application/gzip
+// @Param format query string false "Format snimka (jsonl, tar.gz)"
+// @Param revisions query bool false "Zadržava revizije entiteta"
+// @Param idempotencyKeys query bool false "Izvozi i obrađene idempotency ključeve"
+// @Success 200 {file} file "Snimak skladišta"
+// @Failure 400 {object} problem.Problem "Unknown format"
+// @Router /admin/export [get]
+func (h *ConfigHandler) HandleExport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleExport")
+	defer span.End()
+
+	if r.Method != http.MethodGet {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	query := r.URL.Query()
+	format, err := snapshot.ParseFormat(query.Get("format"))
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	var opts model.ExportOptions
+	opts.Revisions, _ = strconv.ParseBool(query.Get("revisions"))
+	opts.IdempotencyKeys, _ = strconv.ParseBool(query.Get("idempotencyKeys"))
+
+	// The whole snapshot is loaded before the response starts: its header announces the
+	// record counts, and a failure while listing can still be reported with a status.
+	snap, err := h.Service.ExportSnapshot(ctx, opts)
+	if err != nil {
+		problem.Internal(w, r, err)
+		return
+	}
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(snapshotTransferTimeout))
+	filename := fmt.Sprintf("snapshot-%s.%s", snap.Header.ExportedAt.UTC().Format("20060102T150405Z"), format.Extension())
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	// The status is already sent; a failed export is detected on import because the
+	// header announces more records than the snapshot holds.
+	if err := snapshot.Write(w, format, snap); err != nil {
+		log.Printf("Export interrupted: %v", err)
+	}
+}
+
+// HandleImport godoc
+// @Summary Uvozi snimak skladišta
+// @Description Upisuje konfiguracije, grupe i idempotency ključeve iz snimka napravljenog sa GET /admin/export, u jsonl ili tar.gz formatu (format se prepoznaje iz sadržaja). Režim "skip" zadržava postojeće entitete, "overwrite" zamenjuje postojeće nacrte (ostali entiteti se zamenjuju samo ako snimak ima njihovu sačuvanu reviziju, inače su konflikt), a "fail-on-conflict" (podrazumevano) odbija ceo uvoz ako bilo koji entitet već postoji. Sa dryRun=true vraća se samo izveštaj o tome šta bi uvoz uradio.
+// @Tags admin
+// @Accept application/x-ndjson
+// @Accept application/gzip
+// @Produce json
+// @Param mode query string false "Režim uvoza (skip, overwrite, fail-on-conflict)"
+// @Param dryRun query bool false "Samo izveštaj, bez upisa"
+// @Success 200 {object} model.ImportSummary
+// @Failure 400 {object} problem.Problem "Invalid snapshot, mode or entity"
+// @Failure 409 {object} problem.Problem "Entities already exist (fail-on-conflict), are not drafts (overwrite) or were modified concurrently"
+// @Failure 413 {object} problem.Problem "Snapshot too large"
+// @Router /admin/import [post]
+func (h *ConfigHandler) HandleImport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleImport")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Now().Add(snapshotTransferTimeout))
+	_ = rc.SetWriteDeadline(time.Now().Add(snapshotTransferTimeout))
+
+	snap, err := snapshot.Read(http.MaxBytesReader(w, r.Body, validation.MaxSnapshotSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge), errors.Is(err, snapshot.ErrTooLarge):
+			problem.Write(w, r, http.StatusRequestEntityTooLarge, "Snapshot is too large.")
+		case errors.Is(err, snapshot.ErrInvalid):
+			problem.Write(w, r, http.StatusBadRequest, err.Error())
+		default:
+			problem.Write(w, r, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		}
+		return
+	}
+
+	query := r.URL.Query()
+	opts := model.ImportOptions{Mode: model.ImportMode(query.Get("mode"))}
+	opts.DryRun, _ = strconv.ParseBool(query.Get("dryRun"))
+
+	summary, err := h.Service.ImportSnapshot(ctx, snap, opts)
+	if err != nil {
+		writeImportError(w, r, summary, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// writeImportError reports a rejected import. Conflicts of a fail-on-conflict import are
+// listed as field errors, one per existing entity.
+func writeImportError(w http.ResponseWriter, r *http.Request, summary model.ImportSummary, err error) {
+	if writeValidationError(w, r, http.StatusBadRequest, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, services.ErrImportConflict):
+		var fields []problem.FieldError
+		for _, item := range summary.Items {
+			if item.Action == model.ImportConflict {
+				fields = append(fields, problem.FieldError{Field: fmt.Sprintf("%s %s/%s", item.Kind, item.Name, item.Version), Message: item.Reason})
+			}
+		}
+		problem.WriteProblem(w, r, problem.Problem{
+			Status: http.StatusConflict,
+			Detail: fmt.Sprintf("The snapshot was not imported: %d entities conflict with stored ones. Use mode skip to keep them, or overwrite to replace drafts.", len(fields)),
+			Errors: fields,
+		})
+	case errors.Is(err, services.ErrRevisionConflict):
+		problem.Write(w, r, http.StatusConflict, "The import was interrupted: "+err.Error())
+	default:
+		problem.Internal(w, r, err)
+	}
+}
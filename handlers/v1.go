@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// RegisterV1 mounts the version 1 routes on r. Paths are relative to the prefix of r, so
+// the same routes can be mounted under /v1 and as deprecated aliases at the root.
+func (a *API) RegisterV1(r *mux.Router) {
+	// GET /events
+	r.Handle("/events", a.read(a.Events.HandleEvents)).Methods("GET")
+
+	// POST /batch
+	r.Handle("/batch", a.write(a.Config.HandleBatch)).Methods("POST")
+
+	// GET /render
+	r.Handle("/render", a.read(a.Config.HandleRender)).Methods("GET")
+
+	// Webhook routes
+	webhookRouter := r.PathPrefix("/webhooks").Subrouter()
+
+	// POST /webhooks
+	webhookRouter.Handle("", a.write(a.Webhooks.HandleCreateWebhook)).Methods("POST")
+	// GET /webhooks
+	webhookRouter.Handle("", a.read(a.Webhooks.HandleListWebhooks)).Methods("GET")
+	// GET /webhooks/deadletters
+	webhookRouter.Handle("/deadletters", a.read(a.Webhooks.HandleDeadLetters)).Methods("GET")
+	// GET /webhooks/{id}
+	webhookRouter.Handle("/{id}", a.read(a.Webhooks.HandleGetWebhook)).Methods("GET")
+	// DELETE /webhooks/{id}
+	webhookRouter.Handle("/{id}", a.write(a.Webhooks.HandleDeleteWebhook)).Methods("DELETE")
+	// GET /webhooks/{id}/deliveries
+	webhookRouter.Handle("/{id}/deliveries", a.read(a.Webhooks.HandleWebhookDeliveries)).Methods("GET")
+
+	// Configuration routes
+	configRouter := r.PathPrefix("/configurations").Subrouter()
+
+	// POST /configurations
+	configRouter.Handle("", a.write(a.Config.HandleAddConfiguration)).Methods("POST")
+	// PUT /configurations
+	configRouter.Handle("", a.write(a.Config.HandleUpdateConfiguration)).Methods("PUT")
+
+	// POST /configurations/import
+	configRouter.Handle("/import", a.write(a.Config.HandleImportConfiguration)).Methods("POST")
+
+	// GET /configurations/{name}/{version}
+	configRouter.Handle("/{name}/{version}", a.read(a.Config.HandleGetConfiguration)).Methods("GET")
+	// PATCH /configurations/{name}/{version}
+	configRouter.Handle("/{name}/{version}", a.write(a.Config.HandlePatchConfiguration)).Methods("PATCH")
+	// DELETE /configurations/{name}/{version}
+	configRouter.Handle("/{name}/{version}", a.write(a.Config.HandleDeleteConfiguration)).Methods("DELETE")
+	// GET /configurations/{name}/{version}/effective
+	configRouter.Handle("/{name}/{version}/effective", a.read(a.Config.HandleGetEffectiveConfiguration)).Methods("GET")
+	// POST /configurations/{name}/{version}/clone
+	configRouter.Handle("/{name}/{version}/clone", a.write(a.Config.HandleCloneConfiguration)).Methods("POST")
+	// POST /configurations/{name}/{version}/{publish|deprecate|archive}
+	configRouter.Handle("/{name}/{version}/{action:publish|deprecate|archive}", a.write(a.Config.HandleTransitionConfiguration)).Methods("POST")
+
+	// Config group routes
+	groupRouter := r.PathPrefix("/configgroups").Subrouter()
+
+	// POST /configgroups
+	groupRouter.Handle("", a.write(a.Config.HandleAddConfigurationGroup)).Methods("POST")
+	// PUT /configgroups
+	groupRouter.Handle("", a.write(a.Config.HandleUpdateConfigurationGroup)).Methods("PUT")
+
+	// GET /configgroups/{name}/{version}
+	groupRouter.Handle("/{name}/{version}", a.read(a.Config.HandleGetConfigurationGroup)).Methods("GET")
+	// PATCH /configgroups/{name}/{version}
+	groupRouter.Handle("/{name}/{version}", a.write(a.Config.HandlePatchConfigurationGroup)).Methods("PATCH")
+	// DELETE /configgroups/{name}/{version}
+	groupRouter.Handle("/{name}/{version}", a.write(a.Config.HandleDeleteConfigurationGroup)).Methods("DELETE")
+	// POST /configgroups/{name}/{version}/clone
+	groupRouter.Handle("/{name}/{version}/clone", a.write(a.Config.HandleCloneConfigurationGroup)).Methods("POST")
+	// POST /configgroups/{name}/{version}/{publish|deprecate|archive}
+	groupRouter.Handle("/{name}/{version}/{action:publish|deprecate|archive}", a.write(a.Config.HandleTransitionConfigurationGroup)).Methods("POST")
+
+	// GET /configgroups/{name}/{version}/configurations
+	groupRouter.Handle("/{name}/{version}/configurations", a.read(a.Config.HandleGetGroupConfigsByLabels)).Methods("GET")
+	// DELETE /configgroups/{name}/{version}/configurations
+	groupRouter.Handle("/{name}/{version}/configurations", a.write(a.Config.HandleDeleteGroupConfigsByLabels)).Methods("DELETE")
+	// POST /configgroups/{name}/{version}/configurations
+	groupRouter.Handle("/{name}/{version}/configurations", a.write(a.Config.HandleAddGroupMember)).Methods("POST")
+	// PUT /configgroups/{name}/{version}/configurations/{cfgName}/{cfgVersion}
+	groupRouter.Handle("/{name}/{version}/configurations/{cfgName}/{cfgVersion}", a.write(a.Config.HandleUpdateGroupMember)).Methods("PUT")
+	// DELETE /configgroups/{name}/{version}/configurations/{cfgName}/{cfgVersion}
+	groupRouter.Handle("/{name}/{version}/configurations/{cfgName}/{cfgVersion}", a.write(a.Config.HandleRemoveGroupMember)).Methods("DELETE")
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"alati_projekat/problem"
+	"alati_projekat/services"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultWatchWait is used when ?index is given without ?wait, as in Consul.
+	defaultWatchWait = 5 * time.Minute
+	// watchWriteSlack is the time left for writing the response after a watch returns.
+	watchWriteSlack = 10 * time.Second
+)
+
+// watchOptions reads the ?index and ?wait long-polling parameters. A request is a watch when
+// either of them is present; ok is false when a 400 response has already been written.
+func watchOptions(w http.ResponseWriter, r *http.Request) (watch bool, index uint64, wait time.Duration, ok bool) {
+	query := r.URL.Query()
+	if !query.Has("index") && !query.Has("wait") {
+		return false, 0, 0, true
+	}
+
+	if raw := query.Get("index"); raw != "" {
+		var err error
+		if index, err = strconv.ParseUint(raw, 10, 64); err != nil {
+			problem.Write(w, r, http.StatusBadRequest, "Query parameter 'index' must be a non-negative integer.")
+			return false, 0, 0, false
+		}
+	}
+	wait = defaultWatchWait
+	if raw := query.Get("wait"); raw != "" {
+		var err error
+		if wait, err = time.ParseDuration(raw); err != nil || wait <= 0 {
+			problem.Write(w, r, http.StatusBadRequest, "Query parameter 'wait' must be a positive duration such as 30s or 5m.")
+			return false, 0, 0, false
+		}
+	}
+	return true, index, min(wait, services.MaxWatchWait), true
+}
+
+// extendWriteDeadline keeps the server's write timeout from cutting off a watch that blocks
+// for up to wait. Writers that do not support deadlines are left alone.
+func extendWriteDeadline(w http.ResponseWriter, wait time.Duration) {
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(wait + watchWriteSlack))
+}
+
+// setWatchIndex sends the index to pass as ?index= on the next watch request.
+func setWatchIndex(w http.ResponseWriter, index uint64) {
+	w.Header().Set("X-Config-Index", strconv.FormatUint(index, 10))
+}
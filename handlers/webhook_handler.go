@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/problem"
+	"alati_projekat/webhooks"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// WebhookHandler manages webhook subscriptions and exposes their delivery history.
+type WebhookHandler struct {
+	Webhooks *webhooks.Dispatcher
+}
+
+func NewWebhookHandler(dispatcher *webhooks.Dispatcher) *WebhookHandler {
+	return &WebhookHandler{
+		Webhooks: dispatcher,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// webhookID parses the {id} path parameter; on failure the problem response has already
+// been written.
+func webhookID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "Path parameter 'id' must be a UUID.")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// HandleCreateWebhook godoc
+// @Summary Registruje webhook
+// @Description Posle svake uspešne izmene konfiguracije ili grupe šalje događaj (isti kao na /events) POST zahtevom na zadati URL. Telo je potpisano HMAC-SHA256 tajnom: X-Webhook-Signature je "sha256=" i heks zapis potpisa. Neuspešne isporuke (bez 2xx odgovora) ponavljaju se sa eksponencijalnim odlaganjem, a posle poslednjeg pokušaja događaj ide u dead-letter listu. Tajna se vraća samo u ovom odgovoru.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body model.CreateWebhookRequest true "URL, tipovi događaja, selektor labela i tajna"
+// @Success 201 {object} model.Webhook
+// @Failure 400 {object} problem.Problem "Invalid request body or field validation errors"
+// @Failure 413 {object} problem.Problem "Request body too large"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
+// @Router /webhooks [post]
+func (h *WebhookHandler) HandleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleCreateWebhook")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	var req model.CreateWebhookRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	hook, err := h.Webhooks.Create(ctx, req)
+	if err != nil {
+		if writeValidationError(w, r, http.StatusBadRequest, err) {
+			return
+		}
+		problem.Internal(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", strings.TrimSuffix(r.URL.Path, "/")+"/"+hook.ID.String())
+	writeJSON(w, http.StatusCreated, hook)
+}
+
+// HandleListWebhooks godoc
+// @Summary Lista webhook-ova
+// @Description Vraća sve registrovane webhook-ove, od najstarijeg, bez tajni.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} model.Webhook
+// @Failure 500 {object} problem.Problem "Internal Server Error"
+// @Router /webhooks [get]
+func (h *WebhookHandler) HandleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleListWebhooks")
+	defer span.End()
+
+	if r.Method != http.MethodGet {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	hooks, err := h.Webhooks.List(ctx)
+	if err != nil {
+		problem.Internal(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hooks)
+}
+
+// HandleGetWebhook godoc
+// @Summary Dobavlja webhook
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Id webhook-a"
+// @Success 200 {object} model.Webhook
+// @Failure 400 {object} problem.Problem "Invalid id"
+// @Failure 404 {object} problem.Problem "Webhook not found"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) HandleGetWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleGetWebhook")
+	defer span.End()
+
+	if r.Method != http.MethodGet {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+	hook, err := h.Webhooks.Get(ctx, id)
+	if err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hook)
+}
+
+// HandleDeleteWebhook godoc
+// @Summary Briše webhook
+// @Description Nove isporuke se više ne pokreću; započete isporuke se završavaju.
+// @Tags webhooks
+// @Param id path string true "Id webhook-a"
+// @Success 204 "No Content"
+// @Failure 400 {object} problem.Problem "Invalid id"
+// @Failure 404 {object} problem.Problem "Webhook not found"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) HandleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleDeleteWebhook")
+	defer span.End()
+
+	if r.Method != http.MethodDelete {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.Webhooks.Delete(ctx, id); err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleWebhookDeliveries godoc
+// @Summary Log isporuka webhook-a
+// @Description Vraća poslednje pokušaje isporuke ovom webhook-u, od najnovijeg. Log se čuva u memoriji i ograničene je veličine.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Id webhook-a"
+// @Success 200 {array} model.WebhookDelivery
+// @Failure 400 {object} problem.Problem "Invalid id"
+// @Failure 404 {object} problem.Problem "Webhook not found"
+// @Failure 500 {object} problem.Problem "Internal Server Error"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) HandleWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "HandleWebhookDeliveries")
+	defer span.End()
+
+	if r.Method != http.MethodGet {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+	if _, err := h.Webhooks.Get(ctx, id); err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.Webhooks.Deliveries(id))
+}
+
+// HandleDeadLetters godoc
+// @Summary Neisporučeni događaji
+// @Description Vraća događaje koji nisu isporučeni ni posle poslednjeg pokušaja, od najnovijeg. Lista se čuva u memoriji i ograničene je veličine.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} model.DeadLetter
+// @Router /webhooks/deadletters [get]
+func (h *WebhookHandler) HandleDeadLetters(w http.ResponseWriter, r *http.Request) {
+	_, span := tracer.Start(r.Context(), "HandleDeadLetters")
+	defer span.End()
+
+	if r.Method != http.MethodGet {
+		problem.Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.Webhooks.DeadLetters())
+}
+
+func writeWebhookError(w http.ResponseWriter, r *http.Request, err error) {
+	if strings.Contains(err.Error(), "not found") {
+		problem.Write(w, r, http.StatusNotFound, "Webhook not found.")
+		return
+	}
+	problem.Internal(w, r, err)
+}
@@ -0,0 +1,143 @@
+package interpolate
+
+import (
+	"alati_projekat/model"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Reference is a single ${...} placeholder found in a parameter value.
+//
+// Supported forms:
+//
+//	${key}                        param of the same configuration
+//	${ref:name/version#key}       param of another configuration
+//	${key:-default}               default used when the reference cannot be resolved
+//	$${...}                       escaped, rendered literally as ${...}
+type Reference struct {
+	// Config is nil for references within the same configuration.
+	Config     *model.ConfigurationRef
+	Key        string
+	Default    string
+	HasDefault bool
+	// Raw is the placeholder exactly as written, including ${ and }.
+	Raw string
+}
+
+// LookupFunc resolves a reference. It returns ok=false when the referenced value does not exist.
+type LookupFunc func(ref Reference) (value string, ok bool, err error)
+
+var (
+	// ErrUnresolved is returned in strict mode when a reference has no value and no default.
+	ErrUnresolved = errors.New("unresolved reference")
+	// ErrSyntax is returned for malformed placeholders.
+	ErrSyntax = errors.New("invalid placeholder")
+)
+
+const refPrefix = "ref:"
+
+// ParseReference parses the expression between ${ and }.
+func ParseReference(expr string) (Reference, error) {
+	ref := Reference{Raw: "${" + expr + "}"}
+
+	if i := strings.Index(expr, ":-"); i >= 0 {
+		ref.Default = expr[i+2:]
+		ref.HasDefault = true
+		expr = expr[:i]
+	}
+
+	if strings.HasPrefix(expr, refPrefix) {
+		target := strings.TrimPrefix(expr, refPrefix)
+		hash := strings.LastIndex(target, "#")
+		if hash < 0 {
+			return Reference{}, fmt.Errorf("%w %s: expected ref:name/version#key", ErrSyntax, ref.Raw)
+		}
+		slash := strings.LastIndex(target[:hash], "/")
+		if slash <= 0 || slash == hash-1 || hash == len(target)-1 {
+			return Reference{}, fmt.Errorf("%w %s: expected ref:name/version#key", ErrSyntax, ref.Raw)
+		}
+		ref.Config = &model.ConfigurationRef{Name: target[:slash], Version: target[slash+1 : hash]}
+		ref.Key = target[hash+1:]
+		return ref, nil
+	}
+
+	if strings.TrimSpace(expr) == "" {
+		return Reference{}, fmt.Errorf("%w %s: empty key", ErrSyntax, ref.Raw)
+	}
+	ref.Key = expr
+	return ref, nil
+}
+
+// Expand replaces every placeholder in s using lookup. Defaults are expanded recursively.
+// In strict mode an unresolved reference without a default fails with ErrUnresolved,
+// otherwise the placeholder is left in the output unchanged.
+func Expand(s string, lookup LookupFunc, strict bool) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "$${") {
+			out.WriteString("${")
+			i += 3
+			continue
+		}
+		if !strings.HasPrefix(s[i:], "${") {
+			out.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		end := closingBrace(s, i+2)
+		if end < 0 {
+			return "", fmt.Errorf("%w: unterminated placeholder in %q", ErrSyntax, s)
+		}
+
+		ref, err := ParseReference(s[i+2 : end])
+		if err != nil {
+			return "", err
+		}
+
+		value, ok, err := lookup(ref)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case ok:
+			out.WriteString(value)
+		case ref.HasDefault:
+			def, err := Expand(ref.Default, lookup, strict)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(def)
+		case strict:
+			return "", fmt.Errorf("%w: %s", ErrUnresolved, ref.Raw)
+		default:
+			out.WriteString(ref.Raw)
+		}
+		i = end + 1
+	}
+
+	return out.String(), nil
+}
+
+// closingBrace returns the index of the } matching a ${ whose body starts at from, honouring nested placeholders.
+func closingBrace(s string, from int) int {
+	depth := 1
+	for j := from; j < len(s); j++ {
+		switch {
+		case strings.HasPrefix(s[j:], "${"):
+			depth++
+			j++
+		case s[j] == '}':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
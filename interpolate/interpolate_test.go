@@ -0,0 +1,69 @@
+package interpolate
+
+import (
+	"errors"
+	"testing"
+)
+
+func mapLookup(values map[string]string) LookupFunc {
+	return func(ref Reference) (string, bool, error) {
+		key := ref.Key
+		if ref.Config != nil {
+			key = ref.Config.Name + "/" + ref.Config.Version + "#" + ref.Key
+		}
+		v, ok := values[key]
+		return v, ok, nil
+	}
+}
+
+func TestExpand(t *testing.T) {
+	lookup := mapLookup(map[string]string{
+		"db.host":               "localhost",
+		"db.port":               "5432",
+		"shared-db/v3#host":     "db.shared",
+		"shared-db/v3#password": "",
+	})
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{"${db.host}:${db.port}", "localhost:5432"},
+		{"${ref:shared-db/v3#host}", "db.shared"},
+		{"${missing:-fallback}", "fallback"},
+		{"${missing:-${db.host}}", "localhost"},
+		{"${ref:shared-db/v3#missing:-none}", "none"},
+		{"${ref:shared-db/v3#password:-unused}", ""},
+		{"$${db.host}", "${db.host}"},
+		{"${missing}", "${missing}"},
+	}
+
+	for _, tt := range tests {
+		got, err := Expand(tt.in, lookup, false)
+		if err != nil {
+			t.Errorf("Expand(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Expand(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExpand_Errors(t *testing.T) {
+	lookup := mapLookup(map[string]string{})
+
+	if _, err := Expand("${missing}", lookup, true); !errors.Is(err, ErrUnresolved) {
+		t.Errorf("Expected ErrUnresolved in strict mode, got: %v", err)
+	}
+	if _, err := Expand("${missing:-ok}", lookup, true); err != nil {
+		t.Errorf("Default should satisfy strict mode, got: %v", err)
+	}
+
+	for _, in := range []string{"${unterminated", "${}", "${ref:no-version#key}", "${ref:name/v1}"} {
+		if _, err := Expand(in, lookup, false); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Expand(%q): expected ErrSyntax, got: %v", in, err)
+		}
+	}
+}
@@ -1,16 +1,24 @@
 package main
 
 import (
+	"alati_projekat/events"
+	"alati_projekat/grpcserver"
 	"alati_projekat/handlers"
 	"alati_projekat/middleware"
 	"alati_projekat/model"
+	"alati_projekat/problem"
 	"alati_projekat/repository"
 	"alati_projekat/services"
+	"alati_projekat/webhooks"
 	"context"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -31,6 +39,8 @@ import (
 
 type application struct {
 	Services services.Service
+	Events   *events.Log
+	Webhooks *webhooks.Dispatcher
 }
 
 func initTracer() *sdktrace.TracerProvider {
@@ -89,7 +99,7 @@ func initTracer() *sdktrace.TracerProvider {
 // @license.url https://opensource.org/licenses/MIT
 
 // @host localhost:8080
-// @BasePath /
+// @BasePath /v1
 func main() {
 	tp := initTracer()
 	defer func() {
@@ -98,25 +108,28 @@ func main() {
 		}
 	}()
 
-	consulAddr := "http://consul:8500"
-
-	if os.Getenv("CONSUL_HTTP_ADDR") != "" {
-		consulAddr = os.Getenv("CONSUL_HTTP_ADDR")
-	}
-
-	repo, err := repository.NewConsulRepository(consulAddr)
-
+	repo, closeRepo, err := openRepository()
 	if err != nil {
-		log.Fatalf("Fatal error: Failed to connect to Consul at %s: %v", consulAddr, err)
+		log.Fatalf("Fatal error: %v", err)
 	}
-	log.Printf("Successfully connected to Consul at %s", consulAddr)
 
 	baseService := services.NewConfigurationService(repo)
-	tracingService := services.NewTracingService(baseService)
+	if strict, _ := strconv.ParseBool(os.Getenv("STRICT_SEMVER")); strict {
+		baseService.StrictVersions = true
+		log.Println("Strict semantic versioning enabled: non-semver versions are rejected on create")
+	}
+	eventLog := events.NewLog(events.DefaultCapacity)
+	eventService := services.NewEventService(baseService, eventLog)
+	tracingService := services.NewTracingService(eventService)
 	configService := services.NewMetricsService(tracingService)
 
+	dispatcher := webhooks.NewDispatcher(repo)
+	dispatcher.Start(eventLog)
+
 	app := &application{
 		Services: configService,
+		Events:   eventLog,
+		Webhooks: dispatcher,
 	}
 	configV1 := model.Configuration{
 		ID:      uuid.New(),
@@ -144,6 +157,24 @@ func main() {
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
+	// End event streams on shutdown, otherwise they keep the server from draining.
+	srv.RegisterOnShutdown(eventLog.Close)
+
+	// gRPC API on its own port, sharing the service chain with the REST handlers
+	grpcPort := ":50051"
+	if os.Getenv("GRPC_PORT") != "" {
+		grpcPort = ":" + strings.TrimPrefix(os.Getenv("GRPC_PORT"), ":")
+	}
+	grpcListener, err := net.Listen("tcp", grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC on %s: %v", grpcPort, err)
+	}
+	grpcServer := grpcserver.NewGRPCServer(configService)
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Printf("gRPC server stopped: %v", err)
+		}
+	}()
 
 	// GRACEFUL SHUTDOWN
 	quit := make(chan os.Signal, 1)
@@ -159,31 +190,100 @@ func main() {
 		if err := srv.Shutdown(ctx); err != nil {
 			log.Fatalf("Server forced to shutdown: %v", err)
 		}
+
+		// Open watch streams keep GracefulStop waiting, so they are cut off with the deadline.
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcServer.Stop()
+		}
 	}()
 
 	log.Printf("Configuration service is running on http://localhost%s...", port)
 	log.Printf("Swagger UI available at http://localhost%s/swagger/index.html", port)
 	log.Printf("Prometheus metrics available at http://localhost%s/metrics", port)
+	log.Printf("gRPC API available on localhost%s", grpcPort)
 
 	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		log.Fatalf("Server failed to start: %v", err)
 	}
 
+	// Pending webhook retries are dropped; their attempts so far stay in the delivery log
+	// until the process exits.
+	dispatcher.Close()
+
+	if err := closeRepo(); err != nil {
+		log.Printf("Error closing repository: %v", err)
+	}
+
 	log.Println("Server exited gracefully.")
 }
 
+// openRepository opens the storage backend selected by STORAGE_BACKEND: "consul" (default)
+// at CONSUL_HTTP_ADDR, "file" in DATA_DIR with FILE_SYNC controlling when the write-ahead
+// log is flushed, or "memory" for a store that is lost on exit. The returned function releases
+// the backend on shutdown.
+func openRepository() (repository.Repository, func() error, error) {
+	switch backend := os.Getenv("STORAGE_BACKEND"); backend {
+	case "", "consul":
+		consulAddr := "http://consul:8500"
+		if os.Getenv("CONSUL_HTTP_ADDR") != "" {
+			consulAddr = os.Getenv("CONSUL_HTTP_ADDR")
+		}
+		repo, err := repository.NewConsulRepository(consulAddr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to Consul at %s: %w", consulAddr, err)
+		}
+		log.Printf("Successfully connected to Consul at %s", consulAddr)
+		return repo, func() error { return nil }, nil
+
+	case "file":
+		dataDir := "./data"
+		if os.Getenv("DATA_DIR") != "" {
+			dataDir = os.Getenv("DATA_DIR")
+		}
+		syncMode, err := repository.ParseSyncMode(os.Getenv("FILE_SYNC"))
+		if err != nil {
+			return nil, nil, err
+		}
+		repo, err := repository.NewFileRepository(dataDir, repository.FileOptions{Sync: syncMode})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open file repository in %s: %w", dataDir, err)
+		}
+		log.Printf("Using file repository in %s (sync: %s)", dataDir, syncMode)
+		return repo, repo.Close, nil
+
+	case "memory", "inmemory":
+		log.Printf("Using in-memory repository; nothing is persisted across restarts")
+		return repository.NewInMemoryRepository(), func() error { return nil }, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown STORAGE_BACKEND %q, expected consul, file or memory", backend)
+	}
+}
+
+// legacyRoutesDeprecatedAt is when the unversioned API paths were deprecated in favour of /v1.
+var legacyRoutesDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
 func setupRouter(app *application) *mux.Router {
 	router := mux.NewRouter()
+	router.NotFoundHandler = problem.NotFoundHandler()
+	router.MethodNotAllowedHandler = problem.MethodNotAllowedHandler()
 
 	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
 	router.HandleFunc("/health", app.handleHealthCheck).Methods("GET")
 
-	configHandler := handlers.NewConfigHandler(app.Services)
 	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(app.Services)
 
 	apiRouter := router.PathPrefix("/").Subrouter()
 	apiRouter.Use(middleware.HTTPMetricsMiddleware)
 	apiRouter.Use(middleware.TracingMiddleware)
+	apiRouter.Use(middleware.ActorMiddleware)
 	apiRouter.Use(idempotencyMiddleware.Middleware)
 
 	// Swagger rute
@@ -199,36 +299,24 @@ func setupRouter(app *application) *mux.Router {
 	readLimiter := middleware.NewRateLimiter(middleware.ReadRateLimit.Limit, middleware.ReadRateLimit.Window)
 	writeLimiter := middleware.NewRateLimiter(middleware.WriteRateLimit.Limit, middleware.WriteRateLimit.Window)
 
-	// Configuration routes
-	configRouter := apiRouter.PathPrefix("/configurations").Subrouter()
-
-	// POST /configurations
-	configRouter.Handle("", writeLimiter.Middleware(http.HandlerFunc(configHandler.HandleAddConfiguration))).Methods("POST")
-	// PUT /configurations
-	configRouter.Handle("", writeLimiter.Middleware(http.HandlerFunc(configHandler.HandleUpdateConfiguration))).Methods("PUT")
-
-	// GET /configurations/{name}/{version}
-	configRouter.Handle("/{name}/{version}", readLimiter.Middleware(http.HandlerFunc(configHandler.HandleGetConfiguration))).Methods("GET")
-	// DELETE /configurations/{name}/{version}
-	configRouter.Handle("/{name}/{version}", writeLimiter.Middleware(http.HandlerFunc(configHandler.HandleDeleteConfiguration))).Methods("DELETE")
-
-	// Config group routes
-	groupRouter := apiRouter.PathPrefix("/configgroups").Subrouter()
+	api := &handlers.API{
+		Config:     handlers.NewConfigHandler(app.Services),
+		Events:     handlers.NewEventsHandler(app.Events),
+		Webhooks:   handlers.NewWebhookHandler(app.Webhooks),
+		ReadLimit:  readLimiter.Middleware,
+		WriteLimit: writeLimiter.Middleware,
+	}
 
-	// POST /configgroups
-	groupRouter.Handle("", writeLimiter.Middleware(http.HandlerFunc(configHandler.HandleAddConfigurationGroup))).Methods("POST")
-	// PUT /configgroups
-	groupRouter.Handle("", writeLimiter.Middleware(http.HandlerFunc(configHandler.HandleUpdateConfigurationGroup))).Methods("PUT")
+	// Administration routes move the whole store, so they are not versioned with the API
+	api.RegisterAdmin(apiRouter)
 
-	// GET /configgroups/{name}/{version}
-	groupRouter.Handle("/{name}/{version}", readLimiter.Middleware(http.HandlerFunc(configHandler.HandleGetConfigurationGroup))).Methods("GET")
-	// DELETE /configgroups/{name}/{version}
-	groupRouter.Handle("/{name}/{version}", writeLimiter.Middleware(http.HandlerFunc(configHandler.HandleDeleteConfigurationGroup))).Methods("DELETE")
+	// Versioned API
+	api.RegisterV1(apiRouter.PathPrefix("/v1").Subrouter())
 
-	// GET /configgroups/{name}/{version}/configurations
-	groupRouter.Handle("/{name}/{version}/configurations", readLimiter.Middleware(http.HandlerFunc(configHandler.HandleGetGroupConfigsByLabels))).Methods("GET")
-	// DELETE /configgroups/{name}/{version}/configurations
-	groupRouter.Handle("/{name}/{version}/configurations", writeLimiter.Middleware(http.HandlerFunc(configHandler.HandleDeleteGroupConfigsByLabels))).Methods("DELETE")
+	// Unversioned paths of the API before /v1, kept as deprecated aliases
+	legacyRouter := apiRouter.NewRoute().Subrouter()
+	legacyRouter.Use(middleware.DeprecatedAlias("/v1", legacyRoutesDeprecatedAt, nil))
+	api.RegisterV1(legacyRouter)
 
 	return router
 }
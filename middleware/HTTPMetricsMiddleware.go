@@ -29,6 +29,12 @@ func (r *StatusRecorder) Write(b []byte) (int, error) {
 	return r.ResponseWriter.Write(b)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to extend the
+// write deadline of long-polling requests.
+func (r *StatusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // --- Definicija HTTP metrika ---
 
 var (
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"alati_projekat/actor"
+	"net/http"
+	"strings"
+)
+
+// ActorHeader names the caller when no authenticated identity is available.
+const ActorHeader = "X-Actor"
+
+// ActorMiddleware stores the caller identity in the request context so the service can
+// record it in audit metadata. An identity already placed in the context by an
+// authentication layer takes precedence; otherwise the X-Actor header is used.
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := actor.Lookup(r.Context()); !ok {
+			if name := strings.TrimSpace(r.Header.Get(ActorHeader)); name != "" {
+				r = r.WithContext(actor.NewContext(r.Context(), name))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var deprecatedRequestsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "http",
+		Name:      "deprecated_requests_total",
+		Help:      "Total number of requests to deprecated route aliases, labelled by method and route template.",
+	},
+	[]string{"method", "route"},
+)
+
+func init() {
+	prometheus.MustRegister(deprecatedRequestsTotal)
+}
+
+// DeprecatedAlias marks the routes of a router as deprecated aliases of the same routes under
+// successorPrefix. Responses carry the Deprecation (RFC 9745) header with deprecatedAt, a
+// Link to the successor version and, when sunset is set, the Sunset (RFC 8594) header.
+// Every request is counted, so the remaining clients of the aliases can be found.
+func DeprecatedAlias(successorPrefix string, deprecatedAt time.Time, sunset *time.Time) mux.MiddlewareFunc {
+	deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := r.URL.Path
+			if current := mux.CurrentRoute(r); current != nil {
+				if template, err := current.GetPathTemplate(); err == nil {
+					route = template
+				}
+			}
+			deprecatedRequestsTotal.With(prometheus.Labels{"method": r.Method, "route": route}).Inc()
+
+			w.Header().Set("Deprecation", deprecation)
+			w.Header().Add("Link", "<"+successorPrefix+r.URL.EscapedPath()+`>; rel="successor-version"`)
+			if sunset != nil {
+				w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"alati_projekat/problem"
 	"alati_projekat/services"
 	"log"
 	"net/http"
@@ -38,20 +39,20 @@ func (im *IdempotencyMiddleware) Middleware(next http.Handler) http.Handler {
 		isProcessed, err := im.Service.CheckIdempotencyKey(r.Context(), idempotencyKey)
 		if err != nil {
 			log.Printf("IDEMPOTENCY ERROR: Consul check failed: %v", err)
-			w.WriteHeader(http.StatusInternalServerError)
+			problem.Write(w, r, http.StatusInternalServerError, "The idempotency key could not be checked.")
 			return
 		}
 
 		if isProcessed {
 			log.Printf("IDEMPOTENCY HIT: Request with key %s already processed.", idempotencyKey)
 
-			status := http.StatusConflict
 			if r.Method == http.MethodPut {
-				status = http.StatusOK
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("Request already processed (Idempotent). Original response body is not stored/returned."))
+				return
 			}
 
-			w.WriteHeader(status)
-			w.Write([]byte("Request already processed (Idempotent). Original response body is not stored/returned."))
+			problem.Write(w, r, http.StatusConflict, "Request with key "+idempotencyKey+" was already processed. Original response body is not stored/returned.")
 			return
 		}
 
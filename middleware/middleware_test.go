@@ -1,10 +1,15 @@
 package middleware
 
 import (
+	"alati_projekat/actor"
+	"alati_projekat/problem"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestRateLimiter_WithinLimit(t *testing.T) {
@@ -58,6 +63,9 @@ func TestRateLimiter_ExceedsLimit(t *testing.T) {
 	if rr.Header().Get("X-RateLimit-Remaining") != "0" {
 		t.Errorf("Expected X-RateLimit-Remaining to be 0, got %s", rr.Header().Get("X-RateLimit-Remaining"))
 	}
+	if ct := rr.Header().Get("Content-Type"); ct != problem.ContentType {
+		t.Errorf("Expected Content-Type %s, got %s", problem.ContentType, ct)
+	}
 }
 
 func TestRateLimiter_DifferentIPs(t *testing.T) {
@@ -86,3 +94,57 @@ func TestRateLimiter_DifferentIPs(t *testing.T) {
 		t.Errorf("Different IP request should succeed, got %d", rr2.Code)
 	}
 }
+
+func TestActorMiddleware(t *testing.T) {
+	var got string
+	handler := ActorMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = actor.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest("POST", "/configurations", nil)
+	req.Header.Set(ActorHeader, "alice")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if got != "alice" {
+		t.Errorf("Expected actor from header, got %q", got)
+	}
+
+	req = httptest.NewRequest("POST", "/configurations", nil)
+	req.Header.Set(ActorHeader, "mallory")
+	req = req.WithContext(actor.NewContext(req.Context(), "authenticated"))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if got != "authenticated" {
+		t.Errorf("Authenticated caller must take precedence over the header, got %q", got)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/configurations", nil))
+	if got != actor.Anonymous {
+		t.Errorf("Expected %q without caller identity, got %q", actor.Anonymous, got)
+	}
+}
+
+func TestDeprecatedAlias(t *testing.T) {
+	deprecatedAt := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	sunset := deprecatedAt.AddDate(0, 6, 0)
+	router := mux.NewRouter()
+	router.Use(DeprecatedAlias("/v1", deprecatedAt, &sunset))
+	router.HandleFunc("/configurations/{name}/{version}", func(w http.ResponseWriter, r *http.Request) {})
+
+	route := "/configurations/{name}/{version}"
+	before := testutil.ToFloat64(deprecatedRequestsTotal.WithLabelValues("GET", route))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/configurations/service-api/v1", nil))
+
+	if got := rr.Header().Get("Deprecation"); got != "@1792281600" {
+		t.Errorf("Expected Deprecation @1792281600, got %q", got)
+	}
+	if got := rr.Header().Get("Link"); got != `</v1/configurations/service-api/v1>; rel="successor-version"` {
+		t.Errorf("Unexpected Link %q", got)
+	}
+	if got := rr.Header().Get("Sunset"); got != sunset.Format(http.TimeFormat) {
+		t.Errorf("Expected Sunset %q, got %q", sunset.Format(http.TimeFormat), got)
+	}
+	if after := testutil.ToFloat64(deprecatedRequestsTotal.WithLabelValues("GET", route)); after != before+1 {
+		t.Errorf("Expected the counter of %s to grow by one, got %v -> %v", route, before, after)
+	}
+}
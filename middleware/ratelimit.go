@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"alati_projekat/problem"
 	"log"
 	"net/http"
 	"strconv"
@@ -58,7 +59,7 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 			}
 			w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
 
-			http.Error(w, "Rate limit exceeded. Too many requests.", http.StatusTooManyRequests)
+			problem.Write(w, r, http.StatusTooManyRequests, "Rate limit exceeded. Too many requests.")
 			return
 		}
 
@@ -0,0 +1,103 @@
+package model
+
+// BatchMode selects how a batch is executed.
+type BatchMode string
+
+const (
+	// BatchAtomic applies every operation or none of them.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort applies operations one by one and reports the outcome of each.
+	BatchBestEffort BatchMode = "best-effort"
+)
+
+// BatchAction is the kind of change made by a batch operation.
+type BatchAction string
+
+const (
+	BatchCreate BatchAction = "create"
+	BatchUpdate BatchAction = "update"
+	BatchDelete BatchAction = "delete"
+)
+
+// BatchKind is the kind of entity a batch operation targets.
+type BatchKind string
+
+const (
+	BatchConfiguration BatchKind = "configuration"
+	BatchGroup         BatchKind = "group"
+)
+
+// BatchOperation is a single create, update or delete inside a batch.
+//
+// @Description Single operation of a batch request.
+type BatchOperation struct {
+	// @Description Operation (create, update, delete)
+	// @example create
+	Op BatchAction `json:"op"`
+	// @Description Target entity (configuration, group)
+	// @example configuration
+	Kind BatchKind `json:"kind"`
+	// @Description Configuration body for create and update of configurations
+	Configuration *CreateConfigurationRequest `json:"configuration,omitempty"`
+	// @Description Group body for create and update of groups
+	Group *CreateGroupRequest `json:"group,omitempty"`
+	// @Description Name of the entity to delete
+	// @example service-api
+	Name string `json:"name,omitempty"`
+	// @Description Version of the entity to delete
+	// @example v1
+	Version string `json:"version,omitempty"`
+}
+
+// BatchRequest is the body of POST /batch.
+//
+// @Description Request model for executing several operations at once.
+type BatchRequest struct {
+	// @Description Execution mode (atomic, best-effort); defaults to atomic
+	// @example atomic
+	Mode BatchMode `json:"mode,omitempty"`
+	// @Description Operations, executed in order
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchResult is the outcome of a single batch operation.
+//
+// @Description Outcome of a single batch operation.
+type BatchResult struct {
+	// @Description Position of the operation in the request
+	Index int `json:"index"`
+	// @Description Operation
+	Op BatchAction `json:"op"`
+	// @Description Target entity
+	Kind BatchKind `json:"kind"`
+	// @Description Name of the entity
+	Name string `json:"name"`
+	// @Description Version of the entity
+	Version string `json:"version"`
+	// @Description HTTP status the operation would have had as a separate request
+	// @example 201
+	Status int `json:"status"`
+	// @Description Why the operation failed
+	Error string `json:"error,omitempty"`
+	// @Description Stored configuration after create or update, deleted configuration after delete
+	Configuration *Configuration `json:"configuration,omitempty"`
+	// @Description Stored group after create or update, deleted group after delete
+	Group *ConfigurationGroup `json:"group,omitempty"`
+
+	// Err is the error of a failed operation; the transport layer turns it into Status and Error.
+	Err error `json:"-"`
+}
+
+// BatchResponse reports the outcome of a batch.
+//
+// @Description Result of a batch request.
+type BatchResponse struct {
+	// @Description Execution mode that was used
+	Mode BatchMode `json:"mode"`
+	// @Description Number of operations that succeeded
+	Succeeded int `json:"succeeded"`
+	// @Description Number of operations that failed
+	Failed int `json:"failed"`
+	// @Description Outcome of every operation, in request order
+	Results []BatchResult `json:"results"`
+}
@@ -0,0 +1,26 @@
+package model
+
+// CloneRequest is the body of the clone endpoints. Overrides are applied to the copied
+// configuration, or to every member when a group is cloned.
+//
+// @Description Request model for cloning a configuration or a configuration group.
+type CloneRequest struct {
+	// @Description Name of the copy; defaults to the name of the source
+	// @example service-api
+	Name string `json:"name,omitempty"`
+	// @Description Version of the copy
+	// @example v2
+	Version string `json:"version"`
+	// @Description Params to add or replace, by key (optional)
+	Params []Parameter `json:"params,omitempty"`
+	// @Description Keys of params to drop from the copy (optional)
+	// @example ["debug"]
+	UnsetParams []string `json:"unsetParams,omitempty"`
+	// @Description Labels to add or replace, by key (optional)
+	// @example [{"key": "env", "value": "prod"}]
+	Labels []Parameter `json:"labels,omitempty"`
+	// @Description Keys of labels to drop from the copy (optional)
+	UnsetLabels []string `json:"unsetLabels,omitempty"`
+	// @Description Description of the copy; defaults to the description of the source (optional)
+	Description string `json:"description,omitempty"`
+}
@@ -1,6 +1,64 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LifecycleState is the publication state of a configuration or a configuration group.
+type LifecycleState string
+
+const (
+	// StateDraft is the only state in which an entity may be modified.
+	StateDraft LifecycleState = "draft"
+	// StatePublished entities are immutable and safe to consume.
+	StatePublished LifecycleState = "published"
+	// StateDeprecated entities are still served, with Deprecation/Sunset headers.
+	StateDeprecated LifecycleState = "deprecated"
+	// StateArchived entities are kept for reference only.
+	StateArchived LifecycleState = "archived"
+)
+
+// Lifecycle holds the lifecycle state shared by configurations and groups.
+// Entities stored before lifecycle support have no state and are treated as drafts.
+type Lifecycle struct {
+	// @Description Lifecycle state (draft, published, deprecated, archived)
+	// @example draft
+	State LifecycleState `json:"state,omitempty"`
+	// @Description Time the entity was deprecated (optional)
+	DeprecatedAt *time.Time `json:"deprecatedAt,omitempty"`
+	// @Description Time after which the deprecated entity may be removed (optional)
+	SunsetAt *time.Time `json:"sunsetAt,omitempty"`
+}
+
+// CurrentState returns the lifecycle state, defaulting to draft for entities stored without one.
+func (l Lifecycle) CurrentState() LifecycleState {
+	if l.State == "" {
+		return StateDraft
+	}
+	return l.State
+}
+
+// Metadata holds audit information maintained by the service on every write.
+// Entities stored before audit support decode with zero values.
+type Metadata struct {
+	// @Description Time the entity was created
+	CreatedAt time.Time `json:"createdAt,omitzero"`
+	// @Description Caller that created the entity
+	// @example alice
+	CreatedBy string `json:"createdBy,omitempty"`
+	// @Description Time the entity was last modified
+	UpdatedAt time.Time `json:"updatedAt,omitzero"`
+	// @Description Caller that last modified the entity
+	// @example bob
+	UpdatedBy string `json:"updatedBy,omitempty"`
+	// @Description Revision number, incremented on every write
+	// @example 3
+	Revision int64 `json:"revision,omitempty"`
+	// @Description Entity this one was cloned from (optional)
+	ClonedFrom *ConfigurationRef `json:"clonedFrom,omitempty"`
+}
 
 // Parameter represents a key-value pair within a configuration or a label.
 //
@@ -31,6 +89,32 @@ type Configuration struct {
 	// @Description List of config labels (optional)
 	// @example [{"key": "env", "value": "dev"}, {"key": "region", "value": "eu"}]
 	Labels []Parameter `json:"labels,omitempty"`
+
+	// @Description Parent configuration whose params are inherited (optional)
+	Parent *ConfigurationRef `json:"parent,omitempty"`
+	// @Description Keys of inherited params removed from the effective configuration (optional)
+	// @example ["debug"]
+	RemoveParams []string `json:"removeParams,omitempty"`
+
+	// @Description Free-form description (optional)
+	// @example Public API settings
+	Description string `json:"description,omitempty"`
+
+	Lifecycle
+	// @Description Audit metadata, maintained by the service
+	Metadata Metadata `json:"metadata,omitzero"`
+}
+
+// ConfigurationRef identifies a configuration by name and version.
+//
+// @Description Reference to a configuration by name and version.
+type ConfigurationRef struct {
+	// @Description Name of the referenced configuration
+	// @example service-api-base
+	Name string `json:"name"`
+	// @Description Version of the referenced configuration
+	// @example v1
+	Version string `json:"version"`
 }
 
 // ConfigurationGroup represents a collection of configurations.
@@ -48,6 +132,14 @@ type ConfigurationGroup struct {
 	Version string `json:"version"`
 	// @Description List of configurations in this group
 	Configurations []Configuration `json:"configurations"`
+
+	// @Description Free-form description (optional)
+	// @example Production deployment
+	Description string `json:"description,omitempty"`
+
+	Lifecycle
+	// @Description Audit metadata, maintained by the service
+	Metadata Metadata `json:"metadata,omitzero"`
 }
 
 // CreateConfigurationRequest represents the request body for creating a configuration.
@@ -65,6 +157,44 @@ type CreateConfigurationRequest struct {
 
 	// @Description Labels (k:v pairs)
 	Labels []Parameter `json:"labels"`
+
+	// @Description Parent configuration whose params are inherited (optional)
+	Parent *ConfigurationRef `json:"parent,omitempty"`
+	// @Description Keys of inherited params removed from the effective configuration (optional)
+	RemoveParams []string `json:"removeParams,omitempty"`
+
+	// @Description Free-form description (optional)
+	Description string `json:"description,omitempty"`
+}
+
+// EffectiveParameter is a parameter of the merged configuration together with the layer it came from.
+//
+// @Description Merged parameter with the configuration layer that provided its final value.
+type EffectiveParameter struct {
+	// @Description Parameter key
+	Key string `json:"key"`
+	// @Description Parameter value
+	Value string `json:"value"`
+	// @Description Configuration layer the final value came from
+	Source ConfigurationRef `json:"source"`
+}
+
+// EffectiveConfiguration is the result of merging a configuration with all of its ancestors.
+//
+// @Description Configuration merged with its parent chain.
+type EffectiveConfiguration struct {
+	// @Description Name of the configuration
+	// @example service-api
+	Name string `json:"name"`
+	// @Description Version of the configuration
+	// @example v1
+	Version string `json:"version"`
+	// @Description Inheritance chain, from the root ancestor to the requested configuration
+	Layers []ConfigurationRef `json:"layers"`
+	// @Description Merged parameters
+	Params []EffectiveParameter `json:"params"`
+	// @Description Labels of the requested configuration
+	Labels []Parameter `json:"labels,omitempty"`
 }
 
 // CreateGroupRequest represents the request body for creating a configuration group.
@@ -79,6 +209,18 @@ type CreateGroupRequest struct {
 	Version string `json:"version" example:"v2"`
 	// @Description List of configurations to include in the group
 	Configurations []Configuration `json:"configurations"`
+
+	// @Description Free-form description (optional)
+	Description string `json:"description,omitempty"`
+}
+
+// TransitionRequest is the optional body of a lifecycle transition.
+//
+// @Description Request model for lifecycle transitions.
+type TransitionRequest struct {
+	// @Description Sunset time announced when deprecating (optional)
+	// @example 2026-12-31T00:00:00Z
+	Sunset *time.Time `json:"sunset,omitempty"`
 }
 
 func (c Configuration) LabelsMap() map[string]string {
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// EventType is the kind of change an event reports.
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+	EventDeleted EventType = "deleted"
+)
+
+// EventKind is the kind of entity an event is about.
+type EventKind string
+
+const (
+	EventConfiguration EventKind = "configuration"
+	EventGroup         EventKind = "group"
+)
+
+// Event reports a successful change of a configuration or a configuration group.
+//
+// @Description Change of a configuration or a configuration group.
+type Event struct {
+	// @Description Position of the event in the event log; send it back as Last-Event-ID to resume
+	// @example 42
+	ID uint64 `json:"id"`
+	// @Description Change (created, updated, deleted)
+	// @example updated
+	Type EventType `json:"type"`
+	// @Description Changed entity (configuration, group)
+	// @example configuration
+	Kind EventKind `json:"kind"`
+	// @Description Name of the entity
+	// @example service-api
+	Name string `json:"name"`
+	// @Description Version of the entity
+	// @example v1
+	Version string `json:"version"`
+	// @Description Revision after the change; the last stored revision for deletes, when known
+	// @example 3
+	Revision int64 `json:"revision,omitempty"`
+	// @Description Labels of a configuration
+	Labels []Parameter `json:"labels,omitempty"`
+	// @Description Caller that made the change
+	// @example alice
+	Actor string `json:"actor,omitempty"`
+	// @Description Time the change was recorded
+	Time time.Time `json:"time"`
+}
@@ -0,0 +1,40 @@
+package model
+
+// ConflictingValue is one of the values members of a group give to the same key.
+//
+// @Description Value of a conflicting key and the member that provides it.
+type ConflictingValue struct {
+	// @Description Parameter value
+	Value string `json:"value"`
+	// @Description Group member that provides the value
+	Source ConfigurationRef `json:"source"`
+}
+
+// ParameterConflict reports a key that selected members set to different values.
+//
+// @Description Key set to different values by several group members.
+type ParameterConflict struct {
+	// @Description Parameter key
+	// @example port
+	Key string `json:"key"`
+	// @Description Competing values in precedence order; the last one wins
+	Values []ConflictingValue `json:"values"`
+}
+
+// RenderedConfiguration is the flat configuration of a service instance: the params of the
+// group members matching a label selector, merged in precedence order.
+//
+// @Description Flat configuration merged from the group members that match a label selector.
+type RenderedConfiguration struct {
+	// @Description Group the configuration was rendered from
+	Group ConfigurationRef `json:"group"`
+	// @Description Label selector the members were matched against
+	// @example {"env": "prod"}
+	Selector map[string]string `json:"selector,omitempty"`
+	// @Description Matching members in precedence order, lowest first
+	Members []ConfigurationRef `json:"members"`
+	// @Description Merged parameters with the configuration layer that provided the final value
+	Params []EffectiveParameter `json:"params"`
+	// @Description Keys set to different values by several members (optional)
+	Conflicts []ParameterConflict `json:"conflicts,omitempty"`
+}
@@ -0,0 +1,169 @@
+package model
+
+import "time"
+
+// SnapshotFormat identifies snapshot documents produced by this service.
+const SnapshotFormat = "alati-config-snapshot"
+
+// SnapshotVersion is the snapshot format version written by export. Import accepts
+// every version up to and including it.
+const SnapshotVersion = 1
+
+// SnapshotCounts is the number of records of each kind in a snapshot. Import compares it
+// with the records it read, so a truncated snapshot is rejected instead of half applied.
+//
+// @Description Number of records of each kind in a snapshot.
+type SnapshotCounts struct {
+	// @Description Number of configurations
+	// @example 12
+	Configurations int `json:"configurations"`
+	// @Description Number of configuration groups
+	// @example 3
+	Groups int `json:"groups"`
+	// @Description Number of idempotency keys
+	// @example 0
+	IdempotencyKeys int `json:"idempotencyKeys"`
+}
+
+// SnapshotHeader is the first record of every snapshot.
+//
+// @Description Header of a store snapshot.
+type SnapshotHeader struct {
+	// @Description Document format, always alati-config-snapshot
+	// @example alati-config-snapshot
+	Format string `json:"format"`
+	// @Description Snapshot format version
+	// @example 1
+	Version int `json:"version"`
+	// @Description Time the snapshot was taken
+	ExportedAt time.Time `json:"exportedAt"`
+	// @Description Whether entity revisions were exported
+	Revisions bool `json:"revisions"`
+	// @Description Whether idempotency keys were exported
+	IdempotencyKeys bool `json:"idempotencyKeys"`
+	// @Description Number of records that follow the header
+	Counts SnapshotCounts `json:"counts"`
+}
+
+// Snapshot is the content of the store at one point in time.
+type Snapshot struct {
+	Header          SnapshotHeader
+	Configurations  []Configuration
+	Groups          []ConfigurationGroup
+	IdempotencyKeys []string
+}
+
+// ExportOptions selects the optional parts of an export.
+type ExportOptions struct {
+	// Revisions keeps the revision of every entity; otherwise revisions are cleared and
+	// imported entities start over at revision 1.
+	Revisions bool
+	// IdempotencyKeys adds the processed idempotency keys, so retried requests are still
+	// recognised after moving to another backend.
+	IdempotencyKeys bool
+}
+
+// ImportMode selects what an import does with entities that already exist.
+type ImportMode string
+
+const (
+	// ImportSkip keeps existing entities and only creates missing ones.
+	ImportSkip ImportMode = "skip"
+	// ImportOverwrite replaces existing entities with the ones from the snapshot. Only drafts
+	// are replaced; other entities conflict unless the snapshot has their stored revision.
+	ImportOverwrite ImportMode = "overwrite"
+	// ImportFailOnConflict rejects the whole import when any entity already exists.
+	ImportFailOnConflict ImportMode = "fail-on-conflict"
+)
+
+// ImportOptions controls an import.
+type ImportOptions struct {
+	Mode ImportMode
+	// DryRun reports what the import would do without writing anything.
+	DryRun bool
+}
+
+// ImportAction is what an import did, or would do, with a single record.
+type ImportAction string
+
+const (
+	ImportCreated     ImportAction = "created"
+	ImportOverwritten ImportAction = "overwritten"
+	ImportSkipped     ImportAction = "skipped"
+	ImportConflict    ImportAction = "conflict"
+)
+
+// ImportCounts counts the actions taken for one kind of record.
+//
+// @Description Number of records per import action.
+type ImportCounts struct {
+	// @Description Records that did not exist and were created
+	Created int `json:"created"`
+	// @Description Existing records replaced by the snapshot
+	Overwritten int `json:"overwritten"`
+	// @Description Existing records that were kept
+	Skipped int `json:"skipped"`
+	// @Description Existing records that made the import fail: any record in fail-on-conflict mode, non-draft records in overwrite mode
+	Conflicts int `json:"conflicts"`
+}
+
+// Add counts one record with action a.
+func (c *ImportCounts) Add(a ImportAction) {
+	switch a {
+	case ImportCreated:
+		c.Created++
+	case ImportOverwritten:
+		c.Overwritten++
+	case ImportSkipped:
+		c.Skipped++
+	case ImportConflict:
+		c.Conflicts++
+	}
+}
+
+// ImportItem is the outcome of importing a single configuration or group.
+//
+// @Description Outcome of importing a single configuration or group.
+type ImportItem struct {
+	// @Description Entity (configuration, group)
+	// @example configuration
+	Kind EventKind `json:"kind"`
+	// @Description Name of the entity
+	// @example service-api
+	Name string `json:"name"`
+	// @Description Version of the entity
+	// @example v1
+	Version string `json:"version"`
+	// @Description Action (created, overwritten, skipped, conflict)
+	// @example created
+	Action ImportAction `json:"action"`
+	// @Description Revision the entity has, or would have, after the import
+	// @example 1
+	Revision int64 `json:"revision,omitempty"`
+	// @Description Why the record conflicts with the stored one
+	// @example already exists
+	Reason string `json:"reason,omitempty"`
+
+	// Configuration and Group hold the entity as written, for created and overwritten items.
+	Configuration *Configuration      `json:"-"`
+	Group         *ConfigurationGroup `json:"-"`
+}
+
+// ImportSummary reports the outcome of an import.
+//
+// @Description Result of a snapshot import.
+type ImportSummary struct {
+	// @Description Import mode that was used
+	// @example skip
+	Mode ImportMode `json:"mode"`
+	// @Description Whether this was a dry run that wrote nothing
+	DryRun bool `json:"dryRun"`
+	// @Description Actions taken for configurations
+	Configurations ImportCounts `json:"configurations"`
+	// @Description Actions taken for configuration groups
+	Groups ImportCounts `json:"groups"`
+	// @Description Actions taken for idempotency keys
+	IdempotencyKeys ImportCounts `json:"idempotencyKeys"`
+	// @Description Outcome for every configuration and group, in snapshot order
+	Items []ImportItem `json:"items"`
+}
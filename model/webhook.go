@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is a subscription that has change events POSTed to URL.
+//
+// @Description Webhook subscription for change events.
+type Webhook struct {
+	// @Description Identifier of the webhook
+	ID uuid.UUID `json:"id"`
+	// @Description Absolute http(s) URL the events are POSTed to
+	// @example https://deploy.example.com/hooks/config
+	URL string `json:"url"`
+	// @Description Event types to deliver (created, updated, deleted); all when empty
+	Events []EventType `json:"events,omitempty"`
+	// @Description Label selector (k:v;k2:v2) for configuration events; group events are only delivered without a selector
+	// @example app:service-api
+	Labels string `json:"labels,omitempty"`
+	// @Description Secret used to sign deliveries; only returned when the webhook is created
+	Secret string `json:"secret,omitempty"`
+	// @Description Time the webhook was created
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CreateWebhookRequest is the body of POST /webhooks.
+//
+// @Description Request model for creating a webhook.
+type CreateWebhookRequest struct {
+	// @Description Absolute http(s) URL the events are POSTed to
+	// @example https://deploy.example.com/hooks/config
+	URL string `json:"url"`
+	// @Description Event types to deliver (created, updated, deleted); all when empty
+	Events []EventType `json:"events,omitempty"`
+	// @Description Label selector (k:v;k2:v2) for configuration events
+	// @example app:service-api
+	Labels string `json:"labels,omitempty"`
+	// @Description Secret for the HMAC-SHA256 signature in the X-Webhook-Signature header
+	// @example s3cr3t-value
+	Secret string `json:"secret"`
+}
+
+// WebhookDelivery is a single attempt to deliver an event to a webhook.
+//
+// @Description Single delivery attempt of an event to a webhook.
+type WebhookDelivery struct {
+	// @Description Identifier shared by all attempts of one delivery, sent as X-Webhook-Delivery
+	DeliveryID uuid.UUID `json:"deliveryId"`
+	// @Description Webhook the event was delivered to
+	WebhookID uuid.UUID `json:"webhookId"`
+	// @Description Id of the delivered event
+	// @example 42
+	EventID uint64 `json:"eventId"`
+	// @Description Type of the delivered event
+	// @example updated
+	EventType EventType `json:"eventType"`
+	// @Description Attempt number, starting at 1
+	// @example 1
+	Attempt int `json:"attempt"`
+	// @Description HTTP status returned by the receiver; 0 when no response was received
+	// @example 200
+	StatusCode int `json:"statusCode,omitempty"`
+	// @Description Why the attempt failed
+	Error string `json:"error,omitempty"`
+	// @Description Whether the receiver accepted the event
+	Succeeded bool `json:"succeeded"`
+	// @Description Time the attempt was made
+	Time time.Time `json:"time"`
+	// @Description Duration of the attempt in milliseconds
+	DurationMs int64 `json:"durationMs"`
+}
+
+// DeadLetter is an event that could not be delivered to a webhook after every retry.
+//
+// @Description Event that could not be delivered to a webhook.
+type DeadLetter struct {
+	// @Description Identifier of the failed delivery
+	DeliveryID uuid.UUID `json:"deliveryId"`
+	// @Description Webhook the event was meant for
+	WebhookID uuid.UUID `json:"webhookId"`
+	// @Description Undelivered event
+	Event Event `json:"event"`
+	// @Description Number of attempts made
+	// @example 5
+	Attempts int `json:"attempts"`
+	// @Description Error of the last attempt
+	LastError string `json:"lastError"`
+	// @Description Time the delivery was given up
+	Time time.Time `json:"time"`
+}
@@ -0,0 +1,112 @@
+// Package problem writes error responses as RFC 7807 (RFC 9457) problem details,
+// so that every failure of the API has the same machine-readable shape.
+package problem
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ContentType is the media type of problem detail responses.
+const ContentType = "application/problem+json"
+
+const (
+	// TypeDefault means the problem has no semantics beyond its HTTP status code.
+	TypeDefault = "about:blank"
+	// TypeValidation identifies request validation failures; Errors lists each violation.
+	TypeValidation = "/problems/validation-error"
+)
+
+// FieldError describes a single invalid field of a request.
+//
+// @Description Validation failure of a single request field.
+type FieldError struct {
+	// @Description Field path, e.g. "params[2].key"
+	Field string `json:"field"`
+	// @Description What is wrong with the field
+	Message string `json:"message"`
+}
+
+// Problem is an RFC 7807 problem details object.
+//
+// @Description RFC 7807 problem details returned for every error.
+type Problem struct {
+	// @Description URI identifying the problem type
+	// @example about:blank
+	Type string `json:"type"`
+	// @Description Short summary of the problem type
+	// @example Not Found
+	Title string `json:"title"`
+	// @Description HTTP status code
+	// @example 404
+	Status int `json:"status"`
+	// @Description Explanation specific to this occurrence
+	Detail string `json:"detail,omitempty"`
+	// @Description Request URI the problem occurred on
+	Instance string `json:"instance,omitempty"`
+	// @Description Trace ID of the request, for correlating with traces and logs
+	TraceID string `json:"traceId,omitempty"`
+	// @Description Field-level validation failures
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// Write writes a problem with the given status and detail.
+func Write(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	WriteProblem(w, r, Problem{Status: status, Detail: detail})
+}
+
+// Validation writes a 400 validation problem listing every invalid field.
+func Validation(w http.ResponseWriter, r *http.Request, detail string, errs []FieldError) {
+	WriteProblem(w, r, Problem{Type: TypeValidation, Status: http.StatusBadRequest, Detail: detail, Errors: errs})
+}
+
+// Internal logs err and writes a 500 problem that does not expose it to the client.
+func Internal(w http.ResponseWriter, r *http.Request, err error) {
+	log.Printf("Internal error on %s %s: %v", r.Method, r.URL.Path, err)
+	Write(w, r, http.StatusInternalServerError, "The server encountered an unexpected error.")
+}
+
+// WriteProblem fills in the type, title, instance and trace ID when they are not set
+// and writes p as application/problem+json.
+func WriteProblem(w http.ResponseWriter, r *http.Request, p Problem) {
+	if p.Type == "" {
+		p.Type = TypeDefault
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+	if p.Instance == "" && r != nil {
+		p.Instance = r.URL.RequestURI()
+	}
+	if p.TraceID == "" && r != nil {
+		if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+			p.TraceID = sc.TraceID().String()
+		}
+	}
+
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Del("ETag")
+	h.Del("Last-Modified")
+	h.Set("Content-Type", ContentType)
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// NotFoundHandler answers unknown routes with a 404 problem.
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, http.StatusNotFound, "No route matches "+r.URL.Path+".")
+	})
+}
+
+// MethodNotAllowedHandler answers known routes called with the wrong method with a 405 problem.
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed on "+r.URL.Path+".")
+	})
+}
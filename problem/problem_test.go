@@ -0,0 +1,87 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWrite(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID})
+
+	req := httptest.NewRequest(http.MethodGet, "/configurations/app/v1?format=yaml", nil)
+	req = req.WithContext(trace.ContextWithSpanContext(req.Context(), sc))
+	rr := httptest.NewRecorder()
+	rr.Header().Set("ETag", `"stale"`)
+
+	Write(rr, req, http.StatusNotFound, "Configuration not found.")
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("Expected 404, got %d", rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Expected Content-Type %s, got %s", ContentType, ct)
+	}
+	if rr.Header().Get("ETag") != "" {
+		t.Error("Validators of the original response must not leak into a problem response")
+	}
+
+	var p Problem
+	if err := json.NewDecoder(rr.Body).Decode(&p); err != nil {
+		t.Fatalf("Invalid problem body: %v", err)
+	}
+	want := Problem{
+		Type:     TypeDefault,
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "Configuration not found.",
+		Instance: "/configurations/app/v1?format=yaml",
+		TraceID:  traceID.String(),
+	}
+	if p.Type != want.Type || p.Title != want.Title || p.Status != want.Status ||
+		p.Detail != want.Detail || p.Instance != want.Instance || p.TraceID != want.TraceID {
+		t.Errorf("Expected %+v, got %+v", want, p)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/configurations", nil)
+	rr := httptest.NewRecorder()
+
+	Validation(rr, req, "Request is invalid.", []FieldError{
+		{Field: "name", Message: "must not be empty"},
+		{Field: "params[1].key", Message: "duplicate key"},
+	})
+
+	var p Problem
+	if err := json.NewDecoder(rr.Body).Decode(&p); err != nil {
+		t.Fatalf("Invalid problem body: %v", err)
+	}
+	if rr.Code != http.StatusBadRequest || p.Type != TypeValidation || len(p.Errors) != 2 {
+		t.Errorf("Unexpected validation problem %d %+v", rr.Code, p)
+	}
+	if p.TraceID != "" {
+		t.Errorf("Expected no trace ID without a span, got %s", p.TraceID)
+	}
+}
+
+func TestInternal_HidesError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/configurations/app/v1", nil)
+	rr := httptest.NewRecorder()
+
+	Internal(rr, req, errors.New("dial tcp 127.0.0.1:8500: connect: connection refused"))
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", rr.Code)
+	}
+	if strings.Contains(rr.Body.String(), "8500") {
+		t.Errorf("Internal error details leaked to the client: %s", rr.Body.String())
+	}
+}
@@ -0,0 +1,1384 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: configpb/config.proto
+
+package configpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Parameter is a key-value pair of a configuration, or a label.
+type Parameter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Parameter) Reset() {
+	*x = Parameter{}
+	mi := &file_configpb_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parameter) ProtoMessage() {}
+
+func (x *Parameter) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parameter.ProtoReflect.Descriptor instead.
+func (*Parameter) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Parameter) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Parameter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// ConfigurationRef identifies a configuration by name and version.
+type ConfigurationRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigurationRef) Reset() {
+	*x = ConfigurationRef{}
+	mi := &file_configpb_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigurationRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigurationRef) ProtoMessage() {}
+
+func (x *ConfigurationRef) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigurationRef.ProtoReflect.Descriptor instead.
+func (*ConfigurationRef) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConfigurationRef) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConfigurationRef) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// Metadata is the audit information maintained by the service on every write.
+type Metadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,2,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	Revision      int64                  `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	ClonedFrom    *ConfigurationRef      `protobuf:"bytes,6,opt,name=cloned_from,json=clonedFrom,proto3" json:"cloned_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	mi := &file_configpb_config_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Metadata) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Metadata) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Metadata) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Metadata) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Metadata) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *Metadata) GetClonedFrom() *ConfigurationRef {
+	if x != nil {
+		return x.ClonedFrom
+	}
+	return nil
+}
+
+type Configuration struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version      string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Params       []*Parameter           `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty"`
+	Labels       []*Parameter           `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty"`
+	Parent       *ConfigurationRef      `protobuf:"bytes,6,opt,name=parent,proto3" json:"parent,omitempty"`
+	RemoveParams []string               `protobuf:"bytes,7,rep,name=remove_params,json=removeParams,proto3" json:"remove_params,omitempty"`
+	Description  string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	// Lifecycle state: draft, published, deprecated or archived.
+	State         string                 `protobuf:"bytes,9,opt,name=state,proto3" json:"state,omitempty"`
+	DeprecatedAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deprecated_at,json=deprecatedAt,proto3" json:"deprecated_at,omitempty"`
+	SunsetAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=sunset_at,json=sunsetAt,proto3" json:"sunset_at,omitempty"`
+	Metadata      *Metadata              `protobuf:"bytes,12,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Configuration) Reset() {
+	*x = Configuration{}
+	mi := &file_configpb_config_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Configuration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Configuration) ProtoMessage() {}
+
+func (x *Configuration) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Configuration.ProtoReflect.Descriptor instead.
+func (*Configuration) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Configuration) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Configuration) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Configuration) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Configuration) GetParams() []*Parameter {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *Configuration) GetLabels() []*Parameter {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Configuration) GetParent() *ConfigurationRef {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *Configuration) GetRemoveParams() []string {
+	if x != nil {
+		return x.RemoveParams
+	}
+	return nil
+}
+
+func (x *Configuration) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Configuration) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Configuration) GetDeprecatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeprecatedAt
+	}
+	return nil
+}
+
+func (x *Configuration) GetSunsetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SunsetAt
+	}
+	return nil
+}
+
+func (x *Configuration) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ConfigurationGroup struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version        string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Configurations []*Configuration       `protobuf:"bytes,4,rep,name=configurations,proto3" json:"configurations,omitempty"`
+	Description    string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// Lifecycle state: draft, published, deprecated or archived.
+	State         string                 `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	DeprecatedAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=deprecated_at,json=deprecatedAt,proto3" json:"deprecated_at,omitempty"`
+	SunsetAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=sunset_at,json=sunsetAt,proto3" json:"sunset_at,omitempty"`
+	Metadata      *Metadata              `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigurationGroup) Reset() {
+	*x = ConfigurationGroup{}
+	mi := &file_configpb_config_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigurationGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigurationGroup) ProtoMessage() {}
+
+func (x *ConfigurationGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigurationGroup.ProtoReflect.Descriptor instead.
+func (*ConfigurationGroup) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConfigurationGroup) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConfigurationGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConfigurationGroup) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ConfigurationGroup) GetConfigurations() []*Configuration {
+	if x != nil {
+		return x.Configurations
+	}
+	return nil
+}
+
+func (x *ConfigurationGroup) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ConfigurationGroup) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ConfigurationGroup) GetDeprecatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeprecatedAt
+	}
+	return nil
+}
+
+func (x *ConfigurationGroup) GetSunsetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SunsetAt
+	}
+	return nil
+}
+
+func (x *ConfigurationGroup) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// CreateConfigurationRequest is the body of AddConfiguration and UpdateConfiguration.
+type CreateConfigurationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Params        []*Parameter           `protobuf:"bytes,3,rep,name=params,proto3" json:"params,omitempty"`
+	Labels        []*Parameter           `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
+	Parent        *ConfigurationRef      `protobuf:"bytes,5,opt,name=parent,proto3" json:"parent,omitempty"`
+	RemoveParams  []string               `protobuf:"bytes,6,rep,name=remove_params,json=removeParams,proto3" json:"remove_params,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConfigurationRequest) Reset() {
+	*x = CreateConfigurationRequest{}
+	mi := &file_configpb_config_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConfigurationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConfigurationRequest) ProtoMessage() {}
+
+func (x *CreateConfigurationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConfigurationRequest.ProtoReflect.Descriptor instead.
+func (*CreateConfigurationRequest) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateConfigurationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateConfigurationRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CreateConfigurationRequest) GetParams() []*Parameter {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *CreateConfigurationRequest) GetLabels() []*Parameter {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateConfigurationRequest) GetParent() *ConfigurationRef {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *CreateConfigurationRequest) GetRemoveParams() []string {
+	if x != nil {
+		return x.RemoveParams
+	}
+	return nil
+}
+
+func (x *CreateConfigurationRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetConfigurationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigurationRequest) Reset() {
+	*x = GetConfigurationRequest{}
+	mi := &file_configpb_config_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigurationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigurationRequest) ProtoMessage() {}
+
+func (x *GetConfigurationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigurationRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigurationRequest) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetConfigurationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetConfigurationRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type DeleteConfigurationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConfigurationRequest) Reset() {
+	*x = DeleteConfigurationRequest{}
+	mi := &file_configpb_config_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConfigurationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConfigurationRequest) ProtoMessage() {}
+
+func (x *DeleteConfigurationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConfigurationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteConfigurationRequest) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteConfigurationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteConfigurationRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// CreateGroupRequest is the body of AddConfigurationGroup and UpdateConfigurationGroup.
+type CreateGroupRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version        string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Configurations []*Configuration       `protobuf:"bytes,3,rep,name=configurations,proto3" json:"configurations,omitempty"`
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateGroupRequest) Reset() {
+	*x = CreateGroupRequest{}
+	mi := &file_configpb_config_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupRequest) ProtoMessage() {}
+
+func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateGroupRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CreateGroupRequest) GetConfigurations() []*Configuration {
+	if x != nil {
+		return x.Configurations
+	}
+	return nil
+}
+
+func (x *CreateGroupRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetConfigurationGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigurationGroupRequest) Reset() {
+	*x = GetConfigurationGroupRequest{}
+	mi := &file_configpb_config_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigurationGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigurationGroupRequest) ProtoMessage() {}
+
+func (x *GetConfigurationGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigurationGroupRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigurationGroupRequest) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetConfigurationGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetConfigurationGroupRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type DeleteConfigurationGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConfigurationGroupRequest) Reset() {
+	*x = DeleteConfigurationGroupRequest{}
+	mi := &file_configpb_config_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConfigurationGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConfigurationGroupRequest) ProtoMessage() {}
+
+func (x *DeleteConfigurationGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConfigurationGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteConfigurationGroupRequest) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteConfigurationGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteConfigurationGroupRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Exact version; version selectors cannot be watched.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Index of the state the client already has; 0 sends the current state first.
+	Index         uint64 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_configpb_config_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type WatchConfigurationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset when deleted is true.
+	Configuration *Configuration `protobuf:"bytes,1,opt,name=configuration,proto3" json:"configuration,omitempty"`
+	// Index to pass in the next WatchRequest when reconnecting.
+	Index         uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Deleted       bool   `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchConfigurationResponse) Reset() {
+	*x = WatchConfigurationResponse{}
+	mi := &file_configpb_config_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchConfigurationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchConfigurationResponse) ProtoMessage() {}
+
+func (x *WatchConfigurationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchConfigurationResponse.ProtoReflect.Descriptor instead.
+func (*WatchConfigurationResponse) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchConfigurationResponse) GetConfiguration() *Configuration {
+	if x != nil {
+		return x.Configuration
+	}
+	return nil
+}
+
+func (x *WatchConfigurationResponse) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *WatchConfigurationResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type WatchConfigurationGroupResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset when deleted is true.
+	Group *ConfigurationGroup `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	// Index to pass in the next WatchRequest when reconnecting.
+	Index         uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Deleted       bool   `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchConfigurationGroupResponse) Reset() {
+	*x = WatchConfigurationGroupResponse{}
+	mi := &file_configpb_config_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchConfigurationGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchConfigurationGroupResponse) ProtoMessage() {}
+
+func (x *WatchConfigurationGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchConfigurationGroupResponse.ProtoReflect.Descriptor instead.
+func (*WatchConfigurationGroupResponse) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchConfigurationGroupResponse) GetGroup() *ConfigurationGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *WatchConfigurationGroupResponse) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *WatchConfigurationGroupResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type LabelSelectorRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the group.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Version of the group.
+	Version       string            `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Labels        map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelSelectorRequest) Reset() {
+	*x = LabelSelectorRequest{}
+	mi := &file_configpb_config_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelSelectorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelSelectorRequest) ProtoMessage() {}
+
+func (x *LabelSelectorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelSelectorRequest.ProtoReflect.Descriptor instead.
+func (*LabelSelectorRequest) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LabelSelectorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LabelSelectorRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *LabelSelectorRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type FilterConfigsByLabelsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Configurations []*Configuration       `protobuf:"bytes,1,rep,name=configurations,proto3" json:"configurations,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FilterConfigsByLabelsResponse) Reset() {
+	*x = FilterConfigsByLabelsResponse{}
+	mi := &file_configpb_config_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterConfigsByLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterConfigsByLabelsResponse) ProtoMessage() {}
+
+func (x *FilterConfigsByLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterConfigsByLabelsResponse.ProtoReflect.Descriptor instead.
+func (*FilterConfigsByLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FilterConfigsByLabelsResponse) GetConfigurations() []*Configuration {
+	if x != nil {
+		return x.Configurations
+	}
+	return nil
+}
+
+type DeleteConfigsByLabelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int32                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConfigsByLabelsResponse) Reset() {
+	*x = DeleteConfigsByLabelsResponse{}
+	mi := &file_configpb_config_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConfigsByLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConfigsByLabelsResponse) ProtoMessage() {}
+
+func (x *DeleteConfigsByLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_configpb_config_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConfigsByLabelsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteConfigsByLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_configpb_config_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteConfigsByLabelsResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+var File_configpb_config_proto protoreflect.FileDescriptor
+
+const file_configpb_config_proto_rawDesc = "" +
+	"\n" +
+	"\x15configpb/config.proto\x12\tconfig.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"3\n" +
+	"\tParameter\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"@\n" +
+	"\x10ConfigurationRef\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"\x98\x02\n" +
+	"\bMetadata\x129\n" +
+	"\n" +
+	"created_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x02 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x04 \x01(\tR\tupdatedBy\x12\x1a\n" +
+	"\brevision\x18\x05 \x01(\x03R\brevision\x12<\n" +
+	"\vcloned_from\x18\x06 \x01(\v2\x1b.config.v1.ConfigurationRefR\n" +
+	"clonedFrom\"\xe6\x03\n" +
+	"\rConfiguration\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12,\n" +
+	"\x06params\x18\x04 \x03(\v2\x14.config.v1.ParameterR\x06params\x12,\n" +
+	"\x06labels\x18\x05 \x03(\v2\x14.config.v1.ParameterR\x06labels\x123\n" +
+	"\x06parent\x18\x06 \x01(\v2\x1b.config.v1.ConfigurationRefR\x06parent\x12#\n" +
+	"\rremove_params\x18\a \x03(\tR\fremoveParams\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x14\n" +
+	"\x05state\x18\t \x01(\tR\x05state\x12?\n" +
+	"\rdeprecated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\fdeprecatedAt\x127\n" +
+	"\tsunset_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\bsunsetAt\x12/\n" +
+	"\bmetadata\x18\f \x01(\v2\x13.config.v1.MetadataR\bmetadata\"\xf7\x02\n" +
+	"\x12ConfigurationGroup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12@\n" +
+	"\x0econfigurations\x18\x04 \x03(\v2\x18.config.v1.ConfigurationR\x0econfigurations\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05state\x18\x06 \x01(\tR\x05state\x12?\n" +
+	"\rdeprecated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fdeprecatedAt\x127\n" +
+	"\tsunset_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bsunsetAt\x12/\n" +
+	"\bmetadata\x18\t \x01(\v2\x13.config.v1.MetadataR\bmetadata\"\xa2\x02\n" +
+	"\x1aCreateConfigurationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12,\n" +
+	"\x06params\x18\x03 \x03(\v2\x14.config.v1.ParameterR\x06params\x12,\n" +
+	"\x06labels\x18\x04 \x03(\v2\x14.config.v1.ParameterR\x06labels\x123\n" +
+	"\x06parent\x18\x05 \x01(\v2\x1b.config.v1.ConfigurationRefR\x06parent\x12#\n" +
+	"\rremove_params\x18\x06 \x03(\tR\fremoveParams\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"G\n" +
+	"\x17GetConfigurationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"J\n" +
+	"\x1aDeleteConfigurationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"\xa6\x01\n" +
+	"\x12CreateGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12@\n" +
+	"\x0econfigurations\x18\x03 \x03(\v2\x18.config.v1.ConfigurationR\x0econfigurations\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"L\n" +
+	"\x1cGetConfigurationGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"O\n" +
+	"\x1fDeleteConfigurationGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"R\n" +
+	"\fWatchRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x14\n" +
+	"\x05index\x18\x03 \x01(\x04R\x05index\"\x8c\x01\n" +
+	"\x1aWatchConfigurationResponse\x12>\n" +
+	"\rconfiguration\x18\x01 \x01(\v2\x18.config.v1.ConfigurationR\rconfiguration\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x04R\x05index\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\bR\adeleted\"\x86\x01\n" +
+	"\x1fWatchConfigurationGroupResponse\x123\n" +
+	"\x05group\x18\x01 \x01(\v2\x1d.config.v1.ConfigurationGroupR\x05group\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x04R\x05index\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\bR\adeleted\"\xc4\x01\n" +
+	"\x14LabelSelectorRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12C\n" +
+	"\x06labels\x18\x03 \x03(\v2+.config.v1.LabelSelectorRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"a\n" +
+	"\x1dFilterConfigsByLabelsResponse\x12@\n" +
+	"\x0econfigurations\x18\x01 \x03(\v2\x18.config.v1.ConfigurationR\x0econfigurations\"9\n" +
+	"\x1dDeleteConfigsByLabelsResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x05R\adeleted2\xd8\b\n" +
+	"\rConfigService\x12S\n" +
+	"\x10AddConfiguration\x12%.config.v1.CreateConfigurationRequest\x1a\x18.config.v1.Configuration\x12P\n" +
+	"\x10GetConfiguration\x12\".config.v1.GetConfigurationRequest\x1a\x18.config.v1.Configuration\x12V\n" +
+	"\x13UpdateConfiguration\x12%.config.v1.CreateConfigurationRequest\x1a\x18.config.v1.Configuration\x12T\n" +
+	"\x13DeleteConfiguration\x12%.config.v1.DeleteConfigurationRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\x12WatchConfiguration\x12\x17.config.v1.WatchRequest\x1a%.config.v1.WatchConfigurationResponse0\x01\x12U\n" +
+	"\x15AddConfigurationGroup\x12\x1d.config.v1.CreateGroupRequest\x1a\x1d.config.v1.ConfigurationGroup\x12_\n" +
+	"\x15GetConfigurationGroup\x12'.config.v1.GetConfigurationGroupRequest\x1a\x1d.config.v1.ConfigurationGroup\x12X\n" +
+	"\x18UpdateConfigurationGroup\x12\x1d.config.v1.CreateGroupRequest\x1a\x1d.config.v1.ConfigurationGroup\x12^\n" +
+	"\x18DeleteConfigurationGroup\x12*.config.v1.DeleteConfigurationGroupRequest\x1a\x16.google.protobuf.Empty\x12`\n" +
+	"\x17WatchConfigurationGroup\x12\x17.config.v1.WatchRequest\x1a*.config.v1.WatchConfigurationGroupResponse0\x01\x12b\n" +
+	"\x15FilterConfigsByLabels\x12\x1f.config.v1.LabelSelectorRequest\x1a(.config.v1.FilterConfigsByLabelsResponse\x12b\n" +
+	"\x15DeleteConfigsByLabels\x12\x1f.config.v1.LabelSelectorRequest\x1a(.config.v1.DeleteConfigsByLabelsResponseB\x1fZ\x1dalati_projekat/proto/configpbb\x06proto3"
+
+var (
+	file_configpb_config_proto_rawDescOnce sync.Once
+	file_configpb_config_proto_rawDescData []byte
+)
+
+func file_configpb_config_proto_rawDescGZIP() []byte {
+	file_configpb_config_proto_rawDescOnce.Do(func() {
+		file_configpb_config_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_configpb_config_proto_rawDesc), len(file_configpb_config_proto_rawDesc)))
+	})
+	return file_configpb_config_proto_rawDescData
+}
+
+var file_configpb_config_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_configpb_config_proto_goTypes = []any{
+	(*Parameter)(nil),                       // 0: config.v1.Parameter
+	(*ConfigurationRef)(nil),                // 1: config.v1.ConfigurationRef
+	(*Metadata)(nil),                        // 2: config.v1.Metadata
+	(*Configuration)(nil),                   // 3: config.v1.Configuration
+	(*ConfigurationGroup)(nil),              // 4: config.v1.ConfigurationGroup
+	(*CreateConfigurationRequest)(nil),      // 5: config.v1.CreateConfigurationRequest
+	(*GetConfigurationRequest)(nil),         // 6: config.v1.GetConfigurationRequest
+	(*DeleteConfigurationRequest)(nil),      // 7: config.v1.DeleteConfigurationRequest
+	(*CreateGroupRequest)(nil),              // 8: config.v1.CreateGroupRequest
+	(*GetConfigurationGroupRequest)(nil),    // 9: config.v1.GetConfigurationGroupRequest
+	(*DeleteConfigurationGroupRequest)(nil), // 10: config.v1.DeleteConfigurationGroupRequest
+	(*WatchRequest)(nil),                    // 11: config.v1.WatchRequest
+	(*WatchConfigurationResponse)(nil),      // 12: config.v1.WatchConfigurationResponse
+	(*WatchConfigurationGroupResponse)(nil), // 13: config.v1.WatchConfigurationGroupResponse
+	(*LabelSelectorRequest)(nil),            // 14: config.v1.LabelSelectorRequest
+	(*FilterConfigsByLabelsResponse)(nil),   // 15: config.v1.FilterConfigsByLabelsResponse
+	(*DeleteConfigsByLabelsResponse)(nil),   // 16: config.v1.DeleteConfigsByLabelsResponse
+	nil,                                     // 17: config.v1.LabelSelectorRequest.LabelsEntry
+	(*timestamppb.Timestamp)(nil),           // 18: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                   // 19: google.protobuf.Empty
+}
+var file_configpb_config_proto_depIdxs = []int32{
+	18, // 0: config.v1.Metadata.created_at:type_name -> google.protobuf.Timestamp
+	18, // 1: config.v1.Metadata.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: config.v1.Metadata.cloned_from:type_name -> config.v1.ConfigurationRef
+	0,  // 3: config.v1.Configuration.params:type_name -> config.v1.Parameter
+	0,  // 4: config.v1.Configuration.labels:type_name -> config.v1.Parameter
+	1,  // 5: config.v1.Configuration.parent:type_name -> config.v1.ConfigurationRef
+	18, // 6: config.v1.Configuration.deprecated_at:type_name -> google.protobuf.Timestamp
+	18, // 7: config.v1.Configuration.sunset_at:type_name -> google.protobuf.Timestamp
+	2,  // 8: config.v1.Configuration.metadata:type_name -> config.v1.Metadata
+	3,  // 9: config.v1.ConfigurationGroup.configurations:type_name -> config.v1.Configuration
+	18, // 10: config.v1.ConfigurationGroup.deprecated_at:type_name -> google.protobuf.Timestamp
+	18, // 11: config.v1.ConfigurationGroup.sunset_at:type_name -> google.protobuf.Timestamp
+	2,  // 12: config.v1.ConfigurationGroup.metadata:type_name -> config.v1.Metadata
+	0,  // 13: config.v1.CreateConfigurationRequest.params:type_name -> config.v1.Parameter
+	0,  // 14: config.v1.CreateConfigurationRequest.labels:type_name -> config.v1.Parameter
+	1,  // 15: config.v1.CreateConfigurationRequest.parent:type_name -> config.v1.ConfigurationRef
+	3,  // 16: config.v1.CreateGroupRequest.configurations:type_name -> config.v1.Configuration
+	3,  // 17: config.v1.WatchConfigurationResponse.configuration:type_name -> config.v1.Configuration
+	4,  // 18: config.v1.WatchConfigurationGroupResponse.group:type_name -> config.v1.ConfigurationGroup
+	17, // 19: config.v1.LabelSelectorRequest.labels:type_name -> config.v1.LabelSelectorRequest.LabelsEntry
+	3,  // 20: config.v1.FilterConfigsByLabelsResponse.configurations:type_name -> config.v1.Configuration
+	5,  // 21: config.v1.ConfigService.AddConfiguration:input_type -> config.v1.CreateConfigurationRequest
+	6,  // 22: config.v1.ConfigService.GetConfiguration:input_type -> config.v1.GetConfigurationRequest
+	5,  // 23: config.v1.ConfigService.UpdateConfiguration:input_type -> config.v1.CreateConfigurationRequest
+	7,  // 24: config.v1.ConfigService.DeleteConfiguration:input_type -> config.v1.DeleteConfigurationRequest
+	11, // 25: config.v1.ConfigService.WatchConfiguration:input_type -> config.v1.WatchRequest
+	8,  // 26: config.v1.ConfigService.AddConfigurationGroup:input_type -> config.v1.CreateGroupRequest
+	9,  // 27: config.v1.ConfigService.GetConfigurationGroup:input_type -> config.v1.GetConfigurationGroupRequest
+	8,  // 28: config.v1.ConfigService.UpdateConfigurationGroup:input_type -> config.v1.CreateGroupRequest
+	10, // 29: config.v1.ConfigService.DeleteConfigurationGroup:input_type -> config.v1.DeleteConfigurationGroupRequest
+	11, // 30: config.v1.ConfigService.WatchConfigurationGroup:input_type -> config.v1.WatchRequest
+	14, // 31: config.v1.ConfigService.FilterConfigsByLabels:input_type -> config.v1.LabelSelectorRequest
+	14, // 32: config.v1.ConfigService.DeleteConfigsByLabels:input_type -> config.v1.LabelSelectorRequest
+	3,  // 33: config.v1.ConfigService.AddConfiguration:output_type -> config.v1.Configuration
+	3,  // 34: config.v1.ConfigService.GetConfiguration:output_type -> config.v1.Configuration
+	3,  // 35: config.v1.ConfigService.UpdateConfiguration:output_type -> config.v1.Configuration
+	19, // 36: config.v1.ConfigService.DeleteConfiguration:output_type -> google.protobuf.Empty
+	12, // 37: config.v1.ConfigService.WatchConfiguration:output_type -> config.v1.WatchConfigurationResponse
+	4,  // 38: config.v1.ConfigService.AddConfigurationGroup:output_type -> config.v1.ConfigurationGroup
+	4,  // 39: config.v1.ConfigService.GetConfigurationGroup:output_type -> config.v1.ConfigurationGroup
+	4,  // 40: config.v1.ConfigService.UpdateConfigurationGroup:output_type -> config.v1.ConfigurationGroup
+	19, // 41: config.v1.ConfigService.DeleteConfigurationGroup:output_type -> google.protobuf.Empty
+	13, // 42: config.v1.ConfigService.WatchConfigurationGroup:output_type -> config.v1.WatchConfigurationGroupResponse
+	15, // 43: config.v1.ConfigService.FilterConfigsByLabels:output_type -> config.v1.FilterConfigsByLabelsResponse
+	16, // 44: config.v1.ConfigService.DeleteConfigsByLabels:output_type -> config.v1.DeleteConfigsByLabelsResponse
+	33, // [33:45] is the sub-list for method output_type
+	21, // [21:33] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_configpb_config_proto_init() }
+func file_configpb_config_proto_init() {
+	if File_configpb_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_configpb_config_proto_rawDesc), len(file_configpb_config_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_configpb_config_proto_goTypes,
+		DependencyIndexes: file_configpb_config_proto_depIdxs,
+		MessageInfos:      file_configpb_config_proto_msgTypes,
+	}.Build()
+	File_configpb_config_proto = out.File
+	file_configpb_config_proto_goTypes = nil
+	file_configpb_config_proto_depIdxs = nil
+}
@@ -0,0 +1,565 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: configpb/config.proto
+
+package configpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ConfigService_AddConfiguration_FullMethodName         = "/config.v1.ConfigService/AddConfiguration"
+	ConfigService_GetConfiguration_FullMethodName         = "/config.v1.ConfigService/GetConfiguration"
+	ConfigService_UpdateConfiguration_FullMethodName      = "/config.v1.ConfigService/UpdateConfiguration"
+	ConfigService_DeleteConfiguration_FullMethodName      = "/config.v1.ConfigService/DeleteConfiguration"
+	ConfigService_WatchConfiguration_FullMethodName       = "/config.v1.ConfigService/WatchConfiguration"
+	ConfigService_AddConfigurationGroup_FullMethodName    = "/config.v1.ConfigService/AddConfigurationGroup"
+	ConfigService_GetConfigurationGroup_FullMethodName    = "/config.v1.ConfigService/GetConfigurationGroup"
+	ConfigService_UpdateConfigurationGroup_FullMethodName = "/config.v1.ConfigService/UpdateConfigurationGroup"
+	ConfigService_DeleteConfigurationGroup_FullMethodName = "/config.v1.ConfigService/DeleteConfigurationGroup"
+	ConfigService_WatchConfigurationGroup_FullMethodName  = "/config.v1.ConfigService/WatchConfigurationGroup"
+	ConfigService_FilterConfigsByLabels_FullMethodName    = "/config.v1.ConfigService/FilterConfigsByLabels"
+	ConfigService_DeleteConfigsByLabels_FullMethodName    = "/config.v1.ConfigService/DeleteConfigsByLabels"
+)
+
+// ConfigServiceClient is the client API for ConfigService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ConfigService exposes the configuration service over gRPC. It mirrors the REST API:
+// errors use the matching gRPC codes, the idempotency key is read from the "x-request-id"
+// metadata entry and the caller identity from "x-actor".
+type ConfigServiceClient interface {
+	AddConfiguration(ctx context.Context, in *CreateConfigurationRequest, opts ...grpc.CallOption) (*Configuration, error)
+	GetConfiguration(ctx context.Context, in *GetConfigurationRequest, opts ...grpc.CallOption) (*Configuration, error)
+	UpdateConfiguration(ctx context.Context, in *CreateConfigurationRequest, opts ...grpc.CallOption) (*Configuration, error)
+	DeleteConfiguration(ctx context.Context, in *DeleteConfigurationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// WatchConfiguration sends the configuration, then every later change to it until the
+	// client cancels the call.
+	WatchConfiguration(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchConfigurationResponse], error)
+	AddConfigurationGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*ConfigurationGroup, error)
+	GetConfigurationGroup(ctx context.Context, in *GetConfigurationGroupRequest, opts ...grpc.CallOption) (*ConfigurationGroup, error)
+	UpdateConfigurationGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*ConfigurationGroup, error)
+	DeleteConfigurationGroup(ctx context.Context, in *DeleteConfigurationGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// WatchConfigurationGroup is the group counterpart of WatchConfiguration.
+	WatchConfigurationGroup(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchConfigurationGroupResponse], error)
+	// FilterConfigsByLabels returns the members of a group that carry every given label.
+	FilterConfigsByLabels(ctx context.Context, in *LabelSelectorRequest, opts ...grpc.CallOption) (*FilterConfigsByLabelsResponse, error)
+	// DeleteConfigsByLabels removes the members of a group that carry every given label.
+	DeleteConfigsByLabels(ctx context.Context, in *LabelSelectorRequest, opts ...grpc.CallOption) (*DeleteConfigsByLabelsResponse, error)
+}
+
+type configServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConfigServiceClient(cc grpc.ClientConnInterface) ConfigServiceClient {
+	return &configServiceClient{cc}
+}
+
+func (c *configServiceClient) AddConfiguration(ctx context.Context, in *CreateConfigurationRequest, opts ...grpc.CallOption) (*Configuration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Configuration)
+	err := c.cc.Invoke(ctx, ConfigService_AddConfiguration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) GetConfiguration(ctx context.Context, in *GetConfigurationRequest, opts ...grpc.CallOption) (*Configuration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Configuration)
+	err := c.cc.Invoke(ctx, ConfigService_GetConfiguration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) UpdateConfiguration(ctx context.Context, in *CreateConfigurationRequest, opts ...grpc.CallOption) (*Configuration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Configuration)
+	err := c.cc.Invoke(ctx, ConfigService_UpdateConfiguration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) DeleteConfiguration(ctx context.Context, in *DeleteConfigurationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ConfigService_DeleteConfiguration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) WatchConfiguration(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchConfigurationResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ConfigService_ServiceDesc.Streams[0], ConfigService_WatchConfiguration_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchConfigurationResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConfigService_WatchConfigurationClient = grpc.ServerStreamingClient[WatchConfigurationResponse]
+
+func (c *configServiceClient) AddConfigurationGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*ConfigurationGroup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigurationGroup)
+	err := c.cc.Invoke(ctx, ConfigService_AddConfigurationGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) GetConfigurationGroup(ctx context.Context, in *GetConfigurationGroupRequest, opts ...grpc.CallOption) (*ConfigurationGroup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigurationGroup)
+	err := c.cc.Invoke(ctx, ConfigService_GetConfigurationGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) UpdateConfigurationGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*ConfigurationGroup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigurationGroup)
+	err := c.cc.Invoke(ctx, ConfigService_UpdateConfigurationGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) DeleteConfigurationGroup(ctx context.Context, in *DeleteConfigurationGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ConfigService_DeleteConfigurationGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) WatchConfigurationGroup(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchConfigurationGroupResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ConfigService_ServiceDesc.Streams[1], ConfigService_WatchConfigurationGroup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchConfigurationGroupResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConfigService_WatchConfigurationGroupClient = grpc.ServerStreamingClient[WatchConfigurationGroupResponse]
+
+func (c *configServiceClient) FilterConfigsByLabels(ctx context.Context, in *LabelSelectorRequest, opts ...grpc.CallOption) (*FilterConfigsByLabelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FilterConfigsByLabelsResponse)
+	err := c.cc.Invoke(ctx, ConfigService_FilterConfigsByLabels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) DeleteConfigsByLabels(ctx context.Context, in *LabelSelectorRequest, opts ...grpc.CallOption) (*DeleteConfigsByLabelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteConfigsByLabelsResponse)
+	err := c.cc.Invoke(ctx, ConfigService_DeleteConfigsByLabels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConfigServiceServer is the server API for ConfigService service.
+// All implementations must embed UnimplementedConfigServiceServer
+// for forward compatibility.
+//
+// ConfigService exposes the configuration service over gRPC. It mirrors the REST API:
+// errors use the matching gRPC codes, the idempotency key is read from the "x-request-id"
+// metadata entry and the caller identity from "x-actor".
+type ConfigServiceServer interface {
+	AddConfiguration(context.Context, *CreateConfigurationRequest) (*Configuration, error)
+	GetConfiguration(context.Context, *GetConfigurationRequest) (*Configuration, error)
+	UpdateConfiguration(context.Context, *CreateConfigurationRequest) (*Configuration, error)
+	DeleteConfiguration(context.Context, *DeleteConfigurationRequest) (*emptypb.Empty, error)
+	// WatchConfiguration sends the configuration, then every later change to it until the
+	// client cancels the call.
+	WatchConfiguration(*WatchRequest, grpc.ServerStreamingServer[WatchConfigurationResponse]) error
+	AddConfigurationGroup(context.Context, *CreateGroupRequest) (*ConfigurationGroup, error)
+	GetConfigurationGroup(context.Context, *GetConfigurationGroupRequest) (*ConfigurationGroup, error)
+	UpdateConfigurationGroup(context.Context, *CreateGroupRequest) (*ConfigurationGroup, error)
+	DeleteConfigurationGroup(context.Context, *DeleteConfigurationGroupRequest) (*emptypb.Empty, error)
+	// WatchConfigurationGroup is the group counterpart of WatchConfiguration.
+	WatchConfigurationGroup(*WatchRequest, grpc.ServerStreamingServer[WatchConfigurationGroupResponse]) error
+	// FilterConfigsByLabels returns the members of a group that carry every given label.
+	FilterConfigsByLabels(context.Context, *LabelSelectorRequest) (*FilterConfigsByLabelsResponse, error)
+	// DeleteConfigsByLabels removes the members of a group that carry every given label.
+	DeleteConfigsByLabels(context.Context, *LabelSelectorRequest) (*DeleteConfigsByLabelsResponse, error)
+	mustEmbedUnimplementedConfigServiceServer()
+}
+
+// UnimplementedConfigServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedConfigServiceServer struct{}
+
+func (UnimplementedConfigServiceServer) AddConfiguration(context.Context, *CreateConfigurationRequest) (*Configuration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddConfiguration not implemented")
+}
+func (UnimplementedConfigServiceServer) GetConfiguration(context.Context, *GetConfigurationRequest) (*Configuration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfiguration not implemented")
+}
+func (UnimplementedConfigServiceServer) UpdateConfiguration(context.Context, *CreateConfigurationRequest) (*Configuration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfiguration not implemented")
+}
+func (UnimplementedConfigServiceServer) DeleteConfiguration(context.Context, *DeleteConfigurationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConfiguration not implemented")
+}
+func (UnimplementedConfigServiceServer) WatchConfiguration(*WatchRequest, grpc.ServerStreamingServer[WatchConfigurationResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchConfiguration not implemented")
+}
+func (UnimplementedConfigServiceServer) AddConfigurationGroup(context.Context, *CreateGroupRequest) (*ConfigurationGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddConfigurationGroup not implemented")
+}
+func (UnimplementedConfigServiceServer) GetConfigurationGroup(context.Context, *GetConfigurationGroupRequest) (*ConfigurationGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigurationGroup not implemented")
+}
+func (UnimplementedConfigServiceServer) UpdateConfigurationGroup(context.Context, *CreateGroupRequest) (*ConfigurationGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfigurationGroup not implemented")
+}
+func (UnimplementedConfigServiceServer) DeleteConfigurationGroup(context.Context, *DeleteConfigurationGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConfigurationGroup not implemented")
+}
+func (UnimplementedConfigServiceServer) WatchConfigurationGroup(*WatchRequest, grpc.ServerStreamingServer[WatchConfigurationGroupResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchConfigurationGroup not implemented")
+}
+func (UnimplementedConfigServiceServer) FilterConfigsByLabels(context.Context, *LabelSelectorRequest) (*FilterConfigsByLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FilterConfigsByLabels not implemented")
+}
+func (UnimplementedConfigServiceServer) DeleteConfigsByLabels(context.Context, *LabelSelectorRequest) (*DeleteConfigsByLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConfigsByLabels not implemented")
+}
+func (UnimplementedConfigServiceServer) mustEmbedUnimplementedConfigServiceServer() {}
+func (UnimplementedConfigServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeConfigServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConfigServiceServer will
+// result in compilation errors.
+type UnsafeConfigServiceServer interface {
+	mustEmbedUnimplementedConfigServiceServer()
+}
+
+func RegisterConfigServiceServer(s grpc.ServiceRegistrar, srv ConfigServiceServer) {
+	// If the following call pancis, it indicates UnimplementedConfigServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ConfigService_ServiceDesc, srv)
+}
+
+func _ConfigService_AddConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).AddConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_AddConfiguration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).AddConfiguration(ctx, req.(*CreateConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_GetConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).GetConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_GetConfiguration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).GetConfiguration(ctx, req.(*GetConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_UpdateConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).UpdateConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_UpdateConfiguration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).UpdateConfiguration(ctx, req.(*CreateConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_DeleteConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).DeleteConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_DeleteConfiguration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).DeleteConfiguration(ctx, req.(*DeleteConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_WatchConfiguration_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConfigServiceServer).WatchConfiguration(m, &grpc.GenericServerStream[WatchRequest, WatchConfigurationResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConfigService_WatchConfigurationServer = grpc.ServerStreamingServer[WatchConfigurationResponse]
+
+func _ConfigService_AddConfigurationGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).AddConfigurationGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_AddConfigurationGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).AddConfigurationGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_GetConfigurationGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigurationGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).GetConfigurationGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_GetConfigurationGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).GetConfigurationGroup(ctx, req.(*GetConfigurationGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_UpdateConfigurationGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).UpdateConfigurationGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_UpdateConfigurationGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).UpdateConfigurationGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_DeleteConfigurationGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConfigurationGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).DeleteConfigurationGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_DeleteConfigurationGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).DeleteConfigurationGroup(ctx, req.(*DeleteConfigurationGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_WatchConfigurationGroup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConfigServiceServer).WatchConfigurationGroup(m, &grpc.GenericServerStream[WatchRequest, WatchConfigurationGroupResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConfigService_WatchConfigurationGroupServer = grpc.ServerStreamingServer[WatchConfigurationGroupResponse]
+
+func _ConfigService_FilterConfigsByLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelSelectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).FilterConfigsByLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_FilterConfigsByLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).FilterConfigsByLabels(ctx, req.(*LabelSelectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_DeleteConfigsByLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelSelectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).DeleteConfigsByLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_DeleteConfigsByLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).DeleteConfigsByLabels(ctx, req.(*LabelSelectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ConfigService_ServiceDesc is the grpc.ServiceDesc for ConfigService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConfigService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "config.v1.ConfigService",
+	HandlerType: (*ConfigServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddConfiguration",
+			Handler:    _ConfigService_AddConfiguration_Handler,
+		},
+		{
+			MethodName: "GetConfiguration",
+			Handler:    _ConfigService_GetConfiguration_Handler,
+		},
+		{
+			MethodName: "UpdateConfiguration",
+			Handler:    _ConfigService_UpdateConfiguration_Handler,
+		},
+		{
+			MethodName: "DeleteConfiguration",
+			Handler:    _ConfigService_DeleteConfiguration_Handler,
+		},
+		{
+			MethodName: "AddConfigurationGroup",
+			Handler:    _ConfigService_AddConfigurationGroup_Handler,
+		},
+		{
+			MethodName: "GetConfigurationGroup",
+			Handler:    _ConfigService_GetConfigurationGroup_Handler,
+		},
+		{
+			MethodName: "UpdateConfigurationGroup",
+			Handler:    _ConfigService_UpdateConfigurationGroup_Handler,
+		},
+		{
+			MethodName: "DeleteConfigurationGroup",
+			Handler:    _ConfigService_DeleteConfigurationGroup_Handler,
+		},
+		{
+			MethodName: "FilterConfigsByLabels",
+			Handler:    _ConfigService_FilterConfigsByLabels_Handler,
+		},
+		{
+			MethodName: "DeleteConfigsByLabels",
+			Handler:    _ConfigService_DeleteConfigsByLabels_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchConfiguration",
+			Handler:       _ConfigService_WatchConfiguration_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchConfigurationGroup",
+			Handler:       _ConfigService_WatchConfigurationGroup_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "configpb/config.proto",
+}
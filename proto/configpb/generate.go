@@ -0,0 +1,5 @@
+// Package configpb holds the protobuf messages and the gRPC service definition of the
+// configuration service.
+package configpb
+
+//go:generate protoc --proto_path=.. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative configpb/config.proto
@@ -0,0 +1,51 @@
+package repository_test
+
+import (
+	"alati_projekat/repository"
+	"alati_projekat/repository/consultest"
+	"alati_projekat/repository/repotest"
+	"os"
+	"testing"
+)
+
+func TestInMemoryRepository_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		return repository.NewInMemoryRepository()
+	})
+}
+
+func TestFileRepository_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		repo, err := repository.NewFileRepository(t.TempDir(), repository.FileOptions{Sync: repository.SyncNever})
+		if err != nil {
+			t.Fatalf("NewFileRepository failed: %v", err)
+		}
+		t.Cleanup(func() { repo.Close() })
+		return repo
+	})
+}
+
+func TestConsulRepository_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		repo, err := repository.NewConsulRepository(consultest.NewServer(t).URL)
+		if err != nil {
+			t.Fatalf("NewConsulRepository failed: %v", err)
+		}
+		return repo
+	})
+}
+
+func TestConsulRepository_ConformanceAgent(t *testing.T) {
+	addr := os.Getenv("CONSUL_HTTP_ADDR")
+	if addr == "" {
+		t.Skip("Skipping test: set CONSUL_HTTP_ADDR to run the suite against a Consul agent")
+	}
+	repo, err := repository.NewConsulRepository(addr)
+	if err != nil {
+		t.Fatalf("NewConsulRepository failed: %v", err)
+	}
+	if _, err := repo.Client.Status().Leader(); err != nil {
+		t.Skipf("Skipping test: no Consul agent at %s: %v", addr, err)
+	}
+	repotest.Run(t, func(t *testing.T) repository.Repository { return repo })
+}
@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/consul/api"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -42,6 +45,45 @@ func makeKey(name, version string) string {
 	return fmt.Sprintf("%s/%s", name, version)
 }
 
+// listPrefix returns the KV prefix holding all versions of name, or the whole prefix when name is empty.
+func listPrefix(prefix, name string) string {
+	if name == "" {
+		return prefix
+	}
+	return prefix + name + "/"
+}
+
+var errKeyNotFound = errors.New("key not found")
+
+// compareAndSwap writes data to key only if the record currently stored there, decoded into
+// stored, reports expectedRevision. The write uses Consul's check-and-set on the ModifyIndex
+// that was read, so a concurrent writer between the read and the write is also detected.
+func (r *ConsulRepository) compareAndSwap(ctx context.Context, key string, data []byte, stored any, revision func() int64, expectedRevision int64) error {
+	pair, _, err := r.Client.KV().Get(key, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to read %s from Consul: %w", key, err)
+	}
+	if pair == nil {
+		return errKeyNotFound
+	}
+	if err := json.Unmarshal(pair.Value, stored); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	if revision() != expectedRevision {
+		return ErrRevisionConflict
+	}
+
+	p := &api.KVPair{Key: key, Value: data, ModifyIndex: pair.ModifyIndex}
+	ok, _, err := r.Client.KV().CAS(p, (&api.WriteOptions{}).WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update %s in Consul: %w", key, err)
+	}
+	if !ok {
+		return ErrRevisionConflict
+	}
+	return nil
+}
+
 // ---------------------- CONFIGURATIONS ----------------------
 
 func (r *ConsulRepository) AddConfiguration(ctx context.Context, config model.Configuration) (err error) {
@@ -130,6 +172,32 @@ func (r *ConsulRepository) UpdateConfiguration(ctx context.Context, config model
 	return nil
 }
 
+func (r *ConsulRepository) CompareAndSwapConfiguration(ctx context.Context, config model.Configuration, expectedRevision int64) (err error) {
+	ctx, span := tracer.Start(ctx, "CompareAndSwapConfiguration")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(attribute.String("config.name", config.Name), attribute.String("config.version", config.Version))
+
+	key := ConfigsPrefix + makeKey(config.Name, config.Version)
+	data, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to serialize configuration for update: %w", err)
+	}
+
+	var stored model.Configuration
+	if err := r.compareAndSwap(ctx, key, data, &stored, func() int64 { return stored.Metadata.Revision }, expectedRevision); err != nil {
+		if errors.Is(err, errKeyNotFound) {
+			return errors.New("configuration not found")
+		}
+		return err
+	}
+	return nil
+}
+
 func (r *ConsulRepository) DeleteConfiguration(ctx context.Context, name, version string) (err error) {
 	ctx, span := tracer.Start(ctx, "DeleteConfiguration")
 	defer func() {
@@ -152,6 +220,35 @@ func (r *ConsulRepository) DeleteConfiguration(ctx context.Context, name, versio
 	return nil
 }
 
+func (r *ConsulRepository) ListConfigurations(ctx context.Context, name string) (configs []model.Configuration, err error) {
+	ctx, span := tracer.Start(ctx, "ListConfigurations")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(attribute.String("config.name", name))
+
+	queryOptions := (&api.QueryOptions{}).WithContext(ctx)
+
+	pairs, _, err := r.Client.KV().List(listPrefix(ConfigsPrefix, name), queryOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configurations from Consul: %w", err)
+	}
+
+	configs = make([]model.Configuration, 0, len(pairs))
+	for _, pair := range pairs {
+		var config model.Configuration
+		if err := json.Unmarshal(pair.Value, &config); err != nil {
+			return nil, fmt.Errorf("failed to decode configuration JSON at %s: %w", pair.Key, err)
+		}
+		configs = append(configs, config)
+	}
+
+	return configs, nil
+}
+
 // ---------------------- CONFIGURATION GROUPS ----------------------
 
 func (r *ConsulRepository) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup) (err error) {
@@ -241,6 +338,32 @@ func (r *ConsulRepository) UpdateConfigurationGroup(ctx context.Context, group m
 	return nil
 }
 
+func (r *ConsulRepository) CompareAndSwapConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, expectedRevision int64) (err error) {
+	ctx, span := tracer.Start(ctx, "CompareAndSwapConfigurationGroup")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(attribute.String("group.name", group.Name), attribute.String("group.version", group.Version))
+
+	key := GroupsPrefix + makeKey(group.Name, group.Version)
+	data, err := json.Marshal(group)
+	if err != nil {
+		return fmt.Errorf("failed to serialize configuration group for update: %w", err)
+	}
+
+	var stored model.ConfigurationGroup
+	if err := r.compareAndSwap(ctx, key, data, &stored, func() int64 { return stored.Metadata.Revision }, expectedRevision); err != nil {
+		if errors.Is(err, errKeyNotFound) {
+			return errors.New("configuration group not found")
+		}
+		return err
+	}
+	return nil
+}
+
 func (r *ConsulRepository) DeleteConfigurationGroup(ctx context.Context, name, version string) (err error) {
 	ctx, span := tracer.Start(ctx, "DeleteConfigurationGroup")
 	defer func() {
@@ -263,6 +386,286 @@ func (r *ConsulRepository) DeleteConfigurationGroup(ctx context.Context, name, v
 	return nil
 }
 
+func (r *ConsulRepository) ListConfigurationGroups(ctx context.Context, name string) (groups []model.ConfigurationGroup, err error) {
+	ctx, span := tracer.Start(ctx, "ListConfigurationGroups")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(attribute.String("group.name", name))
+
+	queryOptions := (&api.QueryOptions{}).WithContext(ctx)
+
+	pairs, _, err := r.Client.KV().List(listPrefix(GroupsPrefix, name), queryOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configuration groups from Consul: %w", err)
+	}
+
+	groups = make([]model.ConfigurationGroup, 0, len(pairs))
+	for _, pair := range pairs {
+		var group model.ConfigurationGroup
+		if err := json.Unmarshal(pair.Value, &group); err != nil {
+			return nil, fmt.Errorf("failed to decode configuration group JSON at %s: %w", pair.Key, err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// ---------------------- WATCH ----------------------
+
+// watchKey runs a Consul blocking query on key. It returns as soon as the key is modified
+// after index or when wait elapses, with the X-Consul-Index of the response.
+func (r *ConsulRepository) watchKey(ctx context.Context, key string, index uint64, wait time.Duration) (*api.KVPair, uint64, error) {
+	queryOptions := (&api.QueryOptions{WaitIndex: index, WaitTime: wait}).WithContext(ctx)
+	pair, meta, err := r.Client.KV().Get(key, queryOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to watch %s in Consul: %w", key, err)
+	}
+	return pair, meta.LastIndex, nil
+}
+
+func (r *ConsulRepository) WatchConfiguration(ctx context.Context, name, version string, index uint64, wait time.Duration) (config model.Configuration, next uint64, err error) {
+	ctx, span := tracer.Start(ctx, "WatchConfiguration")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(attribute.String("config.name", name), attribute.String("config.version", version), attribute.Int64("watch.index", int64(index)))
+
+	pair, next, err := r.watchKey(ctx, ConfigsPrefix+makeKey(name, version), index, wait)
+	if err != nil {
+		return model.Configuration{}, 0, err
+	}
+	if pair == nil {
+		return model.Configuration{}, next, errors.New("configuration not found")
+	}
+	if err := json.Unmarshal(pair.Value, &config); err != nil {
+		return model.Configuration{}, 0, fmt.Errorf("failed to decode configuration JSON: %w", err)
+	}
+	return config, next, nil
+}
+
+func (r *ConsulRepository) WatchConfigurationGroup(ctx context.Context, name, version string, index uint64, wait time.Duration) (group model.ConfigurationGroup, next uint64, err error) {
+	ctx, span := tracer.Start(ctx, "WatchConfigurationGroup")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(attribute.String("group.name", name), attribute.String("group.version", version), attribute.Int64("watch.index", int64(index)))
+
+	pair, next, err := r.watchKey(ctx, GroupsPrefix+makeKey(name, version), index, wait)
+	if err != nil {
+		return model.ConfigurationGroup{}, 0, err
+	}
+	if pair == nil {
+		return model.ConfigurationGroup{}, next, errors.New("configuration group not found")
+	}
+	if err := json.Unmarshal(pair.Value, &group); err != nil {
+		return model.ConfigurationGroup{}, 0, fmt.Errorf("failed to decode configuration group JSON: %w", err)
+	}
+	return group, next, nil
+}
+
+// ---------------------- TRANSACTIONS ----------------------
+
+// Transact applies ops in a single Consul transaction. Creates use a check-and-set on
+// index 0, so they fail if the key appeared in the meantime; updates and deletes first
+// check the stored revision and then check-and-set on the ModifyIndex that was read.
+func (r *ConsulRepository) Transact(ctx context.Context, ops []TxnOp) (err error) {
+	ctx, span := tracer.Start(ctx, "Transact")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(attribute.Int("txn.ops", len(ops)))
+
+	if len(ops) > MaxTxnOps {
+		return fmt.Errorf("%w: %d operations, at most %d are allowed", ErrTxnTooLarge, len(ops), MaxTxnOps)
+	}
+
+	txn := make(api.TxnOps, 0, len(ops))
+	for _, op := range ops {
+		kvOp, err := r.txnOp(ctx, op)
+		if err != nil {
+			return err
+		}
+		txn = append(txn, &api.TxnOp{KV: kvOp})
+	}
+	if len(txn) == 0 {
+		return nil
+	}
+
+	ok, resp, _, err := r.Client.Txn().Txn(txn, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to execute Consul transaction: %w", err)
+	}
+	if !ok {
+		reasons := make([]string, 0, len(resp.Errors))
+		for _, e := range resp.Errors {
+			reasons = append(reasons, fmt.Sprintf("%s %s/%s: %s", ops[e.OpIndex].Verb, ops[e.OpIndex].Name(), ops[e.OpIndex].Version(), e.What))
+		}
+		return fmt.Errorf("%w: %s", ErrRevisionConflict, strings.Join(reasons, "; "))
+	}
+	return nil
+}
+
+// txnOp translates op into a Consul KV operation, reading the current ModifyIndex for
+// updates and deletes.
+func (r *ConsulRepository) txnOp(ctx context.Context, op TxnOp) (*api.KVTxnOp, error) {
+	key, kind := ConfigsPrefix+makeKey(op.Name(), op.Version()), "configuration"
+	var value any = op.Configuration
+	if op.Group != nil {
+		key, kind = GroupsPrefix+makeKey(op.Name(), op.Version()), "configuration group"
+		value = op.Group
+	}
+
+	if op.Verb == TxnCreate {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize %s: %w", kind, err)
+		}
+		return &api.KVTxnOp{Verb: api.KVCAS, Key: key, Value: data, Index: 0}, nil
+	}
+
+	pair, _, err := r.Client.KV().Get(key, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from Consul: %w", key, err)
+	}
+	if pair == nil {
+		return nil, fmt.Errorf("%w: %s %s/%s not found", ErrRevisionConflict, kind, op.Name(), op.Version())
+	}
+	var stored struct {
+		Metadata model.Metadata `json:"metadata"`
+	}
+	if err := json.Unmarshal(pair.Value, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	if stored.Metadata.Revision != op.ExpectedRevision {
+		return nil, fmt.Errorf("%w: %s %s/%s", ErrRevisionConflict, kind, op.Name(), op.Version())
+	}
+
+	if op.Verb == TxnDelete {
+		return &api.KVTxnOp{Verb: api.KVDeleteCAS, Key: key, Index: pair.ModifyIndex}, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize %s: %w", kind, err)
+	}
+	return &api.KVTxnOp{Verb: api.KVCAS, Key: key, Value: data, Index: pair.ModifyIndex}, nil
+}
+
+// ---------------------- WEBHOOKS ----------------------
+
+const WebhooksPrefix = "webhooks/"
+
+func (r *ConsulRepository) AddWebhook(ctx context.Context, hook model.Webhook) (err error) {
+	ctx, span := tracer.Start(ctx, "AddWebhook")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(attribute.String("webhook.id", hook.ID.String()))
+
+	data, err := json.Marshal(hook)
+	if err != nil {
+		return fmt.Errorf("failed to serialize webhook: %w", err)
+	}
+
+	p := &api.KVPair{Key: WebhooksPrefix + hook.ID.String(), Value: data}
+
+	writeOptions := (&api.WriteOptions{}).WithContext(ctx)
+
+	_, err = r.Client.KV().Put(p, writeOptions)
+	if err != nil {
+		return fmt.Errorf("failed to put webhook into Consul: %w", err)
+	}
+	return nil
+}
+
+func (r *ConsulRepository) GetWebhook(ctx context.Context, id uuid.UUID) (hook model.Webhook, err error) {
+	ctx, span := tracer.Start(ctx, "GetWebhook")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+
+	queryOptions := (&api.QueryOptions{}).WithContext(ctx)
+
+	pair, _, err := r.Client.KV().Get(WebhooksPrefix+id.String(), queryOptions)
+	if err != nil {
+		return model.Webhook{}, fmt.Errorf("failed to get webhook from Consul: %w", err)
+	}
+	if pair == nil {
+		return model.Webhook{}, errors.New("webhook not found")
+	}
+
+	if err := json.Unmarshal(pair.Value, &hook); err != nil {
+		return model.Webhook{}, fmt.Errorf("failed to decode webhook JSON: %w", err)
+	}
+	return hook, nil
+}
+
+func (r *ConsulRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracer.Start(ctx, "DeleteWebhook")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+
+	writeOptions := (&api.WriteOptions{}).WithContext(ctx)
+
+	_, err = r.Client.KV().Delete(WebhooksPrefix+id.String(), writeOptions)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook from Consul: %w", err)
+	}
+	return nil
+}
+
+func (r *ConsulRepository) ListWebhooks(ctx context.Context) (hooks []model.Webhook, err error) {
+	ctx, span := tracer.Start(ctx, "ListWebhooks")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	queryOptions := (&api.QueryOptions{}).WithContext(ctx)
+
+	pairs, _, err := r.Client.KV().List(WebhooksPrefix, queryOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks from Consul: %w", err)
+	}
+
+	hooks = make([]model.Webhook, 0, len(pairs))
+	for _, pair := range pairs {
+		var hook model.Webhook
+		if err := json.Unmarshal(pair.Value, &hook); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook JSON at %s: %w", pair.Key, err)
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
+
 // ---------------------- IDEMPOTENCY ----------------------
 
 const IdempotencyPrefix = "idempotency/"
@@ -309,3 +712,26 @@ func (r *ConsulRepository) SaveIdempotencyKey(ctx context.Context, key string) (
 	}
 	return nil
 }
+
+func (r *ConsulRepository) ListIdempotencyKeys(ctx context.Context) (keys []string, err error) {
+	ctx, span := tracer.Start(ctx, "ListIdempotencyKeys")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	queryOptions := (&api.QueryOptions{}).WithContext(ctx)
+
+	fullKeys, _, err := r.Client.KV().Keys(IdempotencyPrefix, "", queryOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list idempotency keys from Consul: %w", err)
+	}
+
+	keys = make([]string, 0, len(fullKeys))
+	for _, k := range fullKeys {
+		keys = append(keys, strings.TrimPrefix(k, IdempotencyPrefix))
+	}
+	return keys, nil
+}
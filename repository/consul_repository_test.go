@@ -2,18 +2,39 @@ package repository
 
 import (
 	"alati_projekat/model"
+	"alati_projekat/repository/consultest"
 	"context"
+	"errors"
+	"net/http"
+	"os"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-func TestConsulRepository_ConfigurationCRUD(t *testing.T) {
-	// Skip if Consul is not running (like in CI)
-	repo, err := NewConsulRepository("http://localhost:8500")
+// newTestConsulRepository connects to the agent at CONSUL_HTTP_ADDR and skips the test when
+// none answers. Without CONSUL_HTTP_ADDR the repository talks to an in-process fake agent.
+func newTestConsulRepository(t *testing.T) *ConsulRepository {
+	t.Helper()
+	addr := os.Getenv("CONSUL_HTTP_ADDR")
+	if addr == "" {
+		addr = consultest.NewServer(t).URL
+	}
+	repo, err := NewConsulRepository(addr)
 	if err != nil {
-		t.Skipf("Skipping test: Consul not available: %v", err)
+		t.Fatalf("NewConsulRepository failed: %v", err)
 	}
+	// Creating the client does not connect, so ask the agent for its leader first
+	if _, err := repo.Client.Status().Leader(); err != nil {
+		t.Skipf("Skipping test: no Consul agent at %s: %v", addr, err)
+	}
+	return repo
+}
+
+func TestConsulRepository_ConfigurationCRUD(t *testing.T) {
+	repo := newTestConsulRepository(t)
 
 	ctx := context.Background()
 
@@ -97,10 +118,7 @@ func TestConsulRepository_ConfigurationCRUD(t *testing.T) {
 }
 
 func TestConsulRepository_ConfigurationGroupCRUD(t *testing.T) {
-	repo, err := NewConsulRepository("http://localhost:8500")
-	if err != nil {
-		t.Skipf("Skipping test: Consul not available: %v", err)
-	}
+	repo := newTestConsulRepository(t)
 
 	ctx := context.Background()
 
@@ -206,10 +224,7 @@ func TestConsulRepository_ConfigurationGroupCRUD(t *testing.T) {
 }
 
 func TestConsulRepository_Idempotency(t *testing.T) {
-	repo, err := NewConsulRepository("http://localhost:8500")
-	if err != nil {
-		t.Skipf("Skipping test: Consul not available: %v", err)
-	}
+	repo := newTestConsulRepository(t)
 
 	ctx := context.Background()
 	testKey := "test-idempotency-" + uuid.New().String()
@@ -239,19 +254,26 @@ func TestConsulRepository_Idempotency(t *testing.T) {
 		}
 	})
 
+	t.Run("ListKeys", func(t *testing.T) {
+		keys, err := repo.ListIdempotencyKeys(ctx)
+		if err != nil {
+			t.Fatalf("ListIdempotencyKeys failed: %v", err)
+		}
+		if !slices.Contains(keys, testKey) {
+			t.Errorf("Expected %s in listed keys, got %d keys without it", testKey, len(keys))
+		}
+	})
+
 	// Cleanup
 	_ = repo.SaveIdempotencyKey(ctx, testKey) // This will overwrite, but that's fine for test cleanup
 }
 
 func TestConsulRepository_GetNonExistentConfiguration(t *testing.T) {
-	repo, err := NewConsulRepository("http://localhost:8500")
-	if err != nil {
-		t.Skipf("Skipping test: Consul not available: %v", err)
-	}
+	repo := newTestConsulRepository(t)
 
 	ctx := context.Background()
 
-	_, err = repo.GetConfiguration(ctx, "non-existent-config", "v999.0.0")
+	_, err := repo.GetConfiguration(ctx, "non-existent-config", "v999.0.0")
 	if err == nil {
 		t.Error("Expected error for non-existent configuration")
 	}
@@ -261,14 +283,11 @@ func TestConsulRepository_GetNonExistentConfiguration(t *testing.T) {
 }
 
 func TestConsulRepository_GetNonExistentConfigurationGroup(t *testing.T) {
-	repo, err := NewConsulRepository("http://localhost:8500")
-	if err != nil {
-		t.Skipf("Skipping test: Consul not available: %v", err)
-	}
+	repo := newTestConsulRepository(t)
 
 	ctx := context.Background()
 
-	_, err = repo.GetConfigurationGroup(ctx, "non-existent-group", "v999.0.0")
+	_, err := repo.GetConfigurationGroup(ctx, "non-existent-group", "v999.0.0")
 	if err == nil {
 		t.Error("Expected error for non-existent configuration group")
 	}
@@ -277,6 +296,226 @@ func TestConsulRepository_GetNonExistentConfigurationGroup(t *testing.T) {
 	}
 }
 
+func TestConsulRepository_CompareAndSwapConfiguration(t *testing.T) {
+	repo := newTestConsulRepository(t)
+
+	ctx := context.Background()
+	config := model.Configuration{
+		ID:       uuid.New(),
+		Name:     "test-cas-" + uuid.New().String()[:8],
+		Version:  "v1.0.0",
+		Metadata: model.Metadata{Revision: 1},
+	}
+	if err := repo.AddConfiguration(ctx, config); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	defer repo.DeleteConfiguration(ctx, config.Name, config.Version)
+
+	config.Metadata.Revision = 2
+	if err := repo.CompareAndSwapConfiguration(ctx, config, 1); err != nil {
+		t.Fatalf("CompareAndSwapConfiguration with current revision failed: %v", err)
+	}
+	config.Metadata.Revision = 3
+	if err := repo.CompareAndSwapConfiguration(ctx, config, 1); !errors.Is(err, ErrRevisionConflict) {
+		t.Errorf("Expected ErrRevisionConflict for stale revision, got: %v", err)
+	}
+
+	config.Name = "test-cas-missing"
+	if err := repo.CompareAndSwapConfiguration(ctx, config, 0); err == nil || !contains(err.Error(), "not found") {
+		t.Errorf("Expected 'not found' error, got: %v", err)
+	}
+}
+
+func TestConsulRepository_Transact(t *testing.T) {
+	repo := newTestConsulRepository(t)
+
+	ctx := context.Background()
+	suffix := uuid.New().String()[:8]
+	existing := model.Configuration{Name: "test-txn-existing-" + suffix, Version: "v1", Metadata: model.Metadata{Revision: 1}}
+	if err := repo.AddConfiguration(ctx, existing); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	defer repo.DeleteConfiguration(ctx, existing.Name, existing.Version)
+
+	created := model.Configuration{Name: "test-txn-new-" + suffix, Version: "v1", Metadata: model.Metadata{Revision: 1}}
+	group := model.ConfigurationGroup{Name: "test-txn-group-" + suffix, Version: "v1", Metadata: model.Metadata{Revision: 1}}
+	defer repo.DeleteConfiguration(ctx, created.Name, created.Version)
+	defer repo.DeleteConfigurationGroup(ctx, group.Name, group.Version)
+
+	// Zastarela revizija ponistava celu transakciju
+	updated := existing
+	updated.Metadata.Revision = 2
+	err := repo.Transact(ctx, []TxnOp{
+		{Verb: TxnCreate, Configuration: &created},
+		{Verb: TxnUpdate, Configuration: &updated, ExpectedRevision: 5},
+	})
+	if !errors.Is(err, ErrRevisionConflict) {
+		t.Fatalf("Expected ErrRevisionConflict, got: %v", err)
+	}
+	if _, err := repo.GetConfiguration(ctx, created.Name, created.Version); err == nil {
+		t.Fatal("Create of a failed transaction must not be stored")
+	}
+
+	err = repo.Transact(ctx, []TxnOp{
+		{Verb: TxnCreate, Configuration: &created},
+		{Verb: TxnCreate, Group: &group},
+		{Verb: TxnUpdate, Configuration: &updated, ExpectedRevision: 1},
+	})
+	if err != nil {
+		t.Fatalf("Transact failed: %v", err)
+	}
+	stored, err := repo.GetConfiguration(ctx, existing.Name, existing.Version)
+	if err != nil || stored.Metadata.Revision != 2 {
+		t.Errorf("Expected updated configuration with revision 2, got %+v (%v)", stored, err)
+	}
+
+	// Ponovno kreiranje postojeceg kljuca je konflikt
+	if err := repo.Transact(ctx, []TxnOp{{Verb: TxnCreate, Group: &group}}); !errors.Is(err, ErrRevisionConflict) {
+		t.Errorf("Expected ErrRevisionConflict for existing key, got: %v", err)
+	}
+	if err := repo.Transact(ctx, []TxnOp{{Verb: TxnDelete, Configuration: &created, ExpectedRevision: 1}}); err != nil {
+		t.Errorf("Transact delete failed: %v", err)
+	}
+	if _, err := repo.GetConfiguration(ctx, created.Name, created.Version); err == nil {
+		t.Error("Deleted configuration still exists")
+	}
+}
+
+func TestConsulRepository_WatchConfiguration(t *testing.T) {
+	repo := newTestConsulRepository(t)
+
+	ctx := context.Background()
+	config := model.Configuration{Name: "test-watch-" + uuid.New().String()[:8], Version: "v1", Metadata: model.Metadata{Revision: 1}}
+	if err := repo.AddConfiguration(ctx, config); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	defer repo.DeleteConfiguration(ctx, config.Name, config.Version)
+
+	_, index, err := repo.WatchConfiguration(ctx, config.Name, config.Version, 0, time.Second)
+	if err != nil || index == 0 {
+		t.Fatalf("Initial watch failed: index %d, err %v", index, err)
+	}
+
+	// Bez promene blokirajući upit traje do isteka wait
+	start := time.Now()
+	if _, next, err := repo.WatchConfiguration(ctx, config.Name, config.Version, index, time.Second); err != nil || next != index {
+		t.Errorf("Expected unchanged index %d, got %d (%v)", index, next, err)
+	}
+	if time.Since(start) < 500*time.Millisecond {
+		t.Error("Watch returned before wait elapsed without a change")
+	}
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		config.Metadata.Revision = 2
+		repo.UpdateConfiguration(ctx, config)
+	}()
+	changed, next, err := repo.WatchConfiguration(ctx, config.Name, config.Version, index, 10*time.Second)
+	if err != nil || next <= index || changed.Metadata.Revision != 2 {
+		t.Errorf("Expected revision 2 at an index after %d, got %d %+v (%v)", index, next, changed.Metadata, err)
+	}
+}
+
+func TestConsulRepository_Webhooks(t *testing.T) {
+	repo := newTestConsulRepository(t)
+
+	ctx := context.Background()
+	hook := model.Webhook{
+		ID:        uuid.New(),
+		URL:       "http://localhost:9000/hook",
+		Events:    []model.EventType{model.EventUpdated},
+		Secret:    "test-secret",
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := repo.AddWebhook(ctx, hook); err != nil {
+		t.Fatalf("AddWebhook failed: %v", err)
+	}
+
+	stored, err := repo.GetWebhook(ctx, hook.ID)
+	if err != nil || stored.URL != hook.URL || stored.Secret != hook.Secret {
+		t.Errorf("Expected the stored webhook with its secret, got %+v (%v)", stored, err)
+	}
+	hooks, err := repo.ListWebhooks(ctx)
+	found := false
+	for _, h := range hooks {
+		found = found || h.ID == hook.ID
+	}
+	if err != nil || !found {
+		t.Errorf("Expected the webhook in the list, got %d webhooks (%v)", len(hooks), err)
+	}
+
+	if err := repo.DeleteWebhook(ctx, hook.ID); err != nil {
+		t.Fatalf("DeleteWebhook failed: %v", err)
+	}
+	if _, err := repo.GetWebhook(ctx, hook.ID); err == nil || !contains(err.Error(), "not found") {
+		t.Errorf("Expected 'not found' after delete, got: %v", err)
+	}
+}
+
+// Greske Consul-a se ne smeju prijaviti kao "not found" ni kao sukob revizija
+func TestConsulRepository_InjectedFailures(t *testing.T) {
+	srv := consultest.NewServer(t)
+	repo, err := NewConsulRepository(srv.URL)
+	if err != nil {
+		t.Fatalf("NewConsulRepository failed: %v", err)
+	}
+	ctx := context.Background()
+
+	config := model.Configuration{Name: "service-api", Version: "v1", Metadata: model.Metadata{Revision: 1}}
+	if err := repo.AddConfiguration(ctx, config); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+
+	t.Run("ReadFailure", func(t *testing.T) {
+		srv.InjectFault(consultest.Fault{Method: http.MethodGet, Path: "/v1/kv/", Times: 1})
+		_, err := repo.GetConfiguration(ctx, "service-api", "v1")
+		if err == nil || contains(err.Error(), "not found") {
+			t.Errorf("Expected a read error other than 'not found', got %v", err)
+		}
+	})
+
+	t.Run("WriteFailure", func(t *testing.T) {
+		srv.InjectFault(consultest.Fault{Method: http.MethodPut, Path: "/v1/kv/", Status: http.StatusServiceUnavailable, Times: 1})
+		updated := config
+		updated.Metadata.Revision = 2
+		if err := repo.CompareAndSwapConfiguration(ctx, updated, 1); err == nil || errors.Is(err, ErrRevisionConflict) {
+			t.Errorf("Expected a write error other than a conflict, got %v", err)
+		}
+		if stored, _ := repo.GetConfiguration(ctx, "service-api", "v1"); stored.Metadata.Revision != 1 {
+			t.Errorf("Failed write changed the revision to %d", stored.Metadata.Revision)
+		}
+	})
+
+	t.Run("TxnFailure", func(t *testing.T) {
+		srv.InjectFault(consultest.Fault{Path: "/v1/txn", Times: 1})
+		created := model.Configuration{Name: "service-web", Version: "v1"}
+		err := repo.Transact(ctx, []TxnOp{{Verb: TxnCreate, Configuration: &created}})
+		if err == nil || errors.Is(err, ErrRevisionConflict) {
+			t.Errorf("Expected a transaction error other than a conflict, got %v", err)
+		}
+	})
+
+	t.Run("CorruptRecord", func(t *testing.T) {
+		srv.Put(ConfigsPrefix+"broken/v1", []byte("{not json"))
+		if _, err := repo.GetConfiguration(ctx, "broken", "v1"); err == nil || contains(err.Error(), "not found") {
+			t.Errorf("Expected a decode error, got %v", err)
+		}
+		if _, err := repo.ListConfigurations(ctx, "broken"); err == nil {
+			t.Error("Expected listing a corrupt record to fail")
+		}
+	})
+
+	t.Run("Latency", func(t *testing.T) {
+		srv.SetLatency(200 * time.Millisecond)
+		defer srv.SetLatency(0)
+		timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		if _, err := repo.GetConfiguration(timeout, "service-api", "v1"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected the read to time out, got %v", err)
+		}
+	})
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[0:len(substr)] == substr || contains(s[1:], substr)))
@@ -0,0 +1,547 @@
+// Package consultest serves the part of the Consul HTTP API the Consul repository uses, from
+// memory on an httptest.Server, so it can be tested without an agent:
+//
+//	srv := consultest.NewServer(t)
+//	repo, _ := repository.NewConsulRepository(srv.URL)
+//
+// The server supports KV reads of single keys, recursive listings and key listings, blocking
+// queries with index and wait, writes and deletes with check-and-set, the KV operations of
+// /v1/txn and /v1/status/leader. Indexes behave like Consul's: every write advances one
+// global index and a blocking query returns once a key it reads is modified after the given
+// index. Latency and failures can be injected to exercise error handling.
+package consultest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// MaxTxnOps is the number of operations Consul accepts in a single transaction.
+const MaxTxnOps = 64
+
+// Blocking query limits of Consul: the default and the longest wait.
+const (
+	defaultWait = 5 * time.Minute
+	maxWait     = 10 * time.Minute
+)
+
+// Fault makes matching requests fail.
+type Fault struct {
+	// Method and Path select the requests; an empty Method matches every method and Path
+	// is a prefix of the request path, such as "/v1/txn" or "/v1/kv/configurations/".
+	Method string
+	Path   string
+	// Status is returned instead of handling the request; zero selects 500.
+	Status int
+	// Times is the number of requests that fail; zero fails them until ClearFaults.
+	Times int
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	return (f.Method == "" || f.Method == r.Method) && strings.HasPrefix(r.URL.Path, f.Path)
+}
+
+// entry is a stored key; a deleted key keeps its entry as a tombstone, so blocking
+// queries on it notice the delete.
+type entry struct {
+	value       []byte
+	flags       uint64
+	createIndex uint64
+	modifyIndex uint64
+	deleted     bool
+}
+
+// Server is a fake Consul agent. It is safe for concurrent use.
+type Server struct {
+	// URL is the address to pass to repository.NewConsulRepository.
+	URL string
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	index    uint64
+	kv       map[string]*entry
+	changed  chan struct{} // closed and replaced on every write, to wake blocking queries
+	latency  time.Duration
+	faults   []*Fault
+	requests int
+}
+
+// NewServer starts a fake Consul agent with an empty KV store, which is shut down when the
+// test ends.
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		index:   1, // like an agent, whose Raft index is never zero
+		kv:      make(map[string]*entry),
+		changed: make(chan struct{}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/kv/", s.handleKV)
+	mux.HandleFunc("/v1/txn", s.handleTxn)
+	mux.HandleFunc("/v1/status/leader", s.handleLeader)
+	s.srv = httptest.NewServer(s.intercept(mux))
+	s.URL = s.srv.URL
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Close shuts the server down; blocking queries in flight return at once.
+func (s *Server) Close() {
+	s.srv.CloseClientConnections()
+	s.srv.Close()
+}
+
+// SetLatency delays every following request by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// InjectFault makes the requests matching f fail. Faults are checked in the order they were
+// injected.
+func (s *Server) InjectFault(f Fault) {
+	if f.Status == 0 {
+		f.Status = http.StatusInternalServerError
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the number of requests the server received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Put stores value at key directly, for example to seed records the repository cannot
+// write itself.
+func (s *Server) Put(key string, value []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(key, value, 0)
+}
+
+// Get returns the value stored at key.
+func (s *Server) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.kv[key]
+	if !ok || e.deleted {
+		return nil, false
+	}
+	return append([]byte(nil), e.value...), true
+}
+
+// Index returns the index of the last write.
+func (s *Server) Index() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.index
+}
+
+// intercept counts requests, applies the injected latency and fails requests matching a fault.
+func (s *Server) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		latency := s.latency
+		var fault *Fault
+		for i, f := range s.faults {
+			if !f.matches(r) {
+				continue
+			}
+			fault = f
+			if f.Times > 0 {
+				if f.Times--; f.Times == 0 {
+					s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+				}
+			}
+			break
+		}
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault != nil {
+			http.Error(w, fmt.Sprintf("injected fault for %s %s", r.Method, r.URL.Path), fault.Status)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleLeader(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, "127.0.0.1:8300")
+}
+
+// ---------------------- KV ----------------------
+
+func (s *Server) handleKV(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+	switch r.Method {
+	case http.MethodGet:
+		s.handleGet(w, r, key)
+	case http.MethodPut:
+		s.handlePut(w, r, key)
+	case http.MethodDelete:
+		s.handleDelete(w, r, key)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request, key string) {
+	query := r.URL.Query()
+	_, recurse := query["recurse"]
+	_, keysOnly := query["keys"]
+	prefix := recurse || keysOnly
+
+	waitIndex, err := parseUint(query.Get("index"))
+	if err != nil {
+		http.Error(w, "invalid index: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	wait := defaultWait
+	if query.Get("wait") != "" {
+		if wait, err = time.ParseDuration(query.Get("wait")); err != nil {
+			http.Error(w, "invalid wait: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		wait = min(wait, maxWait)
+	}
+
+	s.mu.Lock()
+	index := s.queryIndex(key, prefix)
+	if waitIndex > 0 && index <= waitIndex {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		for index <= waitIndex {
+			changed := s.changed
+			s.mu.Unlock()
+			select {
+			case <-changed:
+			case <-timer.C:
+				waitIndex = 0 // wait elapsed: answer with the current state
+			case <-r.Context().Done():
+				return
+			}
+			s.mu.Lock()
+			index = s.queryIndex(key, prefix)
+		}
+	}
+	pairs := s.read(key, prefix)
+	s.mu.Unlock()
+
+	setQueryMeta(w, index)
+	if len(pairs) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if keysOnly {
+		writeJSON(w, http.StatusOK, listKeys(pairs, key, query.Get("separator")))
+		return
+	}
+	writeJSON(w, http.StatusOK, pairs)
+}
+
+// queryIndex returns the index of a read of key, or of every key under it with prefix:
+// the last modification of a matching key, or of the store when none ever existed.
+// The caller holds s.mu.
+func (s *Server) queryIndex(key string, prefix bool) uint64 {
+	var index uint64
+	for k, e := range s.kv {
+		if (k == key || prefix && strings.HasPrefix(k, key)) && e.modifyIndex > index {
+			index = e.modifyIndex
+		}
+	}
+	if index == 0 {
+		index = s.index
+	}
+	return max(index, 1)
+}
+
+// read returns the live pairs of key, or of every key under it with prefix, ordered by key.
+// The caller holds s.mu.
+func (s *Server) read(key string, prefix bool) []*api.KVPair {
+	var pairs []*api.KVPair
+	for k, e := range s.kv {
+		if e.deleted || !(k == key || prefix && strings.HasPrefix(k, key)) {
+			continue
+		}
+		pairs = append(pairs, &api.KVPair{
+			Key:         k,
+			Flags:       e.flags,
+			Value:       append([]byte(nil), e.value...),
+			CreateIndex: e.createIndex,
+			ModifyIndex: e.modifyIndex,
+		})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+	return pairs
+}
+
+// listKeys returns the keys of pairs; with a separator, keys are cut after the first
+// separator following prefix and duplicates are dropped, like Consul's ?keys&separator.
+func listKeys(pairs []*api.KVPair, prefix, separator string) []string {
+	keys := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		k := p.Key
+		if separator != "" {
+			if i := strings.Index(k[len(prefix):], separator); i >= 0 {
+				k = k[:len(prefix)+i+len(separator)]
+			}
+		}
+		if len(keys) == 0 || keys[len(keys)-1] != k {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (s *Server) handlePut(w http.ResponseWriter, r *http.Request, key string) {
+	query := r.URL.Query()
+	flags, err := parseUint(query.Get("flags"))
+	if err != nil {
+		http.Error(w, "invalid flags: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	value, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if query.Has("cas") {
+		cas, err := parseUint(query.Get("cas"))
+		if err != nil {
+			http.Error(w, "invalid cas: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !s.casMatches(key, cas) {
+			writeJSON(w, http.StatusOK, false)
+			return
+		}
+	}
+	s.set(key, value, flags)
+	writeJSON(w, http.StatusOK, true)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request, key string) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if query.Has("recurse") {
+		s.deleteTree(key)
+		writeJSON(w, http.StatusOK, true)
+		return
+	}
+	if query.Has("cas") {
+		cas, err := parseUint(query.Get("cas"))
+		if err != nil {
+			http.Error(w, "invalid cas: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !s.casMatches(key, cas) {
+			writeJSON(w, http.StatusOK, false)
+			return
+		}
+	}
+	s.delete(key)
+	writeJSON(w, http.StatusOK, true)
+}
+
+// casMatches reports whether a check-and-set on key with index succeeds: index 0 requires
+// the key to be missing, any other index its last modification. The caller holds s.mu.
+func (s *Server) casMatches(key string, index uint64) bool {
+	e, ok := s.kv[key]
+	if !ok || e.deleted {
+		return index == 0
+	}
+	return e.modifyIndex == index
+}
+
+// The write helpers advance the index and wake blocking queries. The caller holds s.mu.
+
+func (s *Server) set(key string, value []byte, flags uint64) {
+	s.index++
+	e, ok := s.kv[key]
+	if !ok || e.deleted {
+		e = &entry{createIndex: s.index}
+		s.kv[key] = e
+	}
+	e.value, e.flags, e.modifyIndex = append([]byte(nil), value...), flags, s.index
+	s.notify()
+}
+
+func (s *Server) delete(key string) {
+	if e, ok := s.kv[key]; ok && !e.deleted {
+		s.index++
+		*e = entry{modifyIndex: s.index, deleted: true}
+		s.notify()
+	}
+}
+
+func (s *Server) deleteTree(prefix string) {
+	for k := range s.kv {
+		if strings.HasPrefix(k, prefix) {
+			s.delete(k)
+		}
+	}
+}
+
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// ---------------------- TRANSACTIONS ----------------------
+
+// handleTxn applies the KV operations of a transaction atomically: every check is made
+// against the state before the transaction and nothing is written when one fails.
+func (s *Server) handleTxn(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var ops api.TxnOps
+	if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+		http.Error(w, "failed to decode transaction: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(ops) > MaxTxnOps {
+		http.Error(w, fmt.Sprintf("Transaction contains too many operations (%d > %d)", len(ops), MaxTxnOps), http.StatusRequestEntityTooLarge)
+		return
+	}
+	for i, op := range ops {
+		if op.KV == nil {
+			http.Error(w, fmt.Sprintf("operation %d: only KV operations are supported", i), http.StatusBadRequest)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs api.TxnErrors
+	for i, op := range ops {
+		if what := s.checkTxnOp(op.KV); what != "" {
+			errs = append(errs, &api.TxnError{OpIndex: i, What: what})
+		}
+	}
+	if len(errs) > 0 {
+		setQueryMeta(w, s.index)
+		writeJSON(w, http.StatusConflict, api.TxnResponse{Errors: errs})
+		return
+	}
+
+	results := make(api.TxnResults, 0, len(ops))
+	for _, op := range ops {
+		kv := op.KV
+		switch kv.Verb {
+		case api.KVSet, api.KVCAS:
+			s.set(kv.Key, kv.Value, kv.Flags)
+		case api.KVDelete, api.KVDeleteCAS:
+			s.delete(kv.Key)
+			continue
+		case api.KVDeleteTree:
+			s.deleteTree(kv.Key)
+			continue
+		case api.KVCheckNotExists:
+			continue
+		}
+		if pairs := s.read(kv.Key, false); len(pairs) > 0 {
+			pair := pairs[0]
+			if kv.Verb != api.KVGet {
+				pair.Value = nil // Consul only returns values of reads
+			}
+			results = append(results, &api.TxnResult{KV: pair})
+		}
+	}
+	setQueryMeta(w, s.index)
+	writeJSON(w, http.StatusOK, api.TxnResponse{Results: results})
+}
+
+// checkTxnOp returns why op fails against the current state, or "" if it succeeds.
+// The caller holds s.mu.
+func (s *Server) checkTxnOp(op *api.KVTxnOp) string {
+	e, ok := s.kv[op.Key]
+	exists := ok && !e.deleted
+	switch op.Verb {
+	case api.KVSet, api.KVDelete, api.KVDeleteTree:
+		return ""
+	case api.KVCAS:
+		if !s.casMatches(op.Key, op.Index) {
+			return fmt.Sprintf("failed to set key %q, index is stale", op.Key)
+		}
+	case api.KVDeleteCAS:
+		if !s.casMatches(op.Key, op.Index) {
+			return fmt.Sprintf("failed to delete key %q, index is stale", op.Key)
+		}
+	case api.KVGet:
+		if !exists {
+			return fmt.Sprintf("key %q doesn't exist", op.Key)
+		}
+	case api.KVCheckIndex:
+		if !exists || e.modifyIndex != op.Index {
+			return fmt.Sprintf("key %q was not modified at index %d", op.Key, op.Index)
+		}
+	case api.KVCheckNotExists:
+		if exists {
+			return fmt.Sprintf("key %q exists", op.Key)
+		}
+	default:
+		return fmt.Sprintf("unsupported KV verb %q", op.Verb)
+	}
+	return ""
+}
+
+// ---------------------- HELPERS ----------------------
+
+// setQueryMeta sets the headers the Consul client parses from every read.
+func setQueryMeta(w http.ResponseWriter, index uint64) {
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(max(index, 1), 10))
+	w.Header().Set("X-Consul-LastContact", "0")
+	w.Header().Set("X-Consul-KnownLeader", "true")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func parseUint(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
@@ -0,0 +1,214 @@
+package consultest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+)
+
+func newClient(t *testing.T, srv *Server) *api.Client {
+	t.Helper()
+	config := api.DefaultConfig()
+	config.Address = srv.URL
+	client, err := api.NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return client
+}
+
+func TestServer_KV(t *testing.T) {
+	srv := NewServer(t)
+	kv := newClient(t, srv).KV()
+
+	if pair, meta, err := kv.Get("a/1", nil); err != nil || pair != nil || meta.LastIndex == 0 {
+		t.Fatalf("Expected a missing key with an index, got %+v %+v (%v)", pair, meta, err)
+	}
+	for _, key := range []string{"a/2", "a/1", "ab/1", "b/1"} {
+		if _, err := kv.Put(&api.KVPair{Key: key, Value: []byte(key)}, nil); err != nil {
+			t.Fatalf("Put %s failed: %v", key, err)
+		}
+	}
+
+	pair, _, err := kv.Get("a/1", nil)
+	if err != nil || pair == nil || string(pair.Value) != "a/1" || pair.CreateIndex != 3 || pair.ModifyIndex != 3 {
+		t.Fatalf("Expected a/1 written at index 3, got %+v (%v)", pair, err)
+	}
+
+	pairs, _, err := kv.List("a/", nil)
+	if err != nil || len(pairs) != 2 || pairs[0].Key != "a/1" || pairs[1].Key != "a/2" {
+		t.Errorf("Expected a/1 and a/2 in key order, got %+v (%v)", pairs, err)
+	}
+	keys, _, err := kv.Keys("a", "/", nil)
+	if err != nil || !slices.Equal(keys, []string{"a/", "ab/"}) {
+		t.Errorf("Expected keys a/ and ab/, got %v (%v)", keys, err)
+	}
+	if pairs, _, err := kv.List("missing/", nil); err != nil || pairs != nil {
+		t.Errorf("Expected no pairs under a missing prefix, got %+v (%v)", pairs, err)
+	}
+
+	// Check-and-set na zastarelom indeksu ne upisuje
+	if ok, _, err := kv.CAS(&api.KVPair{Key: "a/1", Value: []byte("x"), ModifyIndex: 2}, nil); err != nil || ok {
+		t.Errorf("Expected a stale CAS to fail, got %v (%v)", ok, err)
+	}
+	if ok, _, err := kv.CAS(&api.KVPair{Key: "a/1", Value: []byte("x"), ModifyIndex: pair.ModifyIndex}, nil); err != nil || !ok {
+		t.Errorf("Expected a CAS on the current index to succeed, got %v (%v)", ok, err)
+	}
+	if ok, _, err := kv.CAS(&api.KVPair{Key: "c/1", Value: []byte("x")}, nil); err != nil || !ok {
+		t.Errorf("Expected a CAS on index 0 to create the key, got %v (%v)", ok, err)
+	}
+	if ok, _, err := kv.DeleteCAS(&api.KVPair{Key: "c/1", ModifyIndex: 1}, nil); err != nil || ok {
+		t.Errorf("Expected a stale delete CAS to fail, got %v (%v)", ok, err)
+	}
+
+	if _, err := kv.Delete("a/1", nil); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if value, ok := srv.Get("a/1"); ok {
+		t.Errorf("Expected a/1 to be deleted, got %q", value)
+	}
+	if _, err := kv.DeleteTree("a", nil); err != nil {
+		t.Fatalf("DeleteTree failed: %v", err)
+	}
+	if keys, _, _ := kv.Keys("", "", nil); !slices.Equal(keys, []string{"b/1", "c/1"}) {
+		t.Errorf("Expected b/1 and c/1 to remain, got %v", keys)
+	}
+}
+
+func TestServer_BlockingQuery(t *testing.T) {
+	srv := NewServer(t)
+	kv := newClient(t, srv).KV()
+	srv.Put("watched", []byte("v1"))
+	srv.Put("other", []byte("v1"))
+
+	_, meta, err := kv.Get("watched", nil)
+	if err != nil || meta.LastIndex != 2 {
+		t.Fatalf("Expected index 2, got %+v (%v)", meta, err)
+	}
+
+	// Upis drugog kljuca ne budi upit
+	start := time.Now()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		srv.Put("other", []byte("v2"))
+	}()
+	_, meta, err = kv.Get("watched", &api.QueryOptions{WaitIndex: 2, WaitTime: 150 * time.Millisecond})
+	if err != nil || meta.LastIndex != 2 {
+		t.Errorf("Expected unchanged index 2, got %+v (%v)", meta, err)
+	}
+	if time.Since(start) < 150*time.Millisecond {
+		t.Error("Blocking query returned before the wait without a change")
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		srv.Put("watched", []byte("v2"))
+	}()
+	pair, meta, err := kv.Get("watched", &api.QueryOptions{WaitIndex: 2, WaitTime: time.Minute})
+	if err != nil || pair == nil || string(pair.Value) != "v2" || meta.LastIndex != 5 {
+		t.Errorf("Expected v2 at index 5, got %+v %+v (%v)", pair, meta, err)
+	}
+
+	// Brisanje budi upit i vraca nepostojeci kljuc
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		kv.Delete("watched", nil)
+	}()
+	pair, meta, err = kv.Get("watched", &api.QueryOptions{WaitIndex: 5, WaitTime: time.Minute})
+	if err != nil || pair != nil || meta.LastIndex != 6 {
+		t.Errorf("Expected the deleted key at index 6, got %+v %+v (%v)", pair, meta, err)
+	}
+}
+
+func TestServer_Txn(t *testing.T) {
+	srv := NewServer(t)
+	client := newClient(t, srv)
+	srv.Put("existing", []byte("v1"))
+
+	ok, resp, _, err := client.Txn().Txn(api.TxnOps{
+		{KV: &api.KVTxnOp{Verb: api.KVCAS, Key: "created", Value: []byte("new")}},
+		{KV: &api.KVTxnOp{Verb: api.KVCAS, Key: "existing", Value: []byte("v2"), Index: 7}},
+		{KV: &api.KVTxnOp{Verb: api.KVCheckNotExists, Key: "existing"}},
+	}, nil)
+	if err != nil || ok {
+		t.Fatalf("Expected the transaction to be rolled back, got %v (%v)", ok, err)
+	}
+	if len(resp.Errors) != 2 || resp.Errors[0].OpIndex != 1 || resp.Errors[1].OpIndex != 2 {
+		t.Errorf("Expected errors for operations 1 and 2, got %+v", resp.Errors)
+	}
+	if _, found := srv.Get("created"); found {
+		t.Error("Rolled back transaction must not write")
+	}
+
+	ok, resp, _, err = client.Txn().Txn(api.TxnOps{
+		{KV: &api.KVTxnOp{Verb: api.KVCAS, Key: "created", Value: []byte("new")}},
+		{KV: &api.KVTxnOp{Verb: api.KVCAS, Key: "existing", Value: []byte("v2"), Index: 2}},
+		{KV: &api.KVTxnOp{Verb: api.KVGet, Key: "existing"}},
+	}, nil)
+	if err != nil || !ok {
+		t.Fatalf("Expected the transaction to commit, got %v %+v (%v)", ok, resp, err)
+	}
+	if len(resp.Results) != 3 || string(resp.Results[2].KV.Value) != "v2" || resp.Results[2].KV.ModifyIndex != 4 {
+		t.Errorf("Expected the read of existing at index 4, got %+v", resp.Results)
+	}
+
+	ok, _, _, err = client.Txn().Txn(api.TxnOps{
+		{KV: &api.KVTxnOp{Verb: api.KVDeleteCAS, Key: "existing", Index: 4}},
+	}, nil)
+	if err != nil || !ok {
+		t.Fatalf("Expected the delete to commit, got %v (%v)", ok, err)
+	}
+	if _, found := srv.Get("existing"); found {
+		t.Error("Expected existing to be deleted")
+	}
+
+	ops := make(api.TxnOps, MaxTxnOps+1)
+	for i := range ops {
+		ops[i] = &api.TxnOp{KV: &api.KVTxnOp{Verb: api.KVSet, Key: "k"}}
+	}
+	if _, _, _, err := client.Txn().Txn(ops, nil); err == nil {
+		t.Error("Expected a transaction with too many operations to fail")
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	srv := NewServer(t)
+	kv := newClient(t, srv).KV()
+
+	srv.InjectFault(Fault{Method: http.MethodPut, Path: "/v1/kv/", Times: 2})
+	for range 2 {
+		if _, err := kv.Put(&api.KVPair{Key: "k", Value: []byte("v")}, nil); err == nil {
+			t.Error("Expected an injected failure")
+		}
+	}
+	if _, err := kv.Put(&api.KVPair{Key: "k", Value: []byte("v")}, nil); err != nil {
+		t.Errorf("Expected the fault to be used up, got %v", err)
+	}
+
+	srv.InjectFault(Fault{Path: "/v1/kv/k", Status: http.StatusServiceUnavailable})
+	for range 3 {
+		if _, _, err := kv.Get("k", nil); err == nil {
+			t.Error("Expected an injected failure until the faults are cleared")
+		}
+	}
+	srv.ClearFaults()
+	if pair, _, err := kv.Get("k", nil); err != nil || pair == nil {
+		t.Errorf("Expected the key after clearing faults, got %+v (%v)", pair, err)
+	}
+
+	srv.SetLatency(200 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err := kv.Get("k", (&api.QueryOptions{}).WithContext(ctx))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request to time out, got %v", err)
+	}
+	if srv.Requests() != 8 {
+		t.Errorf("Expected 8 requests, got %d", srv.Requests())
+	}
+}
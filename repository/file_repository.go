@@ -0,0 +1,550 @@
+package repository
+
+import (
+	"alati_projekat/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SyncMode selects when a FileRepository flushes its write-ahead log to disk.
+type SyncMode string
+
+const (
+	// SyncAlways flushes after every write, so a write that returned survives a crash.
+	SyncAlways SyncMode = "always"
+	// SyncInterval flushes every FileOptions.SyncInterval; a crash loses at most that much.
+	SyncInterval SyncMode = "interval"
+	// SyncNever leaves flushing to the operating system.
+	SyncNever SyncMode = "never"
+)
+
+// Defaults of FileOptions.
+const (
+	DefaultSyncInterval = time.Second
+	DefaultCompactAfter = 10000
+)
+
+// FileOptions configures a FileRepository; zero values select the defaults.
+type FileOptions struct {
+	// Sync defaults to SyncAlways.
+	Sync         SyncMode
+	SyncInterval time.Duration
+	// CompactAfter is the number of WAL records after which the state is compacted into
+	// a new snapshot and the WAL is emptied.
+	CompactAfter int
+}
+
+// ParseSyncMode returns the sync mode named by s; an empty s selects SyncAlways.
+func ParseSyncMode(s string) (SyncMode, error) {
+	switch mode := SyncMode(strings.ToLower(s)); mode {
+	case "":
+		return SyncAlways, nil
+	case SyncAlways, SyncInterval, SyncNever:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown sync mode %q, expected always, interval or never", s)
+}
+
+// FileRepository stores records in a local data directory, for single-node deployments
+// without Consul. Records are kept in memory under the same keys as in Consul; every write
+// is appended to a write-ahead log first, and the log is compacted into a snapshot once it
+// grows past FileOptions.CompactAfter records and when the repository is closed. On startup
+// the snapshot is loaded and the log replayed; a record torn by a crash is dropped.
+//
+// Writes behave like their Consul counterparts: adds and updates overwrite, deletes of
+// missing records succeed. Watch indexes are kept in memory and start over after a restart,
+// which makes a watcher holding an older index return at once.
+type FileRepository struct {
+	dir  string
+	opts FileOptions
+
+	mu      sync.RWMutex
+	data    map[string]json.RawMessage
+	seq     uint64 // sequence number of the last WAL record
+	wal     *os.File
+	walSize int64
+	records int  // WAL records since the last compaction
+	dirty   bool // WAL written since the last sync, for SyncInterval
+	closed  bool
+
+	watcher *Watcher
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+var errRepositoryClosed = errors.New("file repository is closed")
+
+// NewFileRepository opens the data directory dir, creating it if needed, and recovers the
+// records stored in it.
+func NewFileRepository(dir string, opts FileOptions) (*FileRepository, error) {
+	if opts.Sync == "" {
+		opts.Sync = SyncAlways
+	}
+	if opts.SyncInterval <= 0 {
+		opts.SyncInterval = DefaultSyncInterval
+	}
+	if opts.CompactAfter <= 0 {
+		opts.CompactAfter = DefaultCompactAfter
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	snap, err := loadSnapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+	wal, err := os.OpenFile(filepath.Join(dir, walFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open WAL: %w", err)
+	}
+	records, size, err := replayWAL(wal, &snap)
+	if err != nil {
+		wal.Close()
+		return nil, err
+	}
+	// Cut off a torn record, so new records are appended right after the last valid one.
+	if err := wal.Truncate(size); err != nil {
+		wal.Close()
+		return nil, fmt.Errorf("failed to truncate WAL: %w", err)
+	}
+	if _, err := wal.Seek(size, 0); err != nil {
+		wal.Close()
+		return nil, fmt.Errorf("failed to seek WAL: %w", err)
+	}
+
+	r := &FileRepository{
+		dir:     dir,
+		opts:    opts,
+		data:    snap.Data,
+		seq:     snap.Seq,
+		wal:     wal,
+		walSize: size,
+		records: records,
+		watcher: NewWatcher(),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go r.syncLoop()
+	log.Printf("File repository: recovered %d keys from %s (%d WAL records replayed)", len(r.data), dir, records)
+	return r, nil
+}
+
+// syncLoop flushes the WAL periodically in SyncInterval mode.
+func (r *FileRepository) syncLoop() {
+	defer close(r.done)
+	if r.opts.Sync != SyncInterval {
+		return
+	}
+	ticker := time.NewTicker(r.opts.SyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.mu.Lock()
+			if r.dirty && !r.closed {
+				if err := r.wal.Sync(); err != nil {
+					log.Printf("File repository: failed to sync WAL: %v", err)
+				}
+				r.dirty = false
+			}
+			r.mu.Unlock()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// Close compacts the state into a snapshot and releases the data directory.
+func (r *FileRepository) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	err := r.compact()
+	r.closed = true
+	if cerr := r.wal.Close(); err == nil {
+		err = cerr
+	}
+	r.mu.Unlock()
+
+	close(r.stop)
+	<-r.done
+	return err
+}
+
+// Compact writes the current state to a new snapshot and empties the WAL.
+func (r *FileRepository) Compact() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return errRepositoryClosed
+	}
+	return r.compact()
+}
+
+func (r *FileRepository) compact() error {
+	if err := writeSnapshot(r.dir, fileSnapshot{Seq: r.seq, Data: r.data}); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	// A crash before the truncation leaves records the snapshot already contains;
+	// replay skips them by sequence number.
+	if err := r.wal.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate WAL: %w", err)
+	}
+	if _, err := r.wal.Seek(0, 0); err != nil {
+		return fmt.Errorf("failed to seek WAL: %w", err)
+	}
+	r.walSize, r.records, r.dirty = 0, 0, false
+	return r.wal.Sync()
+}
+
+// commit appends ops to the WAL as one record and applies them. The caller holds r.mu.
+func (r *FileRepository) commit(ops []walOp) error {
+	if r.closed {
+		return errRepositoryClosed
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	line, err := encodeRecord(walRecord{Seq: r.seq + 1, Ops: ops})
+	if err != nil {
+		return fmt.Errorf("failed to encode WAL record: %w", err)
+	}
+	if _, err := r.wal.Write(line); err != nil {
+		// Drop what was written of the record, so the next one starts on a clean line.
+		_ = r.wal.Truncate(r.walSize)
+		_, _ = r.wal.Seek(r.walSize, 0)
+		return fmt.Errorf("failed to write WAL: %w", err)
+	}
+	if r.opts.Sync == SyncAlways {
+		if err := r.wal.Sync(); err != nil {
+			_ = r.wal.Truncate(r.walSize)
+			_, _ = r.wal.Seek(r.walSize, 0)
+			return fmt.Errorf("failed to sync WAL: %w", err)
+		}
+	} else {
+		r.dirty = true
+	}
+
+	r.seq++
+	r.walSize += int64(len(line))
+	r.records++
+	applyOps(r.data, ops)
+	for _, op := range ops {
+		r.watcher.Touch(op.Key)
+	}
+
+	if r.records >= r.opts.CompactAfter {
+		// The write is already durable in the WAL, so a failed compaction is retried later.
+		if err := r.compact(); err != nil {
+			log.Printf("File repository: compaction failed: %v", err)
+		}
+	}
+	return nil
+}
+
+func (r *FileRepository) put(key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s: %w", key, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.commit([]walOp{{Key: key, Value: data}})
+}
+
+func (r *FileRepository) delete(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.data[key]; !ok {
+		return nil
+	}
+	return r.commit([]walOp{{Key: key, Delete: true}})
+}
+
+// get decodes the record at key into v and reports whether it exists.
+func (r *FileRepository) get(key string, v any) (bool, error) {
+	r.mu.RLock()
+	data, ok := r.data[key]
+	r.mu.RUnlock()
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return true, fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// list returns a copy of the index of the records under prefix.
+func (r *FileRepository) list(prefix string) map[string]json.RawMessage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string]json.RawMessage)
+	for key, data := range r.data {
+		if strings.HasPrefix(key, prefix) {
+			out[key] = data
+		}
+	}
+	return out
+}
+
+// listDecoded decodes the records under prefix, ordered by key like a Consul listing.
+func listDecoded[T any](r *FileRepository, prefix, kind string) ([]T, error) {
+	records := r.list(prefix)
+	keys := make([]string, 0, len(records))
+	for key := range records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make([]T, 0, len(keys))
+	for _, key := range keys {
+		var v T
+		if err := json.Unmarshal(records[key], &v); err != nil {
+			return nil, fmt.Errorf("failed to decode %s JSON at %s: %w", kind, key, err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// compareAndSwap stores data at key only if the record there reports expectedRevision.
+func (r *FileRepository) compareAndSwap(key string, data []byte, expectedRevision int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.data[key]
+	if !ok {
+		return errKeyNotFound
+	}
+	revision, err := storedRevision(stored)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	if revision != expectedRevision {
+		return ErrRevisionConflict
+	}
+	return r.commit([]walOp{{Key: key, Value: data}})
+}
+
+func storedRevision(data []byte) (int64, error) {
+	var stored struct {
+		Metadata model.Metadata `json:"metadata"`
+	}
+	err := json.Unmarshal(data, &stored)
+	return stored.Metadata.Revision, err
+}
+
+// ---------------------- CONFIGURATIONS ----------------------
+
+func (r *FileRepository) AddConfiguration(ctx context.Context, config model.Configuration) error {
+	return r.put(ConfigsPrefix+makeKey(config.Name, config.Version), config)
+}
+
+func (r *FileRepository) GetConfiguration(ctx context.Context, name, version string) (config model.Configuration, err error) {
+	found, err := r.get(ConfigsPrefix+makeKey(name, version), &config)
+	if err != nil {
+		return model.Configuration{}, err
+	}
+	if !found {
+		return model.Configuration{}, errors.New("configuration not found")
+	}
+	return config, nil
+}
+
+func (r *FileRepository) UpdateConfiguration(ctx context.Context, config model.Configuration) error {
+	return r.put(ConfigsPrefix+makeKey(config.Name, config.Version), config)
+}
+
+func (r *FileRepository) CompareAndSwapConfiguration(ctx context.Context, config model.Configuration, expectedRevision int64) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to serialize configuration for update: %w", err)
+	}
+	err = r.compareAndSwap(ConfigsPrefix+makeKey(config.Name, config.Version), data, expectedRevision)
+	if errors.Is(err, errKeyNotFound) {
+		return errors.New("configuration not found")
+	}
+	return err
+}
+
+func (r *FileRepository) DeleteConfiguration(ctx context.Context, name, version string) error {
+	return r.delete(ConfigsPrefix + makeKey(name, version))
+}
+
+func (r *FileRepository) ListConfigurations(ctx context.Context, name string) ([]model.Configuration, error) {
+	return listDecoded[model.Configuration](r, listPrefix(ConfigsPrefix, name), "configuration")
+}
+
+// ---------------------- CONFIGURATION GROUPS ----------------------
+
+func (r *FileRepository) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup) error {
+	return r.put(GroupsPrefix+makeKey(group.Name, group.Version), group)
+}
+
+func (r *FileRepository) GetConfigurationGroup(ctx context.Context, name, version string) (group model.ConfigurationGroup, err error) {
+	found, err := r.get(GroupsPrefix+makeKey(name, version), &group)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	if !found {
+		return model.ConfigurationGroup{}, errors.New("configuration group not found")
+	}
+	return group, nil
+}
+
+func (r *FileRepository) UpdateConfigurationGroup(ctx context.Context, group model.ConfigurationGroup) error {
+	return r.put(GroupsPrefix+makeKey(group.Name, group.Version), group)
+}
+
+func (r *FileRepository) CompareAndSwapConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, expectedRevision int64) error {
+	data, err := json.Marshal(group)
+	if err != nil {
+		return fmt.Errorf("failed to serialize configuration group for update: %w", err)
+	}
+	err = r.compareAndSwap(GroupsPrefix+makeKey(group.Name, group.Version), data, expectedRevision)
+	if errors.Is(err, errKeyNotFound) {
+		return errors.New("configuration group not found")
+	}
+	return err
+}
+
+func (r *FileRepository) DeleteConfigurationGroup(ctx context.Context, name, version string) error {
+	return r.delete(GroupsPrefix + makeKey(name, version))
+}
+
+func (r *FileRepository) ListConfigurationGroups(ctx context.Context, name string) ([]model.ConfigurationGroup, error) {
+	return listDecoded[model.ConfigurationGroup](r, listPrefix(GroupsPrefix, name), "configuration group")
+}
+
+// ---------------------- TRANSACTIONS ----------------------
+
+// Transact checks every operation against the stored records and writes all of them as a
+// single WAL record, so a crash never leaves part of a transaction applied.
+func (r *FileRepository) Transact(ctx context.Context, ops []TxnOp) error {
+	if len(ops) > MaxTxnOps {
+		return fmt.Errorf("%w: %d operations, at most %d are allowed", ErrTxnTooLarge, len(ops), MaxTxnOps)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	walOps := make([]walOp, 0, len(ops))
+	var reasons []string
+	for _, op := range ops {
+		key, kind := ConfigsPrefix+makeKey(op.Name(), op.Version()), "configuration"
+		var value any = op.Configuration
+		if op.Group != nil {
+			key, kind = GroupsPrefix+makeKey(op.Name(), op.Version()), "configuration group"
+			value = op.Group
+		}
+
+		stored, exists := r.data[key]
+		switch {
+		case op.Verb == TxnCreate && exists:
+			reasons = append(reasons, fmt.Sprintf("%s %s/%s: key already exists", op.Verb, op.Name(), op.Version()))
+			continue
+		case op.Verb != TxnCreate && !exists:
+			return fmt.Errorf("%w: %s %s/%s not found", ErrRevisionConflict, kind, op.Name(), op.Version())
+		case op.Verb != TxnCreate:
+			revision, err := storedRevision(stored)
+			if err != nil {
+				return fmt.Errorf("failed to decode %s: %w", key, err)
+			}
+			if revision != op.ExpectedRevision {
+				return fmt.Errorf("%w: %s %s/%s", ErrRevisionConflict, kind, op.Name(), op.Version())
+			}
+		}
+
+		if op.Verb == TxnDelete {
+			walOps = append(walOps, walOp{Key: key, Delete: true})
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to serialize %s: %w", kind, err)
+		}
+		walOps = append(walOps, walOp{Key: key, Value: data})
+	}
+	if len(reasons) > 0 {
+		return fmt.Errorf("%w: %s", ErrRevisionConflict, strings.Join(reasons, "; "))
+	}
+	return r.commit(walOps)
+}
+
+// ---------------------- WATCH ----------------------
+
+func (r *FileRepository) WatchConfiguration(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.Configuration, uint64, error) {
+	next := r.watcher.Wait(ctx, ConfigsPrefix+makeKey(name, version), index, wait)
+	config, err := r.GetConfiguration(ctx, name, version)
+	return config, next, err
+}
+
+func (r *FileRepository) WatchConfigurationGroup(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.ConfigurationGroup, uint64, error) {
+	next := r.watcher.Wait(ctx, GroupsPrefix+makeKey(name, version), index, wait)
+	group, err := r.GetConfigurationGroup(ctx, name, version)
+	return group, next, err
+}
+
+// ---------------------- WEBHOOKS ----------------------
+
+func (r *FileRepository) AddWebhook(ctx context.Context, hook model.Webhook) error {
+	return r.put(WebhooksPrefix+hook.ID.String(), hook)
+}
+
+func (r *FileRepository) GetWebhook(ctx context.Context, id uuid.UUID) (hook model.Webhook, err error) {
+	found, err := r.get(WebhooksPrefix+id.String(), &hook)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+	if !found {
+		return model.Webhook{}, errors.New("webhook not found")
+	}
+	return hook, nil
+}
+
+func (r *FileRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	return r.delete(WebhooksPrefix + id.String())
+}
+
+func (r *FileRepository) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	return listDecoded[model.Webhook](r, WebhooksPrefix, "webhook")
+}
+
+// ---------------------- IDEMPOTENCY ----------------------
+
+func (r *FileRepository) CheckIdempotencyKey(ctx context.Context, key string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, found := r.data[IdempotencyPrefix+key]
+	return found, nil
+}
+
+func (r *FileRepository) SaveIdempotencyKey(ctx context.Context, key string) error {
+	return r.put(IdempotencyPrefix+key, "processed")
+}
+
+func (r *FileRepository) ListIdempotencyKeys(ctx context.Context) ([]string, error) {
+	records := r.list(IdempotencyPrefix)
+	keys := make([]string, 0, len(records))
+	for key := range records {
+		keys = append(keys, strings.TrimPrefix(key, IdempotencyPrefix))
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+var _ Repository = (*FileRepository)(nil)
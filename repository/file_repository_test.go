@@ -0,0 +1,344 @@
+package repository
+
+import (
+	"alati_projekat/model"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func openFileRepo(t *testing.T, dir string, opts FileOptions) *FileRepository {
+	t.Helper()
+	repo, err := NewFileRepository(dir, opts)
+	if err != nil {
+		t.Fatalf("NewFileRepository failed: %v", err)
+	}
+	return repo
+}
+
+func TestFileRepository_ConfigurationCRUD(t *testing.T) {
+	repo := openFileRepo(t, t.TempDir(), FileOptions{})
+	defer repo.Close()
+	ctx := context.Background()
+
+	config := model.Configuration{ID: uuid.New(), Name: "service-api", Version: "v1", Params: []model.Parameter{{Key: "port", Value: "8080"}}}
+	if err := repo.AddConfiguration(ctx, config); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	if err := repo.AddConfiguration(ctx, model.Configuration{Name: "service-api", Version: "v2"}); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+
+	got, err := repo.GetConfiguration(ctx, "service-api", "v1")
+	if err != nil || got.ID != config.ID || len(got.Params) != 1 {
+		t.Fatalf("Expected the stored configuration, got %+v (%v)", got, err)
+	}
+	// Izmena vracene vrednosti ne menja sacuvani zapis
+	got.Params[0].Value = "changed"
+	if again, _ := repo.GetConfiguration(ctx, "service-api", "v1"); again.Params[0].Value != "8080" {
+		t.Error("Returned configuration shares memory with the stored one")
+	}
+
+	config.Params = append(config.Params, model.Parameter{Key: "timeout", Value: "30s"})
+	if err := repo.UpdateConfiguration(ctx, config); err != nil {
+		t.Fatalf("UpdateConfiguration failed: %v", err)
+	}
+	if got, _ := repo.GetConfiguration(ctx, "service-api", "v1"); len(got.Params) != 2 {
+		t.Errorf("Expected 2 params after update, got %d", len(got.Params))
+	}
+
+	list, err := repo.ListConfigurations(ctx, "service-api")
+	if err != nil || len(list) != 2 || list[0].Version != "v1" {
+		t.Errorf("Expected both versions in key order, got %+v (%v)", list, err)
+	}
+
+	if err := repo.DeleteConfiguration(ctx, "service-api", "v1"); err != nil {
+		t.Fatalf("DeleteConfiguration failed: %v", err)
+	}
+	if _, err := repo.GetConfiguration(ctx, "service-api", "v1"); err == nil || err.Error() != "configuration not found" {
+		t.Errorf("Expected 'configuration not found', got %v", err)
+	}
+}
+
+func TestFileRepository_GroupsWebhooksIdempotency(t *testing.T) {
+	repo := openFileRepo(t, t.TempDir(), FileOptions{})
+	defer repo.Close()
+	ctx := context.Background()
+
+	group := model.ConfigurationGroup{Name: "cluster", Version: "v1", Configurations: []model.Configuration{{Name: "service-api", Version: "v1"}}}
+	if err := repo.AddConfigurationGroup(ctx, group); err != nil {
+		t.Fatalf("AddConfigurationGroup failed: %v", err)
+	}
+	if got, err := repo.GetConfigurationGroup(ctx, "cluster", "v1"); err != nil || len(got.Configurations) != 1 {
+		t.Errorf("Expected the stored group, got %+v (%v)", got, err)
+	}
+	if err := repo.DeleteConfigurationGroup(ctx, "cluster", "v1"); err != nil {
+		t.Fatalf("DeleteConfigurationGroup failed: %v", err)
+	}
+	if _, err := repo.GetConfigurationGroup(ctx, "cluster", "v1"); err == nil {
+		t.Error("Expected an error for the deleted group")
+	}
+
+	hook := model.Webhook{ID: uuid.New(), URL: "http://example.com/hook"}
+	if err := repo.AddWebhook(ctx, hook); err != nil {
+		t.Fatalf("AddWebhook failed: %v", err)
+	}
+	if hooks, err := repo.ListWebhooks(ctx); err != nil || len(hooks) != 1 || hooks[0].URL != hook.URL {
+		t.Errorf("Expected the stored webhook, got %+v (%v)", hooks, err)
+	}
+	if err := repo.DeleteWebhook(ctx, hook.ID); err != nil {
+		t.Fatalf("DeleteWebhook failed: %v", err)
+	}
+	if _, err := repo.GetWebhook(ctx, hook.ID); err == nil {
+		t.Error("Expected an error for the deleted webhook")
+	}
+
+	if found, _ := repo.CheckIdempotencyKey(ctx, "key-1"); found {
+		t.Error("Expected key-1 not to be processed yet")
+	}
+	if err := repo.SaveIdempotencyKey(ctx, "key-1"); err != nil {
+		t.Fatalf("SaveIdempotencyKey failed: %v", err)
+	}
+	if found, _ := repo.CheckIdempotencyKey(ctx, "key-1"); !found {
+		t.Error("Expected key-1 to be processed")
+	}
+	if keys, _ := repo.ListIdempotencyKeys(ctx); !slices.Equal(keys, []string{"key-1"}) {
+		t.Errorf("Expected [key-1], got %v", keys)
+	}
+}
+
+func TestFileRepository_CompareAndSwapAndTransact(t *testing.T) {
+	repo := openFileRepo(t, t.TempDir(), FileOptions{})
+	defer repo.Close()
+	ctx := context.Background()
+
+	config := model.Configuration{Name: "service-api", Version: "v1", Metadata: model.Metadata{Revision: 1}}
+	if err := repo.AddConfiguration(ctx, config); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+
+	config.Metadata.Revision = 2
+	if err := repo.CompareAndSwapConfiguration(ctx, config, 1); err != nil {
+		t.Fatalf("CompareAndSwapConfiguration failed: %v", err)
+	}
+	if err := repo.CompareAndSwapConfiguration(ctx, config, 1); !errors.Is(err, ErrRevisionConflict) {
+		t.Errorf("Expected ErrRevisionConflict for a stale revision, got %v", err)
+	}
+	if err := repo.CompareAndSwapConfiguration(ctx, model.Configuration{Name: "missing", Version: "v1"}, 1); err == nil || err.Error() != "configuration not found" {
+		t.Errorf("Expected 'configuration not found', got %v", err)
+	}
+
+	created := model.Configuration{Name: "service-web", Version: "v1", Metadata: model.Metadata{Revision: 1}}
+	updated := config
+	updated.Metadata.Revision = 3
+
+	// Sukob u drugoj operaciji ne sme da upise prvu
+	err := repo.Transact(ctx, []TxnOp{
+		{Verb: TxnCreate, Configuration: &created},
+		{Verb: TxnUpdate, Configuration: &updated, ExpectedRevision: 1},
+	})
+	if !errors.Is(err, ErrRevisionConflict) {
+		t.Fatalf("Expected ErrRevisionConflict, got %v", err)
+	}
+	if _, err := repo.GetConfiguration(ctx, "service-web", "v1"); err == nil {
+		t.Error("Failed transaction must not store anything")
+	}
+
+	err = repo.Transact(ctx, []TxnOp{
+		{Verb: TxnCreate, Configuration: &created},
+		{Verb: TxnUpdate, Configuration: &updated, ExpectedRevision: 2},
+	})
+	if err != nil {
+		t.Fatalf("Transact failed: %v", err)
+	}
+	if got, _ := repo.GetConfiguration(ctx, "service-api", "v1"); got.Metadata.Revision != 3 {
+		t.Errorf("Expected revision 3, got %d", got.Metadata.Revision)
+	}
+	if err := repo.Transact(ctx, make([]TxnOp, MaxTxnOps+1)); !errors.Is(err, ErrTxnTooLarge) {
+		t.Errorf("Expected ErrTxnTooLarge, got %v", err)
+	}
+}
+
+func TestFileRepository_WatchConfiguration(t *testing.T) {
+	repo := openFileRepo(t, t.TempDir(), FileOptions{})
+	defer repo.Close()
+	ctx := context.Background()
+
+	_, index, err := repo.WatchConfiguration(ctx, "service-api", "v1", 0, time.Second)
+	if err == nil {
+		t.Fatal("Expected not found for a missing configuration")
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		repo.AddConfiguration(ctx, model.Configuration{Name: "service-api", Version: "v1"})
+	}()
+	config, next, err := repo.WatchConfiguration(ctx, "service-api", "v1", index, time.Minute)
+	if err != nil || next <= index || config.Name != "service-api" {
+		t.Errorf("Expected the created configuration at an index after %d, got %d %+v (%v)", index, next, config, err)
+	}
+}
+
+func TestFileRepository_Recovery(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		crash func(t *testing.T, dir string, repo *FileRepository)
+	}{
+		{"clean shutdown", func(t *testing.T, dir string, repo *FileRepository) {
+			if err := repo.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+		}},
+		{"crash without compaction", func(t *testing.T, dir string, repo *FileRepository) {
+			repo.wal.Close()
+		}},
+		{"torn last record", func(t *testing.T, dir string, repo *FileRepository) {
+			repo.wal.Close()
+			f, err := os.OpenFile(filepath.Join(dir, walFile), os.O_APPEND|os.O_WRONLY, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.WriteString(`0badc0de {"seq":4,"ops":[{"key":"configurations/half`)
+			f.Close()
+		}},
+		{"crash between snapshot and WAL truncation", func(t *testing.T, dir string, repo *FileRepository) {
+			if err := writeSnapshot(dir, fileSnapshot{Seq: repo.seq, Data: repo.data}); err != nil {
+				t.Fatal(err)
+			}
+			repo.wal.Close()
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			repo := openFileRepo(t, dir, FileOptions{Sync: SyncNever})
+			repo.AddConfiguration(ctx, model.Configuration{Name: "service-api", Version: "v1"})
+			repo.AddConfiguration(ctx, model.Configuration{Name: "service-web", Version: "v1"})
+			repo.DeleteConfiguration(ctx, "service-web", "v1")
+			tt.crash(t, dir, repo)
+
+			repo = openFileRepo(t, dir, FileOptions{})
+			list, err := repo.ListConfigurations(ctx, "")
+			if err != nil || len(list) != 1 || list[0].Name != "service-api" {
+				t.Fatalf("Expected only service-api after recovery, got %+v (%v)", list, err)
+			}
+			// Posle oporavka novi zapisi se nastavljaju na poslednji ispravan
+			if err := repo.AddConfiguration(ctx, model.Configuration{Name: "service-db", Version: "v1"}); err != nil {
+				t.Fatalf("AddConfiguration after recovery failed: %v", err)
+			}
+			repo.wal.Close()
+			repo = openFileRepo(t, dir, FileOptions{})
+			defer repo.Close()
+			if list, _ := repo.ListConfigurations(ctx, ""); len(list) != 2 {
+				t.Errorf("Expected 2 configurations after a second restart, got %+v", list)
+			}
+		})
+	}
+}
+
+// Ostecen zapis usred WAL-a ne sme da obrise ispravne zapise posle njega
+func TestFileRepository_CorruptRecord(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		line    int // red WAL-a koji se ostecuje
+		wantErr bool
+		want    int // broj konfiguracija posle oporavka
+	}{
+		{"middle record", 1, true, 0},
+		{"last record", 2, false, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			repo := openFileRepo(t, dir, FileOptions{Sync: SyncNever})
+			for _, name := range []string{"service-api", "service-web", "service-db"} {
+				repo.AddConfiguration(ctx, model.Configuration{Name: name, Version: "v1"})
+			}
+			repo.wal.Close()
+
+			path := filepath.Join(dir, walFile)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := bytes.SplitAfter(data, []byte("\n"))
+			lines[tt.line] = bytes.Replace(lines[tt.line], []byte("service"), []byte("servicE"), 1)
+			corrupted := bytes.Join(lines, nil)
+			if err := os.WriteFile(path, corrupted, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			repo, err = NewFileRepository(dir, FileOptions{})
+			if tt.wantErr {
+				if err == nil {
+					repo.Close()
+					t.Fatal("Expected recovery to fail on a corrupt record followed by valid ones")
+				}
+				if after, _ := os.ReadFile(path); !bytes.Equal(after, corrupted) {
+					t.Error("Failed recovery must not truncate the WAL")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFileRepository failed: %v", err)
+			}
+			defer repo.Close()
+			if list, _ := repo.ListConfigurations(ctx, ""); len(list) != tt.want {
+				t.Errorf("Expected %d configurations after dropping the last record, got %+v", tt.want, list)
+			}
+		})
+	}
+}
+
+func TestFileRepository_Compaction(t *testing.T) {
+	dir := t.TempDir()
+	repo := openFileRepo(t, dir, FileOptions{CompactAfter: 3})
+	ctx := context.Background()
+
+	for i := range 4 {
+		if err := repo.SaveIdempotencyKey(ctx, uuid.NewString()); err != nil {
+			t.Fatalf("SaveIdempotencyKey %d failed: %v", i, err)
+		}
+	}
+	if repo.records != 1 {
+		t.Errorf("Expected the WAL to hold 1 record after compaction, got %d", repo.records)
+	}
+	if _, err := os.Stat(filepath.Join(dir, snapshotFile)); err != nil {
+		t.Errorf("Expected a snapshot after compaction: %v", err)
+	}
+	repo.Close()
+
+	repo = openFileRepo(t, dir, FileOptions{})
+	defer repo.Close()
+	if keys, _ := repo.ListIdempotencyKeys(ctx); len(keys) != 4 {
+		t.Errorf("Expected 4 keys after reopening, got %d", len(keys))
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := repo.SaveIdempotencyKey(ctx, "late"); !errors.Is(err, errRepositoryClosed) {
+		t.Errorf("Expected writes to fail after Close, got %v", err)
+	}
+}
+
+func TestParseSyncMode(t *testing.T) {
+	for in, want := range map[string]SyncMode{"": SyncAlways, "always": SyncAlways, "Interval": SyncInterval, "never": SyncNever} {
+		if got, err := ParseSyncMode(in); err != nil || got != want {
+			t.Errorf("ParseSyncMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseSyncMode("sometimes"); err == nil {
+		t.Error("Expected an error for an unknown sync mode")
+	}
+}
@@ -2,120 +2,382 @@ package repository
 
 import (
 	"alati_projekat/model"
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+// InMemoryRepository keeps records in process memory, for tests and for running the service
+// without Consul. It is safe for concurrent use; records are copied on every read and write,
+// so callers never share slices or pointers with the stored state.
+//
+// Writes behave like their Consul counterparts: adds and updates overwrite, deletes of
+// missing records succeed. Nothing survives a restart.
 type InMemoryRepository struct {
+	mu              sync.RWMutex
 	configs         map[string]model.Configuration
 	groups          map[string]model.ConfigurationGroup
+	webhooks        map[uuid.UUID]model.Webhook
 	idempotencyKeys map[string]struct{}
+	watcher         *Watcher
 }
 
 func NewInMemoryRepository() *InMemoryRepository {
 	return &InMemoryRepository{
 		configs:         make(map[string]model.Configuration),
 		groups:          make(map[string]model.ConfigurationGroup),
+		webhooks:        make(map[uuid.UUID]model.Webhook),
 		idempotencyKeys: make(map[string]struct{}),
+		watcher:         NewWatcher(),
 	}
 }
 
-// CONFIGURATIONS
+// ---------------------- COPIES ----------------------
 
-// ADD
-func (r *InMemoryRepository) AddConfiguration(config model.Configuration) error {
-	key := makeKey(config.Name, config.Version)
+func cloneConfiguration(c model.Configuration) model.Configuration {
+	c.Params = slices.Clone(c.Params)
+	c.Labels = slices.Clone(c.Labels)
+	c.RemoveParams = slices.Clone(c.RemoveParams)
+	if c.Parent != nil {
+		parent := *c.Parent
+		c.Parent = &parent
+	}
+	c.Lifecycle = cloneLifecycle(c.Lifecycle)
+	c.Metadata = cloneMetadata(c.Metadata)
+	return c
+}
+
+func cloneGroup(g model.ConfigurationGroup) model.ConfigurationGroup {
+	if g.Configurations != nil {
+		configs := make([]model.Configuration, len(g.Configurations))
+		for i, c := range g.Configurations {
+			configs[i] = cloneConfiguration(c)
+		}
+		g.Configurations = configs
+	}
+	g.Lifecycle = cloneLifecycle(g.Lifecycle)
+	g.Metadata = cloneMetadata(g.Metadata)
+	return g
+}
+
+func cloneMetadata(m model.Metadata) model.Metadata {
+	if m.ClonedFrom != nil {
+		ref := *m.ClonedFrom
+		m.ClonedFrom = &ref
+	}
+	return m
+}
+
+func cloneLifecycle(l model.Lifecycle) model.Lifecycle {
+	if l.DeprecatedAt != nil {
+		t := *l.DeprecatedAt
+		l.DeprecatedAt = &t
+	}
+	if l.SunsetAt != nil {
+		t := *l.SunsetAt
+		l.SunsetAt = &t
+	}
+	return l
+}
 
-	if _, exists := r.configs[key]; exists {
-		return errors.New("configuration with this name and version already exists")
+func cloneWebhook(h model.Webhook) model.Webhook {
+	h.Events = slices.Clone(h.Events)
+	return h
+}
+
+// sortedValues returns the values of m whose key has prefix, ordered by key like a Consul listing.
+func sortedValues[T any](m map[string]T, prefix string, clone func(T) T) []T {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
 	}
-	r.configs[key] = config
+	sort.Strings(keys)
+
+	out := make([]T, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, clone(m[key]))
+	}
+	return out
+}
+
+// ---------------------- CONFIGURATIONS ----------------------
+
+func (r *InMemoryRepository) AddConfiguration(ctx context.Context, config model.Configuration) error {
+	key := makeKey(config.Name, config.Version)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configs[key] = cloneConfiguration(config)
+	r.watcher.Touch(ConfigsPrefix + key)
 	return nil
 }
 
-// GET
-func (r *InMemoryRepository) GetConfiguration(name, version string) (model.Configuration, error) {
-	key := makeKey(name, version)
-	config, exists := r.configs[key]
+func (r *InMemoryRepository) GetConfiguration(ctx context.Context, name, version string) (model.Configuration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	config, exists := r.configs[makeKey(name, version)]
 	if !exists {
-		return model.Configuration{}, errors.New("configuration not found for get")
+		return model.Configuration{}, errors.New("configuration not found")
 	}
-	return config, nil
+	return cloneConfiguration(config), nil
 }
 
-// UPDATE
-func (r *InMemoryRepository) UpdateConfiguration(config model.Configuration) error {
+func (r *InMemoryRepository) UpdateConfiguration(ctx context.Context, config model.Configuration) error {
 	key := makeKey(config.Name, config.Version)
 
-	if _, exists := r.configs[key]; !exists {
-		return errors.New("configuration not found for update")
-	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configs[key] = cloneConfiguration(config)
+	r.watcher.Touch(ConfigsPrefix + key)
+	return nil
+}
 
-	r.configs[key] = config
+func (r *InMemoryRepository) CompareAndSwapConfiguration(ctx context.Context, config model.Configuration, expectedRevision int64) error {
+	key := makeKey(config.Name, config.Version)
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, exists := r.configs[key]
+	if !exists {
+		return errors.New("configuration not found")
+	}
+	if stored.Metadata.Revision != expectedRevision {
+		return ErrRevisionConflict
+	}
+	r.configs[key] = cloneConfiguration(config)
+	r.watcher.Touch(ConfigsPrefix + key)
 	return nil
 }
 
-// DELETE
-func (r *InMemoryRepository) DeleteConfiguration(name, version string) error {
+func (r *InMemoryRepository) DeleteConfiguration(ctx context.Context, name, version string) error {
 	key := makeKey(name, version)
-	if _, exists := r.configs[key]; !exists {
-		return errors.New("configuration not found for deletion")
-	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	delete(r.configs, key)
+	r.watcher.Touch(ConfigsPrefix + key)
 	return nil
 }
 
-// CONFIGURATION GROUPS
+func (r *InMemoryRepository) ListConfigurations(ctx context.Context, name string) ([]model.Configuration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortedValues(r.configs, listPrefix("", name), cloneConfiguration), nil
+}
+
+// ---------------------- CONFIGURATION GROUPS ----------------------
 
-// ADD
-func (r *InMemoryRepository) AddConfigurationGroup(group model.ConfigurationGroup) error {
+func (r *InMemoryRepository) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup) error {
 	key := makeKey(group.Name, group.Version)
-	if _, exists := r.groups[key]; exists {
-		return errors.New("config group with this name and version already exists")
-	}
-	r.groups[key] = group
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.groups[key] = cloneGroup(group)
+	r.watcher.Touch(GroupsPrefix + key)
 	return nil
 }
 
-// GET
-func (r *InMemoryRepository) GetConfigurationGroup(name, version string) (model.ConfigurationGroup, error) {
-	key := makeKey(name, version)
-	group, exists := r.groups[key]
+func (r *InMemoryRepository) GetConfigurationGroup(ctx context.Context, name, version string) (model.ConfigurationGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	group, exists := r.groups[makeKey(name, version)]
 	if !exists {
-		return model.ConfigurationGroup{}, errors.New("config group not found")
+		return model.ConfigurationGroup{}, errors.New("configuration group not found")
 	}
-	return group, nil
+	return cloneGroup(group), nil
 }
 
-// UPDATE
-func (r *InMemoryRepository) UpdateConfigurationGroup(group model.ConfigurationGroup) error {
+func (r *InMemoryRepository) UpdateConfigurationGroup(ctx context.Context, group model.ConfigurationGroup) error {
 	key := makeKey(group.Name, group.Version)
 
-	if _, exists := r.groups[key]; !exists {
-		return errors.New("config group not found for update")
-	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.groups[key] = cloneGroup(group)
+	r.watcher.Touch(GroupsPrefix + key)
+	return nil
+}
 
-	r.groups[key] = group
+func (r *InMemoryRepository) CompareAndSwapConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, expectedRevision int64) error {
+	key := makeKey(group.Name, group.Version)
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, exists := r.groups[key]
+	if !exists {
+		return errors.New("configuration group not found")
+	}
+	if stored.Metadata.Revision != expectedRevision {
+		return ErrRevisionConflict
+	}
+	r.groups[key] = cloneGroup(group)
+	r.watcher.Touch(GroupsPrefix + key)
 	return nil
 }
 
-// DELETE
-func (r *InMemoryRepository) DeleteConfigurationGroup(name, version string) error {
+func (r *InMemoryRepository) DeleteConfigurationGroup(ctx context.Context, name, version string) error {
 	key := makeKey(name, version)
-	if _, exists := r.groups[key]; !exists {
-		return errors.New("config group not found for deletion")
-	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	delete(r.groups, key)
+	r.watcher.Touch(GroupsPrefix + key)
+	return nil
+}
+
+func (r *InMemoryRepository) ListConfigurationGroups(ctx context.Context, name string) ([]model.ConfigurationGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortedValues(r.groups, listPrefix("", name), cloneGroup), nil
+}
+
+// ---------------------- TRANSACTIONS ----------------------
+
+// Transact checks every operation against the stored records before applying any of them,
+// all under one lock.
+func (r *InMemoryRepository) Transact(ctx context.Context, ops []TxnOp) error {
+	if len(ops) > MaxTxnOps {
+		return fmt.Errorf("%w: %d operations, at most %d are allowed", ErrTxnTooLarge, len(ops), MaxTxnOps)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var reasons []string
+	for _, op := range ops {
+		key, kind := makeKey(op.Name(), op.Version()), "configuration"
+		var revision int64
+		var exists bool
+		if op.Group != nil {
+			kind = "configuration group"
+			var stored model.ConfigurationGroup
+			stored, exists = r.groups[key]
+			revision = stored.Metadata.Revision
+		} else {
+			var stored model.Configuration
+			stored, exists = r.configs[key]
+			revision = stored.Metadata.Revision
+		}
+
+		switch {
+		case op.Verb == TxnCreate && exists:
+			reasons = append(reasons, fmt.Sprintf("%s %s/%s: key already exists", op.Verb, op.Name(), op.Version()))
+		case op.Verb != TxnCreate && !exists:
+			return fmt.Errorf("%w: %s %s/%s not found", ErrRevisionConflict, kind, op.Name(), op.Version())
+		case op.Verb != TxnCreate && revision != op.ExpectedRevision:
+			return fmt.Errorf("%w: %s %s/%s", ErrRevisionConflict, kind, op.Name(), op.Version())
+		}
+	}
+	if len(reasons) > 0 {
+		return fmt.Errorf("%w: %s", ErrRevisionConflict, strings.Join(reasons, "; "))
+	}
+
+	for _, op := range ops {
+		key := makeKey(op.Name(), op.Version())
+		switch {
+		case op.Group != nil && op.Verb == TxnDelete:
+			delete(r.groups, key)
+		case op.Group != nil:
+			r.groups[key] = cloneGroup(*op.Group)
+		case op.Verb == TxnDelete:
+			delete(r.configs, key)
+		default:
+			r.configs[key] = cloneConfiguration(*op.Configuration)
+		}
+		if op.Group != nil {
+			r.watcher.Touch(GroupsPrefix + key)
+		} else {
+			r.watcher.Touch(ConfigsPrefix + key)
+		}
+	}
+	return nil
+}
+
+// ---------------------- WATCH ----------------------
+
+// WatchConfiguration blocks until the configuration is written after index; see Watcher.
+func (r *InMemoryRepository) WatchConfiguration(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.Configuration, uint64, error) {
+	next := r.watcher.Wait(ctx, ConfigsPrefix+makeKey(name, version), index, wait)
+	config, err := r.GetConfiguration(ctx, name, version)
+	return config, next, err
+}
+
+// WatchConfigurationGroup blocks until the group is written after index; see Watcher.
+func (r *InMemoryRepository) WatchConfigurationGroup(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.ConfigurationGroup, uint64, error) {
+	next := r.watcher.Wait(ctx, GroupsPrefix+makeKey(name, version), index, wait)
+	group, err := r.GetConfigurationGroup(ctx, name, version)
+	return group, next, err
+}
+
+// ---------------------- WEBHOOKS ----------------------
+
+func (r *InMemoryRepository) AddWebhook(ctx context.Context, hook model.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.webhooks[hook.ID] = cloneWebhook(hook)
 	return nil
 }
 
-func (r *InMemoryRepository) CheckIdempotencyKey(key string) (bool, error) {
+func (r *InMemoryRepository) GetWebhook(ctx context.Context, id uuid.UUID) (model.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hook, exists := r.webhooks[id]
+	if !exists {
+		return model.Webhook{}, errors.New("webhook not found")
+	}
+	return cloneWebhook(hook), nil
+}
+
+func (r *InMemoryRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.webhooks, id)
+	return nil
+}
+
+func (r *InMemoryRepository) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hooks := make(map[string]model.Webhook, len(r.webhooks))
+	for id, hook := range r.webhooks {
+		hooks[id.String()] = hook
+	}
+	return sortedValues(hooks, "", cloneWebhook), nil
+}
+
+// ---------------------- IDEMPOTENCY ----------------------
+
+func (r *InMemoryRepository) CheckIdempotencyKey(ctx context.Context, key string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	_, exists := r.idempotencyKeys[key]
 	return exists, nil
 }
 
-func (r *InMemoryRepository) SaveIdempotencyKey(key string) error {
+func (r *InMemoryRepository) SaveIdempotencyKey(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.idempotencyKeys[key] = struct{}{}
 	return nil
 }
+
+func (r *InMemoryRepository) ListIdempotencyKeys(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]string, 0, len(r.idempotencyKeys))
+	for key := range r.idempotencyKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+var _ Repository = (*InMemoryRepository)(nil)
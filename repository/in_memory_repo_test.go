@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"alati_projekat/model"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func setupRepo() *InMemoryRepository {
+	return NewInMemoryRepository()
+}
+
+// ----------------------------------------------------
+// TESTOVI ZA KONFIGURACIJE
+// ----------------------------------------------------
+
+func TestConfigCRUD(t *testing.T) {
+	repo := setupRepo()
+	ctx := context.Background()
+
+	name := "ServiceA"
+	version := "v1.0.0"
+	key := makeKey(name, version)
+
+	testConfig := model.Configuration{
+		ID:      uuid.New(),
+		Name:    name,
+		Version: version,
+		Params:  []model.Parameter{{Key: "timeout", Value: "10s"}},
+	}
+
+	// 1. CREATE (Add)
+	t.Run("AddConfiguration", func(t *testing.T) {
+		if err := repo.AddConfiguration(ctx, testConfig); err != nil {
+			t.Fatalf("FAIL: AddConfiguration should succeed, got error: %v", err)
+		}
+		if _, exists := repo.configs[key]; !exists {
+			t.Fatal("FAIL: Configuration not found in map after Add")
+		}
+	})
+
+	// 1. CREATE (Duplikat se upisuje preko postojeceg, kao u Consul-u)
+	t.Run("AddDuplicateConfiguration", func(t *testing.T) {
+		if err := repo.AddConfiguration(ctx, testConfig); err != nil {
+			t.Errorf("FAIL: Expected the duplicate to overwrite, got: %v", err)
+		}
+	})
+
+	// 2. READ (Get - uspešno)
+	t.Run("GetConfiguration_Success", func(t *testing.T) {
+		fetchedConfig, err := repo.GetConfiguration(ctx, name, version)
+		if err != nil {
+			t.Fatalf("FAIL: GetConfiguration failed: %v", err)
+		}
+		if fetchedConfig.Name != name {
+			t.Errorf("FAIL: Expected name %s, got %s", name, fetchedConfig.Name)
+		}
+	})
+
+	// 3. UPDATE (Put)
+	t.Run("UpdateConfiguration_Success", func(t *testing.T) {
+		updatedConfig := testConfig
+		updatedConfig.Params = []model.Parameter{{Key: "timeout", Value: "20s"}}
+
+		if err := repo.UpdateConfiguration(ctx, updatedConfig); err != nil {
+			t.Fatalf("FAIL: UpdateConfiguration failed: %v", err)
+		}
+
+		checkConfig, _ := repo.GetConfiguration(ctx, name, version)
+		if checkConfig.Params[0].Value != "20s" {
+			t.Errorf("FAIL: Update failed, expected value 20s, got %s", checkConfig.Params[0].Value)
+		}
+	})
+
+	// 4. DELETE (uspešno)
+	t.Run("DeleteConfiguration_Success", func(t *testing.T) {
+		if err := repo.DeleteConfiguration(ctx, name, version); err != nil {
+			t.Fatalf("FAIL: DeleteConfiguration failed: %v", err)
+		}
+
+		_, err := repo.GetConfiguration(ctx, name, version)
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("FAIL: Expected 'not found' error after deletion, got: %v", err)
+		}
+	})
+
+	// 4. DELETE (nepostojeci zapis, kao u Consul-u)
+	t.Run("DeleteConfiguration_NotFound", func(t *testing.T) {
+		if err := repo.DeleteConfiguration(ctx, name, version); err != nil {
+			t.Errorf("FAIL: Expected deleting a missing configuration to succeed, got: %v", err)
+		}
+	})
+}
+
+// ----------------------------------------------------
+// TESTOVI ZA GRUPE
+// ----------------------------------------------------
+
+func TestGroupCRUD(t *testing.T) {
+	repo := setupRepo()
+	ctx := context.Background()
+
+	name := "WebServers"
+	version := "v2.0.0"
+	key := makeKey(name, version)
+
+	testGroup := model.ConfigurationGroup{
+		ID:      uuid.New(),
+		Name:    name,
+		Version: version,
+		Configurations: []model.Configuration{
+			{Name: "C1", Version: "v1"},
+		},
+	}
+
+	// 1. CREATE (Add Group)
+	t.Run("AddConfigurationGroup", func(t *testing.T) {
+		if err := repo.AddConfigurationGroup(ctx, testGroup); err != nil {
+			t.Fatalf("FAIL: AddConfigurationGroup should succeed, got error: %v", err)
+		}
+		if _, exists := repo.groups[key]; !exists {
+			t.Fatal("FAIL: Group not found in map after Add")
+		}
+	})
+
+	// 2. READ (Get Group - uspešno)
+	t.Run("GetConfigurationGroup_Success", func(t *testing.T) {
+		fetchedGroup, err := repo.GetConfigurationGroup(ctx, name, version)
+		if err != nil {
+			t.Fatalf("FAIL: GetConfigurationGroup failed: %v", err)
+		}
+		if fetchedGroup.Name != name {
+			t.Errorf("FAIL: Expected name %s, got %s", name, fetchedGroup.Name)
+		}
+	})
+
+	// 3. UPDATE (Put Group)
+	t.Run("UpdateConfigurationGroup_Success", func(t *testing.T) {
+		updatedGroup := testGroup
+		updatedGroup.Configurations = append(updatedGroup.Configurations, model.Configuration{Name: "C2", Version: "v1"})
+
+		if err := repo.UpdateConfigurationGroup(ctx, updatedGroup); err != nil {
+			t.Fatalf("FAIL: UpdateConfigurationGroup failed: %v", err)
+		}
+
+		checkGroup, _ := repo.GetConfigurationGroup(ctx, name, version)
+		if len(checkGroup.Configurations) != 2 {
+			t.Errorf("FAIL: Update failed, expected 2 configurations, got %d", len(checkGroup.Configurations))
+		}
+	})
+
+	// 4. DELETE (uspešno)
+	t.Run("DeleteConfigurationGroup_Success", func(t *testing.T) {
+		if err := repo.DeleteConfigurationGroup(ctx, name, version); err != nil {
+			t.Fatalf("FAIL: DeleteConfigurationGroup failed: %v", err)
+		}
+
+		_, err := repo.GetConfigurationGroup(ctx, name, version)
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("FAIL: Expected 'not found' error after deletion, got: %v", err)
+		}
+	})
+}
+
+// ----------------------------------------------------
+// TESTOVI ZA KOPIJE I KONKURENTNOST
+// ----------------------------------------------------
+
+func TestInMemoryRepository_NoAliasing(t *testing.T) {
+	repo := setupRepo()
+	ctx := context.Background()
+
+	config := model.Configuration{
+		Name:     "service-api",
+		Version:  "v1",
+		Params:   []model.Parameter{{Key: "timeout", Value: "10s"}},
+		Metadata: model.Metadata{ClonedFrom: &model.ConfigurationRef{Name: "service-base", Version: "v1"}},
+	}
+	if err := repo.AddConfiguration(ctx, config); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	// Izmena prosledjenog i procitanog zapisa ne sme da promeni sacuvani
+	config.Params[0].Value = "changed"
+	config.Metadata.ClonedFrom.Name = "changed"
+	fetched, _ := repo.GetConfiguration(ctx, "service-api", "v1")
+	fetched.Params[0].Value = "changed"
+	fetched.Metadata.ClonedFrom.Name = "changed"
+	listed, _ := repo.ListConfigurations(ctx, "")
+	listed[0].Params[0].Value = "changed"
+	listed[0].Metadata.ClonedFrom.Name = "changed"
+	got, _ := repo.GetConfiguration(ctx, "service-api", "v1")
+	if got.Params[0].Value != "10s" {
+		t.Errorf("Stored configuration was modified through a shared slice: %+v", got.Params)
+	}
+	if got.Metadata.ClonedFrom.Name != "service-base" {
+		t.Errorf("Stored configuration was modified through a shared clone source: %+v", got.Metadata.ClonedFrom)
+	}
+
+	group := model.ConfigurationGroup{
+		Name:           "cluster",
+		Version:        "v1",
+		Configurations: []model.Configuration{config},
+		Metadata:       model.Metadata{ClonedFrom: &model.ConfigurationRef{Name: "cluster-base", Version: "v1"}},
+	}
+	if err := repo.AddConfigurationGroup(ctx, group); err != nil {
+		t.Fatalf("AddConfigurationGroup failed: %v", err)
+	}
+	group.Configurations[0].Params[0].Value = "other"
+	group.Configurations[0].Metadata.ClonedFrom.Name = "other"
+	group.Metadata.ClonedFrom.Name = "other"
+	fetchedGroup, _ := repo.GetConfigurationGroup(ctx, "cluster", "v1")
+	fetchedGroup.Metadata.ClonedFrom.Name = "other"
+	gotGroup, _ := repo.GetConfigurationGroup(ctx, "cluster", "v1")
+	if gotGroup.Configurations[0].Params[0].Value != "changed" || gotGroup.Configurations[0].Metadata.ClonedFrom.Name != "changed" {
+		t.Errorf("Stored group was modified through a shared member: %+v", gotGroup.Configurations[0])
+	}
+	if gotGroup.Metadata.ClonedFrom.Name != "cluster-base" {
+		t.Errorf("Stored group was modified through a shared clone source: %+v", gotGroup.Metadata.ClonedFrom)
+	}
+}
+
+func TestInMemoryRepository_Concurrent(t *testing.T) {
+	repo := setupRepo()
+	ctx := context.Background()
+
+	if err := repo.AddConfiguration(ctx, model.Configuration{Name: "counter", Version: "v1"}); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+
+	const workers = 8
+	const increments = 50
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range increments {
+				name := fmt.Sprintf("worker-%d", w)
+				if err := repo.AddConfiguration(ctx, model.Configuration{Name: name, Version: fmt.Sprintf("v%d", i)}); err != nil {
+					t.Errorf("AddConfiguration failed: %v", err)
+				}
+				repo.SaveIdempotencyKey(ctx, fmt.Sprintf("%s-%d", name, i))
+				// Svaki uspesan compare-and-swap povecava reviziju za tacno jedan
+				for {
+					current, err := repo.GetConfiguration(ctx, "counter", "v1")
+					if err != nil {
+						t.Errorf("GetConfiguration failed: %v", err)
+						return
+					}
+					next := current
+					next.Metadata.Revision++
+					next.Params = append(next.Params, model.Parameter{Key: name, Value: fmt.Sprint(i)})
+					if err := repo.CompareAndSwapConfiguration(ctx, next, current.Metadata.Revision); err == nil {
+						break
+					}
+				}
+				repo.ListConfigurations(ctx, "")
+			}
+		}()
+	}
+	wg.Wait()
+
+	counter, _ := repo.GetConfiguration(ctx, "counter", "v1")
+	if counter.Metadata.Revision != workers*increments || len(counter.Params) != workers*increments {
+		t.Errorf("Expected revision %d and %d params, got revision %d with %d params", workers*increments, workers*increments, counter.Metadata.Revision, len(counter.Params))
+	}
+	configs, _ := repo.ListConfigurations(ctx, "")
+	keys, _ := repo.ListIdempotencyKeys(ctx)
+	if len(configs) != workers*increments+1 || len(keys) != workers*increments {
+		t.Errorf("Expected %d configurations and %d keys, got %d and %d", workers*increments+1, workers*increments, len(configs), len(keys))
+	}
+}
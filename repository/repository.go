@@ -3,23 +3,65 @@ package repository
 import (
 	"alati_projekat/model"
 	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+// ErrRevisionConflict is returned by compare-and-swap updates when the stored record
+// no longer has the expected revision.
+var ErrRevisionConflict = errors.New("revision conflict: record was modified concurrently")
+
 // U fajlu gde je definisan repository.Repository
+//
+// Add and Update store the record whether or not it exists, and Delete of a missing record
+// succeeds, as with a Consul KV put and delete. Writes that depend on the stored state use
+// compare-and-swap or Transact.
 type Repository interface {
 	// CONFIGURATIONS
 	AddConfiguration(ctx context.Context, config model.Configuration) error
 	GetConfiguration(ctx context.Context, name, version string) (model.Configuration, error)
 	UpdateConfiguration(ctx context.Context, config model.Configuration) error
+	// CompareAndSwapConfiguration stores config only if the stored record still has expectedRevision.
+	CompareAndSwapConfiguration(ctx context.Context, config model.Configuration, expectedRevision int64) error
 	DeleteConfiguration(ctx context.Context, name, version string) error
+	// ListConfigurations returns every version of the named configuration, or all configurations when name is empty.
+	ListConfigurations(ctx context.Context, name string) ([]model.Configuration, error)
 
 	// CONFIGURATION GROUPS
 	AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup) error
 	GetConfigurationGroup(ctx context.Context, name, version string) (model.ConfigurationGroup, error)
 	UpdateConfigurationGroup(ctx context.Context, group model.ConfigurationGroup) error
+	// CompareAndSwapConfigurationGroup stores group only if the stored record still has expectedRevision.
+	CompareAndSwapConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, expectedRevision int64) error
 	DeleteConfigurationGroup(ctx context.Context, name, version string) error
+	// ListConfigurationGroups returns every version of the named group, or all groups when name is empty.
+	ListConfigurationGroups(ctx context.Context, name string) ([]model.ConfigurationGroup, error)
+
+	// TRANSACTIONS
+	// Transact applies all ops atomically. It fails with ErrRevisionConflict, storing nothing,
+	// when a created record already exists or an updated or deleted one has another revision
+	// or no longer exists.
+	Transact(ctx context.Context, ops []TxnOp) error
+
+	// WATCH
+	// WatchConfiguration blocks until the configuration is written after index, wait elapses or
+	// ctx is done, and returns it with the index to pass to the next call. An index of 0 returns
+	// at once. A missing configuration is reported as "not found" together with the index.
+	WatchConfiguration(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.Configuration, uint64, error)
+	// WatchConfigurationGroup is the group counterpart of WatchConfiguration.
+	WatchConfigurationGroup(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.ConfigurationGroup, uint64, error)
+
+	// WEBHOOKS
+	AddWebhook(ctx context.Context, hook model.Webhook) error
+	GetWebhook(ctx context.Context, id uuid.UUID) (model.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	ListWebhooks(ctx context.Context) ([]model.Webhook, error)
 
 	// IDEMPOTENCY
 	CheckIdempotencyKey(ctx context.Context, key string) (bool, error)
 	SaveIdempotencyKey(ctx context.Context, key string) error // Treba da vrati error, ne void
+	// ListIdempotencyKeys returns every processed idempotency key.
+	ListIdempotencyKeys(ctx context.Context) ([]string, error)
 }
@@ -0,0 +1,618 @@
+// Package repotest is a conformance suite for repository.Repository implementations.
+//
+// A backend runs the whole suite with one call from its tests:
+//
+//	func TestMyRepository_Conformance(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) repository.Repository { return NewMyRepository() })
+//	}
+//
+// The suite checks the behaviour the services rely on and that every backend shares,
+// including the put semantics of plain writes: adds of existing records and updates of
+// missing ones store the record, deletes of missing ones succeed. Durability is left to the
+// backends' own tests. Every subtest uses names of its own, so a backend backed by a shared
+// store such as a Consul agent may return the same store for all of them.
+package repotest
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/repository"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Run runs the conformance suite, calling newRepo for the repository of each subtest.
+func Run(t *testing.T, newRepo func(t *testing.T) repository.Repository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo repository.Repository, name string)
+	}{
+		{"ConfigurationCRUD", testConfigurationCRUD},
+		{"GroupCRUD", testGroupCRUD},
+		{"NotFound", testNotFound},
+		{"PlainWrites", testPlainWrites},
+		{"CompareAndSwap", testCompareAndSwap},
+		{"Transact", testTransact},
+		{"Listing", testListing},
+		{"Watch", testWatch},
+		{"Webhooks", testWebhooks},
+		{"IdempotencyKeys", testIdempotencyKeys},
+		{"Concurrency", testConcurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepo(t), uniqueName(tt.name))
+		})
+	}
+}
+
+// uniqueName returns a record name no other subtest or run uses.
+func uniqueName(test string) string {
+	return "conformance-" + strings.ToLower(test) + "-" + uuid.NewString()[:8]
+}
+
+func newConfiguration(name, version string, revision int64) model.Configuration {
+	return model.Configuration{
+		ID:       uuid.New(),
+		Name:     name,
+		Version:  version,
+		Params:   []model.Parameter{{Key: "timeout", Value: "10s"}, {Key: "retries", Value: "3"}},
+		Labels:   []model.Parameter{{Key: "team", Value: "platform"}},
+		Metadata: model.Metadata{Revision: revision},
+	}
+}
+
+func newGroup(name, version string, revision int64) model.ConfigurationGroup {
+	return model.ConfigurationGroup{
+		ID:             uuid.New(),
+		Name:           name,
+		Version:        version,
+		Configurations: []model.Configuration{newConfiguration(name+"-member", "v1", 1)},
+		Metadata:       model.Metadata{Revision: revision},
+	}
+}
+
+// requireNotFound fails unless err reports a missing record the way the services detect it.
+func requireNotFound(t *testing.T, what string, err error) {
+	t.Helper()
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("%s: expected a 'not found' error, got %v", what, err)
+	}
+}
+
+func testConfigurationCRUD(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+	config := newConfiguration(name, "v1", 1)
+	config.Description = "conformance"
+
+	if err := repo.AddConfiguration(ctx, config); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	got, err := repo.GetConfiguration(ctx, name, "v1")
+	if err != nil {
+		t.Fatalf("GetConfiguration failed: %v", err)
+	}
+	if got.ID != config.ID || got.Description != config.Description || !slices.Equal(got.Params, config.Params) || !slices.Equal(got.Labels, config.Labels) {
+		t.Errorf("Expected the stored configuration %+v, got %+v", config, got)
+	}
+
+	config.Params = []model.Parameter{{Key: "timeout", Value: "20s"}}
+	config.Metadata.Revision = 2
+	if err := repo.UpdateConfiguration(ctx, config); err != nil {
+		t.Fatalf("UpdateConfiguration failed: %v", err)
+	}
+	got, err = repo.GetConfiguration(ctx, name, "v1")
+	if err != nil {
+		t.Fatalf("GetConfiguration after update failed: %v", err)
+	}
+	if !slices.Equal(got.Params, config.Params) || got.Metadata.Revision != 2 {
+		t.Errorf("Expected the updated configuration, got params %+v at revision %d", got.Params, got.Metadata.Revision)
+	}
+
+	if err := repo.DeleteConfiguration(ctx, name, "v1"); err != nil {
+		t.Fatalf("DeleteConfiguration failed: %v", err)
+	}
+	_, err = repo.GetConfiguration(ctx, name, "v1")
+	requireNotFound(t, "GetConfiguration after delete", err)
+}
+
+func testGroupCRUD(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+	group := newGroup(name, "v1", 1)
+
+	if err := repo.AddConfigurationGroup(ctx, group); err != nil {
+		t.Fatalf("AddConfigurationGroup failed: %v", err)
+	}
+	got, err := repo.GetConfigurationGroup(ctx, name, "v1")
+	if err != nil {
+		t.Fatalf("GetConfigurationGroup failed: %v", err)
+	}
+	if got.ID != group.ID || len(got.Configurations) != 1 || !slices.Equal(got.Configurations[0].Params, group.Configurations[0].Params) {
+		t.Errorf("Expected the stored group %+v, got %+v", group, got)
+	}
+
+	group.Configurations = append(group.Configurations, newConfiguration(name+"-second", "v1", 1))
+	group.Metadata.Revision = 2
+	if err := repo.UpdateConfigurationGroup(ctx, group); err != nil {
+		t.Fatalf("UpdateConfigurationGroup failed: %v", err)
+	}
+	got, err = repo.GetConfigurationGroup(ctx, name, "v1")
+	if err != nil {
+		t.Fatalf("GetConfigurationGroup after update failed: %v", err)
+	}
+	if len(got.Configurations) != 2 || got.Metadata.Revision != 2 {
+		t.Errorf("Expected 2 configurations at revision 2, got %d at revision %d", len(got.Configurations), got.Metadata.Revision)
+	}
+
+	if err := repo.DeleteConfigurationGroup(ctx, name, "v1"); err != nil {
+		t.Fatalf("DeleteConfigurationGroup failed: %v", err)
+	}
+	_, err = repo.GetConfigurationGroup(ctx, name, "v1")
+	requireNotFound(t, "GetConfigurationGroup after delete", err)
+}
+
+func testNotFound(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+
+	_, err := repo.GetConfiguration(ctx, name, "v1")
+	requireNotFound(t, "GetConfiguration", err)
+	_, err = repo.GetConfigurationGroup(ctx, name, "v1")
+	requireNotFound(t, "GetConfigurationGroup", err)
+	_, err = repo.GetWebhook(ctx, uuid.New())
+	requireNotFound(t, "GetWebhook", err)
+
+	err = repo.CompareAndSwapConfiguration(ctx, newConfiguration(name, "v1", 2), 1)
+	requireNotFound(t, "CompareAndSwapConfiguration", err)
+	err = repo.CompareAndSwapConfigurationGroup(ctx, newGroup(name, "v1", 2), 1)
+	requireNotFound(t, "CompareAndSwapConfigurationGroup", err)
+
+	config := newConfiguration(name, "v1", 2)
+	err = repo.Transact(ctx, []repository.TxnOp{{Verb: repository.TxnUpdate, Configuration: &config, ExpectedRevision: 1}})
+	requireNotFound(t, "Transact update", err)
+	if !errors.Is(err, repository.ErrRevisionConflict) {
+		t.Errorf("Transact update: expected ErrRevisionConflict for a missing record, got %v", err)
+	}
+
+	_, _, err = repo.WatchConfiguration(ctx, name, "v1", 0, time.Second)
+	requireNotFound(t, "WatchConfiguration", err)
+	_, _, err = repo.WatchConfigurationGroup(ctx, name, "v1", 0, time.Second)
+	requireNotFound(t, "WatchConfigurationGroup", err)
+
+	configs, err := repo.ListConfigurations(ctx, name)
+	if err != nil || len(configs) != 0 {
+		t.Errorf("Expected no versions of a missing configuration, got %d (%v)", len(configs), err)
+	}
+	groups, err := repo.ListConfigurationGroups(ctx, name)
+	if err != nil || len(groups) != 0 {
+		t.Errorf("Expected no versions of a missing group, got %d (%v)", len(groups), err)
+	}
+}
+
+// testPlainWrites checks that Add, Update and Delete do not depend on whether the record exists.
+func testPlainWrites(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+
+	config := newConfiguration(name, "v1", 1)
+	if err := repo.UpdateConfiguration(ctx, config); err != nil {
+		t.Fatalf("UpdateConfiguration of a missing configuration failed: %v", err)
+	}
+	config.Params = []model.Parameter{{Key: "timeout", Value: "20s"}}
+	config.Metadata.Revision = 2
+	if err := repo.AddConfiguration(ctx, config); err != nil {
+		t.Fatalf("AddConfiguration of an existing configuration failed: %v", err)
+	}
+	if got, err := repo.GetConfiguration(ctx, name, "v1"); err != nil || !slices.Equal(got.Params, config.Params) || got.Metadata.Revision != 2 {
+		t.Errorf("Expected the add to overwrite, got %+v (%v)", got, err)
+	}
+
+	group := newGroup(name, "v1", 1)
+	if err := repo.UpdateConfigurationGroup(ctx, group); err != nil {
+		t.Fatalf("UpdateConfigurationGroup of a missing group failed: %v", err)
+	}
+	group.Metadata.Revision = 2
+	if err := repo.AddConfigurationGroup(ctx, group); err != nil {
+		t.Fatalf("AddConfigurationGroup of an existing group failed: %v", err)
+	}
+	if got, err := repo.GetConfigurationGroup(ctx, name, "v1"); err != nil || got.Metadata.Revision != 2 {
+		t.Errorf("Expected the add to overwrite the group, got %+v (%v)", got, err)
+	}
+
+	for range 2 {
+		if err := repo.DeleteConfiguration(ctx, name, "v1"); err != nil {
+			t.Errorf("DeleteConfiguration failed: %v", err)
+		}
+		if err := repo.DeleteConfigurationGroup(ctx, name, "v1"); err != nil {
+			t.Errorf("DeleteConfigurationGroup failed: %v", err)
+		}
+	}
+	_, err := repo.GetConfiguration(ctx, name, "v1")
+	requireNotFound(t, "GetConfiguration after delete", err)
+}
+
+func testCompareAndSwap(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+
+	config := newConfiguration(name, "v1", 1)
+	if err := repo.AddConfiguration(ctx, config); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	config.Metadata.Revision = 2
+	if err := repo.CompareAndSwapConfiguration(ctx, config, 1); err != nil {
+		t.Fatalf("CompareAndSwapConfiguration failed: %v", err)
+	}
+	stale := config
+	stale.Params = nil
+	if err := repo.CompareAndSwapConfiguration(ctx, stale, 1); !errors.Is(err, repository.ErrRevisionConflict) {
+		t.Errorf("Expected ErrRevisionConflict for a stale configuration revision, got %v", err)
+	}
+	if got, _ := repo.GetConfiguration(ctx, name, "v1"); got.Metadata.Revision != 2 || len(got.Params) == 0 {
+		t.Errorf("A conflicting swap must not write, got %+v", got)
+	}
+
+	group := newGroup(name, "v1", 1)
+	if err := repo.AddConfigurationGroup(ctx, group); err != nil {
+		t.Fatalf("AddConfigurationGroup failed: %v", err)
+	}
+	group.Metadata.Revision = 2
+	if err := repo.CompareAndSwapConfigurationGroup(ctx, group, 1); err != nil {
+		t.Fatalf("CompareAndSwapConfigurationGroup failed: %v", err)
+	}
+	if err := repo.CompareAndSwapConfigurationGroup(ctx, group, 1); !errors.Is(err, repository.ErrRevisionConflict) {
+		t.Errorf("Expected ErrRevisionConflict for a stale group revision, got %v", err)
+	}
+	if got, _ := repo.GetConfigurationGroup(ctx, name, "v1"); got.Metadata.Revision != 2 {
+		t.Errorf("Expected group revision 2, got %d", got.Metadata.Revision)
+	}
+}
+
+func testTransact(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+
+	existing := newConfiguration(name, "v1", 1)
+	if err := repo.AddConfiguration(ctx, existing); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	created := newConfiguration(name, "v2", 1)
+	group := newGroup(name, "v1", 1)
+	updated := existing
+	updated.Metadata.Revision = 2
+
+	tests := []struct {
+		name string
+		ops  []repository.TxnOp
+	}{
+		{"StaleUpdate", []repository.TxnOp{
+			{Verb: repository.TxnCreate, Configuration: &created},
+			{Verb: repository.TxnUpdate, Configuration: &updated, ExpectedRevision: 5},
+		}},
+		{"CreateExisting", []repository.TxnOp{
+			{Verb: repository.TxnCreate, Group: &group},
+			{Verb: repository.TxnCreate, Configuration: &existing},
+		}},
+		{"StaleDelete", []repository.TxnOp{
+			{Verb: repository.TxnCreate, Configuration: &created},
+			{Verb: repository.TxnDelete, Configuration: &existing, ExpectedRevision: 5},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repo.Transact(ctx, tt.ops); !errors.Is(err, repository.ErrRevisionConflict) {
+				t.Fatalf("Expected ErrRevisionConflict, got %v", err)
+			}
+			// Nothing of a failed transaction may be stored
+			if _, err := repo.GetConfiguration(ctx, name, "v2"); err == nil {
+				t.Error("Failed transaction created a configuration")
+			}
+			if _, err := repo.GetConfigurationGroup(ctx, name, "v1"); err == nil {
+				t.Error("Failed transaction created a group")
+			}
+			if got, _ := repo.GetConfiguration(ctx, name, "v1"); got.Metadata.Revision != 1 {
+				t.Errorf("Failed transaction changed the existing configuration to revision %d", got.Metadata.Revision)
+			}
+		})
+	}
+
+	err := repo.Transact(ctx, []repository.TxnOp{
+		{Verb: repository.TxnCreate, Configuration: &created},
+		{Verb: repository.TxnCreate, Group: &group},
+		{Verb: repository.TxnUpdate, Configuration: &updated, ExpectedRevision: 1},
+	})
+	if err != nil {
+		t.Fatalf("Transact failed: %v", err)
+	}
+	if got, err := repo.GetConfiguration(ctx, name, "v1"); err != nil || got.Metadata.Revision != 2 {
+		t.Errorf("Expected the updated configuration at revision 2, got %d (%v)", got.Metadata.Revision, err)
+	}
+	if _, err := repo.GetConfiguration(ctx, name, "v2"); err != nil {
+		t.Errorf("Expected the created configuration, got %v", err)
+	}
+	if _, err := repo.GetConfigurationGroup(ctx, name, "v1"); err != nil {
+		t.Errorf("Expected the created group, got %v", err)
+	}
+
+	err = repo.Transact(ctx, []repository.TxnOp{
+		{Verb: repository.TxnDelete, Configuration: &updated, ExpectedRevision: 2},
+		{Verb: repository.TxnDelete, Group: &group, ExpectedRevision: 1},
+	})
+	if err != nil {
+		t.Fatalf("Transact delete failed: %v", err)
+	}
+	_, err = repo.GetConfiguration(ctx, name, "v1")
+	requireNotFound(t, "GetConfiguration after transactional delete", err)
+	_, err = repo.GetConfigurationGroup(ctx, name, "v1")
+	requireNotFound(t, "GetConfigurationGroup after transactional delete", err)
+
+	if err := repo.Transact(ctx, make([]repository.TxnOp, repository.MaxTxnOps+1)); !errors.Is(err, repository.ErrTxnTooLarge) {
+		t.Errorf("Expected ErrTxnTooLarge, got %v", err)
+	}
+}
+
+func testListing(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+
+	// Names sharing a prefix must not be listed with each other
+	versions := []string{"v2", "v1", "v10"}
+	for _, n := range []string{name, name + "-other"} {
+		for _, v := range versions {
+			if err := repo.AddConfiguration(ctx, newConfiguration(n, v, 1)); err != nil {
+				t.Fatalf("AddConfiguration failed: %v", err)
+			}
+			if err := repo.AddConfigurationGroup(ctx, newGroup(n, v, 1)); err != nil {
+				t.Fatalf("AddConfigurationGroup failed: %v", err)
+			}
+		}
+	}
+
+	want := []string{"v1", "v10", "v2"}
+	configs, err := repo.ListConfigurations(ctx, name)
+	if err != nil {
+		t.Fatalf("ListConfigurations failed: %v", err)
+	}
+	var got []string
+	for _, c := range configs {
+		if c.Name != name {
+			t.Errorf("Listing %s returned configuration %s", name, c.Name)
+		}
+		got = append(got, c.Version)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected versions %v in key order, got %v", want, got)
+	}
+
+	groups, err := repo.ListConfigurationGroups(ctx, name)
+	if err != nil {
+		t.Fatalf("ListConfigurationGroups failed: %v", err)
+	}
+	got = nil
+	for _, g := range groups {
+		if g.Name != name {
+			t.Errorf("Listing %s returned group %s", name, g.Name)
+		}
+		got = append(got, g.Version)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected group versions %v in key order, got %v", want, got)
+	}
+
+	// Listing everything includes both names
+	all, err := repo.ListConfigurations(ctx, "")
+	if err != nil {
+		t.Fatalf("ListConfigurations of everything failed: %v", err)
+	}
+	count := 0
+	for _, c := range all {
+		if strings.HasPrefix(c.Name, name) {
+			count++
+		}
+	}
+	if count != 2*len(versions) {
+		t.Errorf("Expected %d configurations of this test in the full listing, got %d", 2*len(versions), count)
+	}
+	allGroups, err := repo.ListConfigurationGroups(ctx, "")
+	if err != nil {
+		t.Fatalf("ListConfigurationGroups of everything failed: %v", err)
+	}
+	count = 0
+	for _, g := range allGroups {
+		if strings.HasPrefix(g.Name, name) {
+			count++
+		}
+	}
+	if count != 2*len(versions) {
+		t.Errorf("Expected %d groups of this test in the full listing, got %d", 2*len(versions), count)
+	}
+}
+
+func testWatch(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+
+	_, index, _ := repo.WatchConfiguration(ctx, name, "v1", 0, time.Second)
+	if index == 0 {
+		t.Fatal("Expected a non-zero index from a non-blocking watch")
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		repo.AddConfiguration(ctx, newConfiguration(name, "v1", 1))
+	}()
+	config, next, err := repo.WatchConfiguration(ctx, name, "v1", index, 10*time.Second)
+	if err != nil || next <= index || config.Name != name {
+		t.Fatalf("Expected the created configuration at an index after %d, got %d %+v (%v)", index, next, config, err)
+	}
+
+	// Without a change the watch returns the same record once wait elapses
+	start := time.Now()
+	_, unchanged, err := repo.WatchConfiguration(ctx, name, "v1", next, 200*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchConfiguration failed: %v", err)
+	}
+	if unchanged < next {
+		t.Errorf("Expected an index of at least %d, got %d", next, unchanged)
+	}
+	if time.Since(start) < 100*time.Millisecond {
+		t.Errorf("Watch without a change returned after %s", time.Since(start))
+	}
+
+	_, index, _ = repo.WatchConfigurationGroup(ctx, name, "v1", 0, time.Second)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		repo.AddConfigurationGroup(ctx, newGroup(name, "v1", 1))
+	}()
+	group, next, err := repo.WatchConfigurationGroup(ctx, name, "v1", index, 10*time.Second)
+	if err != nil || next <= index || group.Name != name {
+		t.Fatalf("Expected the created group at an index after %d, got %d %+v (%v)", index, next, group, err)
+	}
+}
+
+func testWebhooks(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+
+	hook := model.Webhook{
+		ID:        uuid.New(),
+		URL:       "https://example.com/hooks/" + name,
+		Events:    []model.EventType{model.EventCreated, model.EventDeleted},
+		Labels:    "app:" + name,
+		Secret:    "secret",
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	if err := repo.AddWebhook(ctx, hook); err != nil {
+		t.Fatalf("AddWebhook failed: %v", err)
+	}
+	got, err := repo.GetWebhook(ctx, hook.ID)
+	if err != nil {
+		t.Fatalf("GetWebhook failed: %v", err)
+	}
+	if got.URL != hook.URL || got.Labels != hook.Labels || got.Secret != hook.Secret || !slices.Equal(got.Events, hook.Events) || !got.CreatedAt.Equal(hook.CreatedAt) {
+		t.Errorf("Expected the stored webhook %+v, got %+v", hook, got)
+	}
+
+	hooks, err := repo.ListWebhooks(ctx)
+	if err != nil {
+		t.Fatalf("ListWebhooks failed: %v", err)
+	}
+	if !slices.ContainsFunc(hooks, func(h model.Webhook) bool { return h.ID == hook.ID }) {
+		t.Errorf("Expected webhook %s in the listing", hook.ID)
+	}
+
+	if err := repo.DeleteWebhook(ctx, hook.ID); err != nil {
+		t.Fatalf("DeleteWebhook failed: %v", err)
+	}
+	_, err = repo.GetWebhook(ctx, hook.ID)
+	requireNotFound(t, "GetWebhook after delete", err)
+}
+
+func testIdempotencyKeys(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+
+	if found, err := repo.CheckIdempotencyKey(ctx, name); err != nil || found {
+		t.Fatalf("Expected an unknown key, got %v (%v)", found, err)
+	}
+	if err := repo.SaveIdempotencyKey(ctx, name); err != nil {
+		t.Fatalf("SaveIdempotencyKey failed: %v", err)
+	}
+	// Saving a key twice is not an error
+	if err := repo.SaveIdempotencyKey(ctx, name); err != nil {
+		t.Fatalf("Saving a key again failed: %v", err)
+	}
+	if found, err := repo.CheckIdempotencyKey(ctx, name); err != nil || !found {
+		t.Errorf("Expected a saved key, got %v (%v)", found, err)
+	}
+
+	keys, err := repo.ListIdempotencyKeys(ctx)
+	if err != nil {
+		t.Fatalf("ListIdempotencyKeys failed: %v", err)
+	}
+	if !slices.Contains(keys, name) {
+		t.Errorf("Expected key %s in the listing", name)
+	}
+}
+
+func testConcurrency(t *testing.T, repo repository.Repository, name string) {
+	ctx := context.Background()
+	const workers = 8
+	const increments = 10
+
+	if err := repo.AddConfiguration(ctx, newConfiguration(name, "counter", 0)); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+
+	// Every successful compare-and-swap moves the revision by exactly one, so lost
+	// updates show up as a revision lower than the number of increments.
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range increments {
+				if err := repo.AddConfiguration(ctx, newConfiguration(name, fmt.Sprintf("w%d-%d", w, i), 1)); err != nil {
+					t.Errorf("AddConfiguration failed: %v", err)
+				}
+				if err := repo.SaveIdempotencyKey(ctx, fmt.Sprintf("%s-%d-%d", name, w, i)); err != nil {
+					t.Errorf("SaveIdempotencyKey failed: %v", err)
+				}
+				for {
+					current, err := repo.GetConfiguration(ctx, name, "counter")
+					if err != nil {
+						t.Errorf("GetConfiguration failed: %v", err)
+						return
+					}
+					next := current
+					next.Metadata.Revision++
+					err = repo.CompareAndSwapConfiguration(ctx, next, current.Metadata.Revision)
+					if err == nil {
+						break
+					}
+					if !errors.Is(err, repository.ErrRevisionConflict) {
+						t.Errorf("CompareAndSwapConfiguration failed: %v", err)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	counter, err := repo.GetConfiguration(ctx, name, "counter")
+	if err != nil {
+		t.Fatalf("GetConfiguration failed: %v", err)
+	}
+	if counter.Metadata.Revision != workers*increments {
+		t.Errorf("Expected revision %d after concurrent swaps, got %d", workers*increments, counter.Metadata.Revision)
+	}
+	configs, err := repo.ListConfigurations(ctx, name)
+	if err != nil || len(configs) != workers*increments+1 {
+		t.Errorf("Expected %d configurations, got %d (%v)", workers*increments+1, len(configs), err)
+	}
+
+	// Of concurrent transactions creating the same record exactly one succeeds
+	var created sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for range workers {
+		created.Add(1)
+		go func() {
+			defer created.Done()
+			group := newGroup(name, "contended", 1)
+			err := repo.Transact(ctx, []repository.TxnOp{{Verb: repository.TxnCreate, Group: &group}})
+			switch {
+			case err == nil:
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			case !errors.Is(err, repository.ErrRevisionConflict):
+				t.Errorf("Transact failed: %v", err)
+			}
+		}()
+	}
+	created.Wait()
+	if succeeded != 1 {
+		t.Errorf("Expected exactly one of %d concurrent creates to succeed, got %d", workers, succeeded)
+	}
+}
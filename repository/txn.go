@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"alati_projekat/model"
+	"errors"
+)
+
+// MaxTxnOps is the number of operations Consul accepts in a single transaction.
+const MaxTxnOps = 64
+
+// ErrTxnTooLarge is returned by Transact when a transaction has more than MaxTxnOps operations.
+var ErrTxnTooLarge = errors.New("transaction has too many operations")
+
+// TxnVerb is the kind of write performed by a TxnOp.
+type TxnVerb string
+
+const (
+	// TxnCreate stores a record that must not exist yet.
+	TxnCreate TxnVerb = "create"
+	// TxnUpdate replaces a record that must still have ExpectedRevision.
+	TxnUpdate TxnVerb = "update"
+	// TxnDelete removes a record that must still have ExpectedRevision.
+	TxnDelete TxnVerb = "delete"
+)
+
+// TxnOp is a single write of a transaction. Exactly one of Configuration and Group is set;
+// for deletes only its name and version are used.
+type TxnOp struct {
+	Verb             TxnVerb
+	Configuration    *model.Configuration
+	Group            *model.ConfigurationGroup
+	ExpectedRevision int64
+}
+
+// Name returns the name of the record the operation writes.
+func (op TxnOp) Name() string {
+	if op.Group != nil {
+		return op.Group.Name
+	}
+	return op.Configuration.Name
+}
+
+// Version returns the version of the record the operation writes.
+func (op TxnOp) Version() string {
+	if op.Group != nil {
+		return op.Group.Version
+	}
+	return op.Configuration.Version
+}
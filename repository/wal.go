@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Files of the data directory of a FileRepository.
+const (
+	walFile      = "wal.log"
+	snapshotFile = "snapshot.json"
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// walOp is a single key write of a WAL record.
+type walOp struct {
+	Key    string          `json:"key"`
+	Value  json.RawMessage `json:"value,omitempty"`
+	Delete bool            `json:"delete,omitempty"`
+}
+
+// walRecord is one atomic write: a single put or delete, or all operations of a transaction.
+// Each record is one line of the log, "<crc32c of the JSON in hex> <JSON>\n", so a record
+// torn by a crash is recognised and dropped on recovery.
+type walRecord struct {
+	Seq uint64  `json:"seq"`
+	Ops []walOp `json:"ops"`
+}
+
+// fileSnapshot is the compacted state of the store: every key after the record with Seq.
+type fileSnapshot struct {
+	Seq  uint64                     `json:"seq"`
+	Data map[string]json.RawMessage `json:"data"`
+}
+
+func encodeRecord(rec walRecord) ([]byte, error) {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	line := make([]byte, 0, 9+len(data)+1)
+	line = fmt.Appendf(line, "%08x ", crc32.Checksum(data, crcTable))
+	line = append(line, data...)
+	return append(line, '\n'), nil
+}
+
+func decodeRecord(line []byte) (walRecord, error) {
+	var rec walRecord
+	sum, data, ok := bytes.Cut(bytes.TrimSuffix(line, []byte("\n")), []byte(" "))
+	if !ok || len(sum) != 8 {
+		return rec, errors.New("malformed record")
+	}
+	want, err := strconv.ParseUint(string(sum), 16, 32)
+	if err != nil {
+		return rec, errors.New("malformed checksum")
+	}
+	if crc32.Checksum(data, crcTable) != uint32(want) {
+		return rec, errors.New("checksum mismatch")
+	}
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return rec, err
+	}
+	return rec, nil
+}
+
+func applyOps(data map[string]json.RawMessage, ops []walOp) {
+	for _, op := range ops {
+		if op.Delete {
+			delete(data, op.Key)
+		} else {
+			data[op.Key] = op.Value
+		}
+	}
+}
+
+// loadSnapshot reads the compacted state of dir; a missing snapshot is an empty store.
+func loadSnapshot(dir string) (fileSnapshot, error) {
+	snap := fileSnapshot{Data: make(map[string]json.RawMessage)}
+	data, err := os.ReadFile(filepath.Join(dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return snap, nil
+	}
+	if err != nil {
+		return snap, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, fmt.Errorf("failed to decode snapshot %s: %w", filepath.Join(dir, snapshotFile), err)
+	}
+	if snap.Data == nil {
+		snap.Data = make(map[string]json.RawMessage)
+	}
+	return snap, nil
+}
+
+// replayWAL applies the records of wal written after snap to it and returns the number of
+// records and the offset of the end of the last valid one. Records up to snap.Seq are
+// already part of the snapshot, which happens after a crash during compaction.
+func replayWAL(wal io.Reader, snap *fileSnapshot) (records int, size int64, err error) {
+	r := bufio.NewReader(wal)
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				log.Printf("File repository: dropping incomplete WAL record at offset %d", size)
+			}
+			return records, size, nil
+		}
+		if err != nil {
+			return records, size, fmt.Errorf("failed to read WAL: %w", err)
+		}
+		rec, err := decodeRecord(line)
+		if err != nil {
+			// Only the last record can be torn by a crash. A bad record followed by valid
+			// ones is corruption, and truncating there would lose the records after it.
+			if offset, ok := validRecordAfter(r, size+int64(len(line))); ok {
+				return records, size, fmt.Errorf("WAL record at offset %d is corrupt (%v) but followed by a valid record at offset %d", size, err, offset)
+			}
+			log.Printf("File repository: dropping WAL from offset %d: %v", size, err)
+			return records, size, nil
+		}
+		size += int64(len(line))
+		if rec.Seq <= snap.Seq {
+			continue
+		}
+		if rec.Seq != snap.Seq+1 {
+			return records, size, fmt.Errorf("WAL record %d follows %d: records are missing", rec.Seq, snap.Seq)
+		}
+		applyOps(snap.Data, rec.Ops)
+		snap.Seq = rec.Seq
+		records++
+	}
+}
+
+// validRecordAfter reports whether r, which starts at offset, holds a valid record, and
+// returns the offset of the first one.
+func validRecordAfter(r *bufio.Reader, offset int64) (int64, bool) {
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 && err == nil {
+			if _, derr := decodeRecord(line); derr == nil {
+				return offset, true
+			}
+		}
+		if err != nil {
+			return 0, false
+		}
+		offset += int64(len(line))
+	}
+}
+
+// writeSnapshot atomically replaces the snapshot of dir with snap.
+func writeSnapshot(dir string, snap fileSnapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, snapshotFile+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, snapshotFile)); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir makes a rename in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
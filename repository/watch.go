@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Watcher gives backends without native blocking queries the semantics of Consul's. Every
+// write advances a global modification index and records it as the index of the written
+// key; readers pass the index they last saw and block until the key is written again.
+// Indexes start at 1, so 0 always means "do not block".
+type Watcher struct {
+	mu      sync.Mutex
+	index   uint64
+	keys    map[string]uint64
+	changed chan struct{} // closed and replaced on every write
+}
+
+func NewWatcher() *Watcher {
+	return &Watcher{index: 1, keys: make(map[string]uint64), changed: make(chan struct{})}
+}
+
+// Touch records a write to key and wakes up every blocked reader.
+func (w *Watcher) Touch(key string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.index++
+	w.keys[key] = w.index
+	close(w.changed)
+	w.changed = make(chan struct{})
+}
+
+// Index returns the modification index of key. Like Consul, keys that were never written
+// report the global index, so a reader can wait for them to be created.
+func (w *Watcher) Index(key string) uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	index, _ := w.current(key)
+	return index
+}
+
+func (w *Watcher) current(key string) (uint64, <-chan struct{}) {
+	if index, ok := w.keys[key]; ok {
+		return index, w.changed
+	}
+	return w.index, w.changed
+}
+
+// Wait blocks until key is written after index, wait elapses or ctx is done, and returns
+// the current index of key. An index of 0, or one ahead of the watcher, returns at once.
+func (w *Watcher) Wait(ctx context.Context, key string, index uint64, wait time.Duration) uint64 {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		w.mu.Lock()
+		current, changed := w.current(key)
+		w.mu.Unlock()
+		if index == 0 || current != index {
+			return current
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return current
+		case <-ctx.Done():
+			return current
+		}
+	}
+}
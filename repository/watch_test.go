@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"alati_projekat/model"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Wait(t *testing.T) {
+	w := NewWatcher()
+	ctx := context.Background()
+
+	if got := w.Wait(ctx, "a", 0, time.Hour); got != 1 {
+		t.Errorf("Expected index 1 for an empty watcher, got %d", got)
+	}
+	w.Touch("a")
+	w.Touch("b")
+	if got := w.Index("a"); got != 2 {
+		t.Errorf("Expected index 2 for a, got %d", got)
+	}
+	// Ključ koji nikad nije upisan vraća globalni indeks
+	if got := w.Index("missing"); got != 3 {
+		t.Errorf("Expected global index 3 for a missing key, got %d", got)
+	}
+
+	// Upis drugog ključa ne budi čitaoca
+	start := time.Now()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		w.Touch("b")
+	}()
+	if got := w.Wait(ctx, "a", 2, 100*time.Millisecond); got != 2 {
+		t.Errorf("Expected unchanged index 2, got %d", got)
+	}
+	if time.Since(start) < 100*time.Millisecond {
+		t.Error("Wait returned before the timeout without a change")
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		w.Touch("a")
+	}()
+	if got := w.Wait(ctx, "a", 2, time.Minute); got != 5 {
+		t.Errorf("Expected index 5 after the write, got %d", got)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if got := w.Wait(ctx, "a", 5, time.Minute); got != 5 {
+		t.Errorf("Expected a cancelled wait to return index 5, got %d", got)
+	}
+}
+
+func TestInMemoryRepository_WatchConfiguration(t *testing.T) {
+	repo := NewInMemoryRepository()
+	ctx := context.Background()
+
+	_, index, err := repo.WatchConfiguration(ctx, "service-api", "v1", 0, time.Second)
+	if err == nil {
+		t.Fatal("Expected not found for a missing configuration")
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		repo.AddConfiguration(ctx, model.Configuration{Name: "service-api", Version: "v1"})
+	}()
+	config, next, err := repo.WatchConfiguration(ctx, "service-api", "v1", index, time.Minute)
+	if err != nil || next <= index || config.Name != "service-api" {
+		t.Errorf("Expected the created configuration at an index after %d, got %d %+v (%v)", index, next, config, err)
+	}
+}
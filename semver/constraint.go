@@ -0,0 +1,309 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidConstraint is returned for version selectors that cannot be parsed.
+var ErrInvalidConstraint = errors.New("invalid version constraint")
+
+// Latest is the selector that matches the highest available version.
+const Latest = "latest"
+
+type comparator struct {
+	op string // one of =, >, >=, <, <=
+	v  Version
+}
+
+func (c comparator) matches(v Version) bool {
+	cmp := Compare(v, c.v)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// Constraint is a parsed version selector such as "latest", "^1.2", "~1.2.3", "1.x" or ">=1.0.0 <2.0.0 || 3.x".
+// Comparators separated by spaces or commas must all match; sets separated by "||" are alternatives.
+type Constraint struct {
+	raw  string
+	sets [][]comparator
+}
+
+// IsSelector reports whether a version path segment is a selector rather than a concrete version.
+// Only "latest", operator prefixes and wildcards are treated as selectors, so that stored versions
+// like "1.2" keep being addressed literally.
+func IsSelector(s string) bool {
+	if s == Latest {
+		return true
+	}
+	if strings.ContainsAny(s, "^~<>=*| ,") {
+		return true
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(s, "v"), ".") {
+		if part == "x" || part == "X" {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseConstraint parses a version selector.
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{raw: s}
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return Constraint{}, fmt.Errorf("%w: empty constraint", ErrInvalidConstraint)
+	}
+	if trimmed == Latest {
+		c.sets = [][]comparator{{}}
+		return c, nil
+	}
+
+	for _, alternative := range strings.Split(trimmed, "||") {
+		fields := strings.FieldsFunc(alternative, func(r rune) bool { return r == ' ' || r == ',' })
+		if len(fields) == 0 {
+			return Constraint{}, fmt.Errorf("%w %q: empty alternative", ErrInvalidConstraint, s)
+		}
+		var set []comparator
+		for _, field := range fields {
+			comps, err := parseComparator(field)
+			if err != nil {
+				return Constraint{}, fmt.Errorf("%w %q: %v", ErrInvalidConstraint, s, err)
+			}
+			set = append(set, comps...)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// Matches reports whether v satisfies the constraint. Pre-release versions only match when
+// includePrerelease is set or when a comparator in the same set names a pre-release of the
+// same MAJOR.MINOR.PATCH, so "^1.2.0" never selects "1.3.0-beta" by accident.
+func (c Constraint) Matches(v Version, includePrerelease bool) bool {
+	for _, set := range c.sets {
+		if setMatches(set, v, includePrerelease) {
+			return true
+		}
+	}
+	return false
+}
+
+func setMatches(set []comparator, v Version, includePrerelease bool) bool {
+	for _, comp := range set {
+		if !comp.matches(v) {
+			return false
+		}
+	}
+	if !v.IsPrerelease() || includePrerelease {
+		return true
+	}
+	for _, comp := range set {
+		if comp.v.IsPrerelease() && comp.v.Major == v.Major && comp.v.Minor == v.Minor && comp.v.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// lowestPrerelease makes exclusive upper bounds such as "<2.0.0-0" exclude pre-releases of the bound itself.
+var lowestPrerelease = []string{"0"}
+
+// partial is a possibly incomplete version such as "1", "1.2", "1.x" or "1.2.3-rc.1".
+type partial struct {
+	nums []uint64 // only the components that were given
+	pre  []string
+}
+
+func (p partial) version(major, minor, patch uint64, pre []string) Version {
+	return Version{Major: major, Minor: minor, Patch: patch, Prerelease: pre}
+}
+
+func (p partial) floor() Version {
+	v := Version{}
+	if len(p.nums) > 0 {
+		v.Major = p.nums[0]
+	}
+	if len(p.nums) > 1 {
+		v.Minor = p.nums[1]
+	}
+	if len(p.nums) > 2 {
+		v.Patch = p.nums[2]
+		v.Prerelease = p.pre
+	}
+	return v
+}
+
+// next returns the lowest version above every version matched by the partial.
+func (p partial) next() Version {
+	switch len(p.nums) {
+	case 1:
+		return p.version(p.nums[0]+1, 0, 0, lowestPrerelease)
+	case 2:
+		return p.version(p.nums[0], p.nums[1]+1, 0, lowestPrerelease)
+	}
+	return p.version(p.nums[0], p.nums[1], p.nums[2]+1, lowestPrerelease)
+}
+
+func parsePartial(s string) (partial, error) {
+	s = strings.TrimPrefix(s, "v")
+	var p partial
+
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		ids, err := splitIdentifiers(s[i+1:], true)
+		if err != nil {
+			return partial{}, err
+		}
+		p.pre = ids
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return partial{}, fmt.Errorf("%q has too many components", s)
+	}
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			for _, rest := range parts[i+1:] {
+				if rest != "x" && rest != "X" && rest != "*" {
+					return partial{}, fmt.Errorf("%q has a number after a wildcard", s)
+				}
+			}
+			break
+		}
+		n, err := parseNumeric(part)
+		if err != nil {
+			return partial{}, err
+		}
+		p.nums = append(p.nums, n)
+	}
+	if p.pre != nil && len(p.nums) != 3 {
+		return partial{}, fmt.Errorf("%q: pre-release requires a full version", s)
+	}
+	return p, nil
+}
+
+// parseComparator expands a single selector term into primitive comparators.
+func parseComparator(term string) ([]comparator, error) {
+	op := ""
+	for _, candidate := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, candidate) {
+			op = candidate
+			term = term[len(candidate):]
+			break
+		}
+	}
+	if term == "" {
+		return nil, errors.New("missing version after operator")
+	}
+
+	p, err := parsePartial(term)
+	if err != nil {
+		return nil, err
+	}
+	n := len(p.nums)
+	matchAll := []comparator{{op: ">=", v: Version{}}}
+	matchNone := []comparator{{op: "<", v: Version{}}}
+
+	switch op {
+	case "", "=":
+		if n == 0 {
+			return matchAll, nil
+		}
+		if n == 3 {
+			return []comparator{{op: "=", v: p.floor()}}, nil
+		}
+		return []comparator{{op: ">=", v: p.floor()}, {op: "<", v: p.next()}}, nil
+
+	case "^":
+		if n == 0 {
+			return matchAll, nil
+		}
+		lower := comparator{op: ">=", v: p.floor()}
+		// The upper bound bumps the left-most non-zero component that was given.
+		switch {
+		case p.nums[0] > 0 || n == 1:
+			return []comparator{lower, {op: "<", v: p.version(p.nums[0]+1, 0, 0, lowestPrerelease)}}, nil
+		case n == 2 || p.nums[1] > 0:
+			return []comparator{lower, {op: "<", v: p.version(0, p.nums[1]+1, 0, lowestPrerelease)}}, nil
+		default:
+			return []comparator{lower, {op: "<", v: p.version(0, 0, p.nums[2]+1, lowestPrerelease)}}, nil
+		}
+
+	case "~":
+		if n == 0 {
+			return matchAll, nil
+		}
+		lower := comparator{op: ">=", v: p.floor()}
+		if n == 1 {
+			return []comparator{lower, {op: "<", v: p.version(p.nums[0]+1, 0, 0, lowestPrerelease)}}, nil
+		}
+		return []comparator{lower, {op: "<", v: p.version(p.nums[0], p.nums[1]+1, 0, lowestPrerelease)}}, nil
+
+	case ">":
+		if n == 0 {
+			return matchNone, nil
+		}
+		if n == 3 {
+			return []comparator{{op: ">", v: p.floor()}}, nil
+		}
+		return []comparator{{op: ">=", v: p.next()}}, nil
+
+	case ">=":
+		return []comparator{{op: ">=", v: p.floor()}}, nil
+
+	case "<":
+		if n == 0 {
+			return matchNone, nil
+		}
+		return []comparator{{op: "<", v: p.floor()}}, nil
+
+	case "<=":
+		if n == 0 {
+			return matchAll, nil
+		}
+		if n == 3 {
+			return []comparator{{op: "<=", v: p.floor()}}, nil
+		}
+		return []comparator{{op: "<", v: p.next()}}, nil
+	}
+
+	return nil, fmt.Errorf("unknown operator in %q", op+term)
+}
+
+// Select returns the index of the highest version in candidates that satisfies the constraint.
+// Candidates that are not valid semantic versions are skipped. It returns -1 when nothing matches.
+func Select(candidates []string, c Constraint, includePrerelease bool) int {
+	best := -1
+	var bestVersion Version
+	for i, candidate := range candidates {
+		v, err := Parse(candidate)
+		if err != nil || !c.Matches(v, includePrerelease) {
+			continue
+		}
+		if best < 0 || Compare(v, bestVersion) > 0 {
+			best = i
+			bestVersion = v
+		}
+	}
+	return best
+}
@@ -0,0 +1,185 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVersion is returned for strings that are not valid semantic versions.
+var ErrInvalidVersion = errors.New("invalid semantic version")
+
+// Version is a parsed semantic version (https://semver.org). A leading "v" is accepted and ignored.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      []string
+	// Original is the string the version was parsed from.
+	Original string
+}
+
+// Parse parses a full MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD] version.
+func Parse(s string) (Version, error) {
+	v := Version{Original: s}
+	rest := strings.TrimPrefix(s, "v")
+
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		build := rest[i+1:]
+		rest = rest[:i]
+		ids, err := splitIdentifiers(build, false)
+		if err != nil {
+			return Version{}, fmt.Errorf("%w %q: build metadata: %v", ErrInvalidVersion, s, err)
+		}
+		v.Build = ids
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		pre := rest[i+1:]
+		rest = rest[:i]
+		ids, err := splitIdentifiers(pre, true)
+		if err != nil {
+			return Version{}, fmt.Errorf("%w %q: pre-release: %v", ErrInvalidVersion, s, err)
+		}
+		v.Prerelease = ids
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("%w %q: expected MAJOR.MINOR.PATCH", ErrInvalidVersion, s)
+	}
+	nums := make([]uint64, 3)
+	for i, p := range parts {
+		n, err := parseNumeric(p)
+		if err != nil {
+			return Version{}, fmt.Errorf("%w %q: %v", ErrInvalidVersion, s, err)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, nil
+}
+
+// IsValid reports whether s parses as a semantic version.
+func IsValid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+func parseNumeric(s string) (uint64, error) {
+	if s == "" {
+		return 0, errors.New("empty numeric identifier")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("numeric identifier %q has a leading zero", s)
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%q is not numeric", s)
+		}
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func splitIdentifiers(s string, checkLeadingZero bool) ([]string, error) {
+	ids := strings.Split(s, ".")
+	for _, id := range ids {
+		if id == "" {
+			return nil, errors.New("empty identifier")
+		}
+		numeric := true
+		for _, c := range id {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return nil, fmt.Errorf("identifier %q contains invalid character %q", id, c)
+			}
+		}
+		if checkLeadingZero && numeric && len(id) > 1 && id[0] == '0' {
+			return nil, fmt.Errorf("numeric identifier %q has a leading zero", id)
+		}
+	}
+	return ids, nil
+}
+
+// IsPrerelease reports whether the version has pre-release identifiers.
+func (v Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 following semver precedence rules. Build metadata is ignored.
+func Compare(a, b Version) int {
+	if c := compareUint(a.Major, b.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(a.Minor, b.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(a.Patch, b.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(a.Prerelease, b.Prerelease)
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePrerelease implements semver §11.3-11.4: a version without pre-release has higher precedence,
+// numeric identifiers compare numerically and always rank below alphanumeric ones, and a shorter
+// set of identifiers ranks lower when all preceding identifiers are equal.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		an, aNumeric := numericIdentifier(a[i])
+		bn, bNumeric := numericIdentifier(b[i])
+		switch {
+		case aNumeric && bNumeric:
+			if c := compareUint(an, bn); c != 0 {
+				return c
+			}
+		case aNumeric:
+			return -1
+		case bNumeric:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+func numericIdentifier(s string) (uint64, bool) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	return n, err == nil
+}
@@ -0,0 +1,137 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	valid := []string{"1.0.0", "v1.0.0", "0.0.1", "1.2.3-alpha.1", "1.2.3+build.5", "1.2.3-rc.1+sha.abc", "10.20.30"}
+	for _, s := range valid {
+		if _, err := Parse(s); err != nil {
+			t.Errorf("Parse(%q) failed: %v", s, err)
+		}
+	}
+
+	invalid := []string{"", "v1", "1.2", "1.2.3.4", "01.2.3", "1.2.3-", "1.2.3-01", "1.2.3-a..b", "1.2.3+", "latest", "1.2.x"}
+	for _, s := range invalid {
+		if _, err := Parse(s); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("Parse(%q): expected ErrInvalidVersion, got %v", s, err)
+		}
+	}
+}
+
+func TestCompare_SpecOrdering(t *testing.T) {
+	// Redosled iz semver specifikacije, paragraf 11.
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0",
+	}
+	for i := 0; i+1 < len(ordered); i++ {
+		a, _ := Parse(ordered[i])
+		b, _ := Parse(ordered[i+1])
+		if Compare(a, b) >= 0 || Compare(b, a) <= 0 {
+			t.Errorf("Expected %s < %s", ordered[i], ordered[i+1])
+		}
+	}
+
+	a, _ := Parse("v1.0.0+build.1")
+	b, _ := Parse("1.0.0+build.2")
+	if Compare(a, b) != 0 {
+		t.Errorf("Build metadata must not affect precedence")
+	}
+}
+
+func TestConstraint_Matches(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		prerelease bool
+		want       bool
+	}{
+		{"latest", "1.0.0", false, true},
+		{"latest", "1.0.0-rc.1", false, false},
+		{"latest", "1.0.0-rc.1", true, true},
+		{"^1.2", "1.2.0", false, true},
+		{"^1.2", "1.9.9", false, true},
+		{"^1.2", "2.0.0", false, false},
+		{"^1.2", "1.1.9", false, false},
+		{"^1.2", "2.0.0-alpha", true, false},
+		{"^0.2.3", "0.2.9", false, true},
+		{"^0.2.3", "0.3.0", false, false},
+		{"^0.0.3", "0.0.4", false, false},
+		{"~1.2.3", "1.2.9", false, true},
+		{"~1.2.3", "1.3.0", false, false},
+		{"1.x", "1.4.2", false, true},
+		{"1.x", "2.0.0", false, false},
+		{"*", "3.1.4", false, true},
+		{">=1.0.0 <2.0.0", "1.5.0", false, true},
+		{">=1.0.0, <2.0.0", "2.0.0", false, false},
+		{">1.2", "1.2.9", false, false},
+		{">1.2", "1.3.0", false, true},
+		{"<=1.2", "1.2.9", false, true},
+		{"1.x || >=3.0.0", "3.1.0", false, true},
+		{"1.x || >=3.0.0", "2.1.0", false, false},
+		{"^1.2.0", "1.3.0-beta", false, false},
+		{"^1.3.0-beta", "1.3.0-beta.2", false, true},
+		{"^1.3.0-beta", "1.4.0-beta", false, false},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q) failed: %v", tt.constraint, err)
+			continue
+		}
+		v, err := Parse(tt.version)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.version, err)
+		}
+		if got := c.Matches(v, tt.prerelease); got != tt.want {
+			t.Errorf("%q matches %q (prerelease=%v) = %v, want %v", tt.constraint, tt.version, tt.prerelease, got, tt.want)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	candidates := []string{"v1.0.0", "v1.2.0", "v1.10.0", "v2.0.0-rc.1", "not-semver", "v1.9.0"}
+
+	tests := []struct {
+		constraint string
+		prerelease bool
+		want       string
+	}{
+		{"latest", false, "v1.10.0"},
+		{"latest", true, "v2.0.0-rc.1"},
+		{"~1.2", false, "v1.2.0"},
+		{"^1", false, "v1.10.0"},
+		{"^3", false, ""},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Fatalf("ParseConstraint(%q) failed: %v", tt.constraint, err)
+		}
+		got := ""
+		if i := Select(candidates, c, tt.prerelease); i >= 0 {
+			got = candidates[i]
+		}
+		if got != tt.want {
+			t.Errorf("Select(%q, prerelease=%v) = %q, want %q", tt.constraint, tt.prerelease, got, tt.want)
+		}
+	}
+}
+
+func TestIsSelector(t *testing.T) {
+	for _, s := range []string{"latest", "^1.2", "~1.2.3", ">=1.0.0", "1.x", "*", "1.2.*"} {
+		if !IsSelector(s) {
+			t.Errorf("IsSelector(%q) = false, want true", s)
+		}
+	}
+	for _, s := range []string{"v1.0.0", "1.2", "prod", "v2"} {
+		if IsSelector(s) {
+			t.Errorf("IsSelector(%q) = true, want false", s)
+		}
+	}
+}
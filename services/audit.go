@@ -0,0 +1,33 @@
+package services
+
+import (
+	"alati_projekat/actor"
+	"alati_projekat/model"
+	"context"
+	"time"
+)
+
+// now is the clock used for audit timestamps; tests may replace it.
+var now = func() time.Time { return time.Now().UTC() }
+
+// createdMetadata returns the metadata of a newly created entity.
+func createdMetadata(ctx context.Context) model.Metadata {
+	t := now()
+	who := actor.FromContext(ctx)
+	return model.Metadata{
+		CreatedAt: t,
+		CreatedBy: who,
+		UpdatedAt: t,
+		UpdatedBy: who,
+		Revision:  1,
+	}
+}
+
+// touchMetadata returns the metadata of an entity after a write. Creation data is kept and
+// the revision is incremented, so entities stored before audit support start at revision 1.
+func touchMetadata(ctx context.Context, m model.Metadata) model.Metadata {
+	m.UpdatedAt = now()
+	m.UpdatedBy = actor.FromContext(ctx)
+	m.Revision++
+	return m
+}
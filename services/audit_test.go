@@ -0,0 +1,81 @@
+package services
+
+import (
+	"alati_projekat/actor"
+	"alati_projekat/model"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestConfigurationService_AuditMetadata(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return clock }
+
+	created, err := service.AddConfiguration(actor.NewContext(context.Background(), "alice"), model.Configuration{Name: "audited", Version: "v1", Description: "prva verzija"}, "")
+	if err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	m := created.Metadata
+	if m.CreatedBy != "alice" || m.UpdatedBy != "alice" || !m.CreatedAt.Equal(clock) || m.Revision != 1 {
+		t.Errorf("Unexpected metadata after create: %+v", m)
+	}
+	if created.Description != "prva verzija" {
+		t.Errorf("Expected description to be kept, got %q", created.Description)
+	}
+
+	clock = clock.Add(time.Hour)
+	updated, err := service.UpdateConfiguration(context.Background(), model.Configuration{Name: "audited", Version: "v1"}, "")
+	if err != nil {
+		t.Fatalf("UpdateConfiguration failed: %v", err)
+	}
+	m = updated.Metadata
+	if m.CreatedBy != "alice" || m.UpdatedBy != actor.Anonymous || !m.UpdatedAt.Equal(clock) || m.Revision != 2 {
+		t.Errorf("Unexpected metadata after update: %+v", m)
+	}
+
+	published, err := service.TransitionConfiguration(actor.NewContext(context.Background(), "bob"), "audited", "v1", model.StatePublished, nil)
+	if err != nil {
+		t.Fatalf("TransitionConfiguration failed: %v", err)
+	}
+	if published.Metadata.Revision != 3 || published.Metadata.UpdatedBy != "bob" {
+		t.Errorf("Expected transition to bump the revision, got %+v", published.Metadata)
+	}
+
+	stored, _ := service.GetConfiguration(context.Background(), "audited", "v1")
+	if stored.Metadata != published.Metadata {
+		t.Errorf("Stored metadata %+v differs from returned %+v", stored.Metadata, published.Metadata)
+	}
+}
+
+func TestConfigurationService_AuditMetadata_LegacyRecord(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+
+	// Zapis sacuvan pre uvodjenja metapodataka
+	var legacy model.ConfigurationGroup
+	if err := json.Unmarshal([]byte(`{"id":"00000000-0000-0000-0000-000000000001","name":"legacy","version":"v1","configurations":[]}`), &legacy); err != nil {
+		t.Fatalf("Failed to decode legacy JSON: %v", err)
+	}
+	mockRepo.groups[mockRepo.makeGroupKey("legacy", "v1")] = legacy
+
+	updated, err := service.UpdateConfigurationGroup(context.Background(), model.ConfigurationGroup{Name: "legacy", Version: "v1"}, "")
+	if err != nil {
+		t.Fatalf("UpdateConfigurationGroup failed: %v", err)
+	}
+	if updated.Metadata.Revision != 1 || !updated.Metadata.CreatedAt.IsZero() || updated.Metadata.UpdatedAt.IsZero() {
+		t.Errorf("Unexpected metadata for legacy record: %+v", updated.Metadata)
+	}
+
+	out, _ := json.Marshal(legacy)
+	var raw map[string]any
+	_ = json.Unmarshal(out, &raw)
+	if _, ok := raw["metadata"]; ok {
+		t.Errorf("Zero metadata must be omitted from JSON, got %s", out)
+	}
+}
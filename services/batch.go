@@ -0,0 +1,193 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/repository"
+	"alati_projekat/validation"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// BatchError reports the operation that made an atomic batch fail; nothing was stored.
+type BatchError struct {
+	Index int
+	Op    model.BatchAction
+	Kind  model.BatchKind
+	Ref   string // name/version of the target
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("operation %d (%s %s %s) failed: %v", e.Index, e.Op, e.Kind, e.Ref, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// ExecuteBatch runs the operations of req in order. In atomic mode every operation is staged
+// and the result is committed with a single repository transaction, so either all of them are
+// stored or none; the first failing operation is reported as a *BatchError. In best-effort mode
+// each operation is applied on its own and failures are only recorded in its result.
+// The idempotency key is saved once for the whole batch.
+func (s *ConfigurationService) ExecuteBatch(ctx context.Context, req model.BatchRequest, idempotencyKey string) (model.BatchResponse, error) {
+	if req.Mode == "" {
+		req.Mode = model.BatchAtomic
+	}
+	if err := validateBatch(req); err != nil {
+		return model.BatchResponse{}, err
+	}
+
+	resp := model.BatchResponse{Mode: req.Mode, Results: make([]model.BatchResult, len(req.Operations))}
+
+	if req.Mode == model.BatchAtomic {
+		stage := newStagingRepository(s.Repo)
+		staged := &ConfigurationService{Repo: stage, StrictVersions: s.StrictVersions}
+		for i, op := range req.Operations {
+			result := staged.applyBatchOperation(ctx, i, op)
+			if result.Err != nil {
+				return model.BatchResponse{}, &BatchError{Index: i, Op: op.Op, Kind: op.Kind, Ref: result.Name + "/" + result.Version, Err: result.Err}
+			}
+			resp.Results[i] = result
+		}
+		if err := s.Repo.Transact(ctx, stage.ops()); err != nil {
+			return model.BatchResponse{}, err
+		}
+		resp.Succeeded = len(req.Operations)
+	} else {
+		for i, op := range req.Operations {
+			resp.Results[i] = s.applyBatchOperation(ctx, i, op)
+			if resp.Results[i].Err != nil {
+				resp.Failed++
+			} else {
+				resp.Succeeded++
+			}
+		}
+	}
+
+	s.SaveIdempotencyKey(ctx, idempotencyKey)
+	return resp, nil
+}
+
+// applyBatchOperation applies a single operation through the regular service methods. The
+// result holds the stored entity, or for deletes the entity as it was before, so events
+// about it carry its labels and revision.
+func (s *ConfigurationService) applyBatchOperation(ctx context.Context, index int, op model.BatchOperation) model.BatchResult {
+	result := model.BatchResult{Index: index, Op: op.Op, Kind: op.Kind, Name: op.Name, Version: op.Version}
+
+	switch op.Kind {
+	case model.BatchConfiguration:
+		if op.Op == model.BatchDelete {
+			var deleted model.Configuration
+			if deleted, result.Err = s.Repo.GetConfiguration(ctx, op.Name, op.Version); result.Err == nil {
+				result.Err = s.DeleteConfiguration(ctx, op.Name, op.Version)
+			}
+			if result.Err == nil {
+				result.Configuration = &deleted
+			}
+			return result
+		}
+		config := configurationFromRequest(*op.Configuration)
+		result.Name, result.Version = config.Name, config.Version
+		var stored model.Configuration
+		if op.Op == model.BatchCreate {
+			config.ID = uuid.New()
+			stored, result.Err = s.AddConfiguration(ctx, config, "")
+		} else {
+			stored, result.Err = s.UpdateConfiguration(ctx, config, "")
+		}
+		if result.Err == nil {
+			result.Configuration = &stored
+		}
+
+	case model.BatchGroup:
+		if op.Op == model.BatchDelete {
+			var deleted model.ConfigurationGroup
+			if deleted, result.Err = s.Repo.GetConfigurationGroup(ctx, op.Name, op.Version); result.Err == nil {
+				result.Err = s.DeleteConfigurationGroup(ctx, op.Name, op.Version)
+			}
+			if result.Err == nil {
+				result.Group = &deleted
+			}
+			return result
+		}
+		group := groupFromRequest(*op.Group)
+		result.Name, result.Version = group.Name, group.Version
+		var stored model.ConfigurationGroup
+		if op.Op == model.BatchCreate {
+			group.ID = uuid.New()
+			stored, result.Err = s.AddConfigurationGroup(ctx, group, "")
+		} else {
+			stored, result.Err = s.UpdateConfigurationGroup(ctx, group, "")
+		}
+		if result.Err == nil {
+			result.Group = &stored
+		}
+	}
+	return result
+}
+
+// validateBatch checks the shape of a batch; the entities themselves are validated when applied.
+func validateBatch(req model.BatchRequest) error {
+	var v validation.Validator
+	switch {
+	case req.Mode != model.BatchAtomic && req.Mode != model.BatchBestEffort:
+		v.Add("mode", "must be %q or %q", model.BatchAtomic, model.BatchBestEffort)
+	case len(req.Operations) == 0:
+		v.Add("operations", "must contain at least one operation")
+	case len(req.Operations) > validation.MaxBatchOperations:
+		v.Add("operations", "must contain at most %d operations", validation.MaxBatchOperations)
+	case req.Mode == model.BatchAtomic && len(req.Operations) > repository.MaxTxnOps:
+		v.Add("operations", "atomic batches must contain at most %d operations; use best-effort mode for larger batches", repository.MaxTxnOps)
+	}
+
+	for i, op := range req.Operations {
+		field := fmt.Sprintf("operations[%d]", i)
+		if op.Kind != model.BatchConfiguration && op.Kind != model.BatchGroup {
+			v.Add(field+".kind", "must be %q or %q", model.BatchConfiguration, model.BatchGroup)
+			continue
+		}
+		hasBody := op.Configuration != nil || op.Group != nil
+		switch op.Op {
+		case model.BatchCreate, model.BatchUpdate:
+			if op.Kind == model.BatchConfiguration && (op.Configuration == nil || op.Group != nil) {
+				v.Add(field+".configuration", "is required for %s of a configuration", op.Op)
+			}
+			if op.Kind == model.BatchGroup && (op.Group == nil || op.Configuration != nil) {
+				v.Add(field+".group", "is required for %s of a group", op.Op)
+			}
+		case model.BatchDelete:
+			v.Name(field+".name", op.Name)
+			v.Version(field+".version", op.Version)
+			if hasBody {
+				v.Add(field, "delete must not carry a configuration or group body")
+			}
+		default:
+			v.Add(field+".op", "must be %q, %q or %q", model.BatchCreate, model.BatchUpdate, model.BatchDelete)
+		}
+	}
+	return v.Err()
+}
+
+func configurationFromRequest(req model.CreateConfigurationRequest) model.Configuration {
+	return model.Configuration{
+		Name:         req.Name,
+		Version:      req.Version,
+		Params:       req.Params,
+		Labels:       req.Labels,
+		Parent:       req.Parent,
+		RemoveParams: req.RemoveParams,
+		Description:  req.Description,
+	}
+}
+
+func groupFromRequest(req model.CreateGroupRequest) model.ConfigurationGroup {
+	return model.ConfigurationGroup{
+		Name:           req.Name,
+		Version:        req.Version,
+		Configurations: req.Configurations,
+		Description:    req.Description,
+	}
+}
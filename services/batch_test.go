@@ -0,0 +1,188 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/repository"
+	"context"
+	"errors"
+	"testing"
+)
+
+func configOp(op model.BatchAction, name, version string, params ...model.Parameter) model.BatchOperation {
+	return model.BatchOperation{
+		Op:            op,
+		Kind:          model.BatchConfiguration,
+		Configuration: &model.CreateConfigurationRequest{Name: name, Version: version, Params: params},
+	}
+}
+
+func TestConfigurationService_ExecuteBatch_Atomic(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	if _, err := service.AddConfiguration(ctx, model.Configuration{Name: "old", Version: "v1"}, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	// Dete se oslanja na roditelja kreiranog ranije u istom batch-u
+	child := configOp(model.BatchCreate, "api", "v1")
+	child.Configuration.Parent = &model.ConfigurationRef{Name: "api-base", Version: "v1"}
+	req := model.BatchRequest{Operations: []model.BatchOperation{
+		configOp(model.BatchCreate, "api-base", "v1", model.Parameter{Key: "db.host", Value: "localhost"}),
+		child,
+		configOp(model.BatchUpdate, "api-base", "v1", model.Parameter{Key: "db.host", Value: "db"}),
+		{Op: model.BatchDelete, Kind: model.BatchConfiguration, Name: "old", Version: "v1"},
+		{Op: model.BatchCreate, Kind: model.BatchGroup, Group: &model.CreateGroupRequest{Name: "cluster", Version: "v1"}},
+	}}
+
+	resp, err := service.ExecuteBatch(ctx, req, "batch-key")
+	if err != nil {
+		t.Fatalf("ExecuteBatch failed: %v", err)
+	}
+	if resp.Mode != model.BatchAtomic || resp.Succeeded != 5 || resp.Failed != 0 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+
+	base, err := mockRepo.GetConfiguration(ctx, "api-base", "v1")
+	if err != nil || base.Params[0].Value != "db" || base.Metadata.Revision != 2 {
+		t.Errorf("Expected updated api-base with revision 2, got %+v (%v)", base, err)
+	}
+	if _, err := mockRepo.GetConfiguration(ctx, "old", "v1"); err == nil {
+		t.Error("Deleted configuration still exists")
+	}
+	if _, err := mockRepo.GetConfigurationGroup(ctx, "cluster", "v1"); err != nil {
+		t.Errorf("Group was not created: %v", err)
+	}
+	if !mockRepo.idempotencyKeys["batch-key"] {
+		t.Error("Idempotency key should have been saved for the batch")
+	}
+}
+
+func TestConfigurationService_ExecuteBatch_AtomicFailure(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	req := model.BatchRequest{Mode: model.BatchAtomic, Operations: []model.BatchOperation{
+		configOp(model.BatchCreate, "a", "v1"),
+		configOp(model.BatchUpdate, "missing", "v1"),
+	}}
+	_, err := service.ExecuteBatch(ctx, req, "failed-key")
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || batchErr.Index != 1 {
+		t.Fatalf("Expected BatchError for operation 1, got: %v", err)
+	}
+	if len(mockRepo.configs) != 0 {
+		t.Errorf("Nothing may be stored when an atomic batch fails, got %v", mockRepo.configs)
+	}
+	if mockRepo.idempotencyKeys["failed-key"] {
+		t.Error("Idempotency key must not be saved for a failed atomic batch")
+	}
+}
+
+// conflictingRepository simulates another writer creating a key between staging and commit.
+type conflictingRepository struct {
+	*MockRepository
+}
+
+func (r *conflictingRepository) Transact(ctx context.Context, ops []repository.TxnOp) error {
+	r.configs[r.makeConfigKey("a", "v1")] = model.Configuration{Name: "a", Version: "v1"}
+	return r.MockRepository.Transact(ctx, ops)
+}
+
+func TestConfigurationService_ExecuteBatch_CommitConflict(t *testing.T) {
+	repo := &conflictingRepository{MockRepository: NewMockRepository()}
+	service := NewConfigurationService(repo)
+
+	req := model.BatchRequest{Operations: []model.BatchOperation{
+		configOp(model.BatchCreate, "a", "v1"),
+		configOp(model.BatchCreate, "b", "v1"),
+	}}
+	if _, err := service.ExecuteBatch(context.Background(), req, ""); !errors.Is(err, ErrRevisionConflict) {
+		t.Fatalf("Expected ErrRevisionConflict, got: %v", err)
+	}
+	if _, err := repo.GetConfiguration(context.Background(), "b", "v1"); err == nil {
+		t.Error("Other writes of a conflicting batch must not be stored")
+	}
+}
+
+// vanishingRepository simulates another writer deleting a record between staging and commit.
+type vanishingRepository struct {
+	*repository.InMemoryRepository
+}
+
+func (r vanishingRepository) Transact(ctx context.Context, ops []repository.TxnOp) error {
+	r.DeleteConfiguration(ctx, "a", "v1")
+	return r.InMemoryRepository.Transact(ctx, ops)
+}
+
+func TestConfigurationService_ExecuteBatch_CommitMissingRecord(t *testing.T) {
+	repo := vanishingRepository{repository.NewInMemoryRepository()}
+	service := NewConfigurationService(repo)
+	ctx := context.Background()
+
+	if _, err := service.AddConfiguration(ctx, model.Configuration{Name: "a", Version: "v1"}, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	req := model.BatchRequest{Operations: []model.BatchOperation{
+		configOp(model.BatchUpdate, "a", "v1", model.Parameter{Key: "port", Value: "80"}),
+		configOp(model.BatchCreate, "b", "v1"),
+	}}
+	if _, err := service.ExecuteBatch(ctx, req, ""); !errors.Is(err, ErrRevisionConflict) {
+		t.Fatalf("Expected ErrRevisionConflict for a record deleted before commit, got: %v", err)
+	}
+	if _, err := repo.GetConfiguration(ctx, "b", "v1"); err == nil {
+		t.Error("Other writes of a conflicting batch must not be stored")
+	}
+}
+
+func TestConfigurationService_ExecuteBatch_BestEffort(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	req := model.BatchRequest{Mode: model.BatchBestEffort, Operations: []model.BatchOperation{
+		configOp(model.BatchCreate, "a", "v1"),
+		configOp(model.BatchCreate, "a", "v1"),
+		configOp(model.BatchCreate, "bad/name", "v1"),
+		{Op: model.BatchDelete, Kind: model.BatchConfiguration, Name: "missing", Version: "v1"},
+	}}
+	resp, err := service.ExecuteBatch(ctx, req, "")
+	if err != nil {
+		t.Fatalf("ExecuteBatch failed: %v", err)
+	}
+	if resp.Succeeded != 1 || resp.Failed != 3 {
+		t.Errorf("Expected 1 success and 3 failures, got %+v", resp)
+	}
+	if resp.Results[0].Err != nil || resp.Results[0].Configuration == nil {
+		t.Errorf("First operation should succeed, got %+v", resp.Results[0])
+	}
+	if !errors.Is(resp.Results[2].Err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for invalid name, got %v", resp.Results[2].Err)
+	}
+	if _, err := mockRepo.GetConfiguration(ctx, "a", "v1"); err != nil {
+		t.Errorf("Successful operation was not stored: %v", err)
+	}
+}
+
+func TestConfigurationService_ExecuteBatch_InvalidShape(t *testing.T) {
+	service := NewConfigurationService(NewMockRepository())
+
+	tooMany := make([]model.BatchOperation, repository.MaxTxnOps+1)
+	for i := range tooMany {
+		tooMany[i] = configOp(model.BatchCreate, "a", "v1")
+	}
+	for name, req := range map[string]model.BatchRequest{
+		"empty":        {},
+		"unknown mode": {Mode: "eventual", Operations: []model.BatchOperation{configOp(model.BatchCreate, "a", "v1")}},
+		"unknown op":   {Operations: []model.BatchOperation{{Op: "upsert", Kind: model.BatchConfiguration}}},
+		"missing body": {Operations: []model.BatchOperation{{Op: model.BatchCreate, Kind: model.BatchGroup}}},
+		"too large":    {Operations: tooMany},
+	} {
+		if _, err := service.ExecuteBatch(context.Background(), req, ""); !errors.Is(err, ErrValidation) {
+			t.Errorf("%s: expected ErrValidation, got %v", name, err)
+		}
+	}
+}
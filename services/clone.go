@@ -0,0 +1,88 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// CloneConfiguration stores a copy of the configuration name/version under the name and
+// version of req, with the overrides of req applied. The copy starts as a draft and records
+// its source in Metadata.ClonedFrom.
+func (s *ConfigurationService) CloneConfiguration(ctx context.Context, name, version string, req model.CloneRequest, idempotencyKey string) (model.Configuration, error) {
+	source, err := s.Repo.GetConfiguration(ctx, name, version)
+	if err != nil {
+		return model.Configuration{}, err
+	}
+
+	clone := cloneConfiguration(source, req)
+	clone.Name, clone.Version = cmp.Or(req.Name, source.Name), req.Version
+	clone.Description = cmp.Or(req.Description, source.Description)
+	return s.addConfiguration(ctx, clone, &model.ConfigurationRef{Name: name, Version: version}, idempotencyKey)
+}
+
+// CloneConfigurationGroup stores a copy of the group name/version under the name and version
+// of req. Every member is copied with the overrides of req applied; the members keep their
+// names and versions. The copy starts as a draft and records its source in Metadata.ClonedFrom.
+func (s *ConfigurationService) CloneConfigurationGroup(ctx context.Context, name, version string, req model.CloneRequest, idempotencyKey string) (model.ConfigurationGroup, error) {
+	source, err := s.Repo.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+
+	clone := model.ConfigurationGroup{
+		ID:             uuid.New(),
+		Name:           cmp.Or(req.Name, source.Name),
+		Version:        req.Version,
+		Configurations: make([]model.Configuration, len(source.Configurations)),
+		Description:    cmp.Or(req.Description, source.Description),
+	}
+	for i, member := range source.Configurations {
+		clone.Configurations[i] = cloneConfiguration(member, req)
+	}
+	return s.addConfigurationGroup(ctx, clone, &model.ConfigurationRef{Name: name, Version: version}, idempotencyKey)
+}
+
+// cloneConfiguration copies the content of c under a new ID and applies the param and label
+// overrides of req. Lifecycle and metadata are left for the caller to set.
+func cloneConfiguration(c model.Configuration, req model.CloneRequest) model.Configuration {
+	clone := model.Configuration{
+		ID:           uuid.New(),
+		Name:         c.Name,
+		Version:      c.Version,
+		Params:       override(c.Params, req.Params, req.UnsetParams),
+		Labels:       override(c.Labels, req.Labels, req.UnsetLabels),
+		RemoveParams: slices.Clone(c.RemoveParams),
+		Description:  c.Description,
+	}
+	if c.Parent != nil {
+		parent := *c.Parent
+		clone.Parent = &parent
+	}
+	return clone
+}
+
+// override returns a copy of params with the keys in set added or replaced and the keys in
+// unset removed. Existing keys keep their position and new keys are appended in order.
+func override(params, set []model.Parameter, unset []string) []model.Parameter {
+	if len(params) == 0 && len(set) == 0 {
+		return nil
+	}
+	out := make([]model.Parameter, 0, len(params)+len(set))
+	for _, p := range params {
+		if !slices.Contains(unset, p.Key) {
+			out = append(out, p)
+		}
+	}
+	for _, p := range set {
+		if i := slices.IndexFunc(out, func(o model.Parameter) bool { return o.Key == p.Key }); i >= 0 {
+			out[i].Value = p.Value
+		} else {
+			out = append(out, p)
+		}
+	}
+	return out
+}
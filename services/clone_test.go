@@ -0,0 +1,111 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigurationService_CloneConfiguration(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	source, err := service.AddConfiguration(ctx, model.Configuration{
+		Name:    "service-api",
+		Version: "v1",
+		Params:  []model.Parameter{{Key: "port", Value: "8080"}, {Key: "debug", Value: "true"}},
+		Labels:  []model.Parameter{{Key: "env", Value: "staging"}, {Key: "team", Value: "core"}},
+	}, "")
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if _, err := service.TransitionConfiguration(ctx, "service-api", "v1", model.StatePublished, nil); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	clone, err := service.CloneConfiguration(ctx, "service-api", "v1", model.CloneRequest{
+		Version:     "v2",
+		Params:      []model.Parameter{{Key: "port", Value: "9090"}, {Key: "timeout", Value: "5s"}},
+		UnsetParams: []string{"debug"},
+		Labels:      []model.Parameter{{Key: "env", Value: "prod"}},
+	}, "")
+	if err != nil {
+		t.Fatalf("CloneConfiguration failed: %v", err)
+	}
+
+	wantParams := []model.Parameter{{Key: "port", Value: "9090"}, {Key: "timeout", Value: "5s"}}
+	wantLabels := []model.Parameter{{Key: "env", Value: "prod"}, {Key: "team", Value: "core"}}
+	if !reflect.DeepEqual(clone.Params, wantParams) || !reflect.DeepEqual(clone.Labels, wantLabels) {
+		t.Errorf("Overrides not applied: params %+v, labels %+v", clone.Params, clone.Labels)
+	}
+	if clone.ID == source.ID || clone.CurrentState() != model.StateDraft || clone.Metadata.Revision != 1 {
+		t.Errorf("Expected a new draft, got %+v", clone)
+	}
+	if got := clone.Metadata.ClonedFrom; got == nil || *got != (model.ConfigurationRef{Name: "service-api", Version: "v1"}) {
+		t.Errorf("Expected clonedFrom service-api/v1, got %+v", got)
+	}
+
+	// Izvor ostaje nepromenjen
+	stored, _ := mockRepo.GetConfiguration(ctx, "service-api", "v1")
+	if stored.Params[0].Value != "8080" || stored.Labels[0].Value != "staging" {
+		t.Errorf("Source was modified: %+v", stored)
+	}
+
+	if _, err := service.CloneConfiguration(ctx, "service-api", "v1", model.CloneRequest{Version: "v2"}, ""); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected conflict for existing target, got: %v", err)
+	}
+	if _, err := service.CloneConfiguration(ctx, "service-api", "v1", model.CloneRequest{}, ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for missing version, got: %v", err)
+	}
+	if _, err := service.CloneConfiguration(ctx, "missing", "v1", model.CloneRequest{Version: "v2"}, ""); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found, got: %v", err)
+	}
+}
+
+func TestConfigurationService_CloneConfigurationGroup(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.AddConfigurationGroup(ctx, model.ConfigurationGroup{
+		Name:    "cluster",
+		Version: "v1",
+		Configurations: []model.Configuration{
+			{Name: "api", Version: "v1", Labels: []model.Parameter{{Key: "env", Value: "staging"}}},
+			{Name: "worker", Version: "v1", Params: []model.Parameter{{Key: "threads", Value: "4"}}},
+		},
+		Description: "Staging cluster",
+	}, "")
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	clone, err := service.CloneConfigurationGroup(ctx, "cluster", "v1", model.CloneRequest{
+		Name:    "cluster-prod",
+		Version: "v1",
+		Labels:  []model.Parameter{{Key: "env", Value: "prod"}},
+	}, "")
+	if err != nil {
+		t.Fatalf("CloneConfigurationGroup failed: %v", err)
+	}
+	if clone.Name != "cluster-prod" || clone.Description != "Staging cluster" || len(clone.Configurations) != 2 {
+		t.Fatalf("Unexpected clone: %+v", clone)
+	}
+	for _, member := range clone.Configurations {
+		if member.LabelsMap()["env"] != "prod" {
+			t.Errorf("Member %s/%s was not relabeled: %+v", member.Name, member.Version, member.Labels)
+		}
+	}
+	if got := clone.Metadata.ClonedFrom; got == nil || got.Name != "cluster" {
+		t.Errorf("Expected clonedFrom cluster/v1, got %+v", got)
+	}
+
+	stored, _ := mockRepo.GetConfigurationGroup(ctx, "cluster", "v1")
+	if stored.Configurations[0].Labels[0].Value != "staging" {
+		t.Errorf("Source group was modified: %+v", stored.Configurations)
+	}
+}
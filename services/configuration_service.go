@@ -4,14 +4,21 @@ import (
 	"alati_projekat/labels"
 	"alati_projekat/model"
 	"alati_projekat/repository"
+	"alati_projekat/validation"
 	"context"
 	"errors"
 	"log"
 	"strings"
+	"time"
 )
 
+// MaxWatchWait caps how long a watch blocks, matching the limit of Consul blocking queries.
+const MaxWatchWait = 10 * time.Minute
+
 type ConfigurationService struct {
 	Repo repository.Repository
+	// StrictVersions rejects configurations and groups whose version is not a valid semantic version.
+	StrictVersions bool
 }
 
 func NewConfigurationService(repo repository.Repository) *ConfigurationService {
@@ -39,31 +46,74 @@ func (s *ConfigurationService) SaveIdempotencyKey(ctx context.Context, key strin
 
 // --- CONFIGURATION CRUD LOGIC  ---
 
-func (s *ConfigurationService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) error {
+func (s *ConfigurationService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error) {
+	return s.addConfiguration(ctx, config, nil, idempotencyKey)
+}
+
+// addConfiguration stores a new draft; clonedFrom is recorded in its metadata when set.
+func (s *ConfigurationService) addConfiguration(ctx context.Context, config model.Configuration, clonedFrom *model.ConfigurationRef, idempotencyKey string) (model.Configuration, error) {
+	if err := validation.Configuration(config); err != nil {
+		return model.Configuration{}, err
+	}
+	if err := s.checkVersion(config.Version); err != nil {
+		return model.Configuration{}, err
+	}
+
 	if _, err := s.Repo.GetConfiguration(ctx, config.Name, config.Version); err == nil {
-		return errors.New("configuration already exists (Conflict)")
+		return model.Configuration{}, errors.New("configuration already exists (Conflict)")
 	} else if !strings.Contains(err.Error(), "not found") {
-		return err
+		return model.Configuration{}, err
+	}
+
+	if config.Parent != nil {
+		if _, err := s.resolveChain(ctx, config); err != nil {
+			return model.Configuration{}, err
+		}
 	}
 
+	config.Lifecycle = model.Lifecycle{State: model.StateDraft}
+	config.Metadata = createdMetadata(ctx)
+	config.Metadata.ClonedFrom = clonedFrom
+
 	if err := s.Repo.AddConfiguration(ctx, config); err != nil {
-		return err
+		return model.Configuration{}, err
 	}
 	s.SaveIdempotencyKey(ctx, idempotencyKey)
-	return nil
+	return config, nil
 }
 
 func (s *ConfigurationService) GetConfiguration(ctx context.Context, name string, version string) (model.Configuration, error) {
 	return s.Repo.GetConfiguration(ctx, name, version)
 }
 
+// WatchConfiguration long-polls the configuration: it returns once the stored record changes
+// after index or wait elapses, with the index to wait on next.
+func (s *ConfigurationService) WatchConfiguration(ctx context.Context, name string, version string, index uint64, wait time.Duration) (model.Configuration, uint64, error) {
+	return s.Repo.WatchConfiguration(ctx, name, version, index, min(wait, MaxWatchWait))
+}
+
 func (s *ConfigurationService) UpdateConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error) {
+	if err := validation.Configuration(config); err != nil {
+		return model.Configuration{}, err
+	}
 	existingConfig, err := s.Repo.GetConfiguration(ctx, config.Name, config.Version)
 	if err != nil {
 		return model.Configuration{}, err // Vraća "not found" ili drugu grešku
 	}
 
+	if err := requireDraft("configuration", config.Name, config.Version, existingConfig.Lifecycle); err != nil {
+		return model.Configuration{}, err
+	}
+
 	config.ID = existingConfig.ID
+	config.Lifecycle = existingConfig.Lifecycle
+	config.Metadata = touchMetadata(ctx, existingConfig.Metadata)
+
+	if config.Parent != nil {
+		if _, err := s.resolveChain(ctx, config); err != nil {
+			return model.Configuration{}, err
+		}
+	}
 
 	if err := s.Repo.UpdateConfiguration(ctx, config); err != nil {
 		return model.Configuration{}, err
@@ -73,36 +123,73 @@ func (s *ConfigurationService) UpdateConfiguration(ctx context.Context, config m
 }
 
 func (s *ConfigurationService) DeleteConfiguration(ctx context.Context, name string, version string) error {
+	config, err := s.Repo.GetConfiguration(ctx, name, version)
+	if err != nil {
+		return err
+	}
+	if err := requireDeletable("configuration", name, version, config.Lifecycle); err != nil {
+		return err
+	}
 	return s.Repo.DeleteConfiguration(ctx, name, version)
 }
 
 // --- CONFIGURATION GROUP CRUD LOGIC
 
-func (s *ConfigurationService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) error {
+func (s *ConfigurationService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (model.ConfigurationGroup, error) {
+	return s.addConfigurationGroup(ctx, group, nil, idempotencyKey)
+}
+
+// addConfigurationGroup stores a new draft group; clonedFrom is recorded in its metadata when set.
+func (s *ConfigurationService) addConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, clonedFrom *model.ConfigurationRef, idempotencyKey string) (model.ConfigurationGroup, error) {
+	if err := validation.Group(group); err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	if err := s.checkVersion(group.Version); err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+
 	if _, err := s.Repo.GetConfigurationGroup(ctx, group.Name, group.Version); err == nil {
-		return errors.New("configuration group already exists (Conflict)")
+		return model.ConfigurationGroup{}, errors.New("configuration group already exists (Conflict)")
 	} else if !strings.Contains(err.Error(), "not found") {
-		return err
+		return model.ConfigurationGroup{}, err
 	}
 
+	group.Lifecycle = model.Lifecycle{State: model.StateDraft}
+	group.Metadata = createdMetadata(ctx)
+	group.Metadata.ClonedFrom = clonedFrom
+
 	if err := s.Repo.AddConfigurationGroup(ctx, group); err != nil {
-		return err
+		return model.ConfigurationGroup{}, err
 	}
 	s.SaveIdempotencyKey(ctx, idempotencyKey)
-	return nil
+	return group, nil
 }
 
 func (s *ConfigurationService) GetConfigurationGroup(ctx context.Context, name string, version string) (model.ConfigurationGroup, error) {
 	return s.Repo.GetConfigurationGroup(ctx, name, version)
 }
 
+// WatchConfigurationGroup is the group counterpart of WatchConfiguration.
+func (s *ConfigurationService) WatchConfigurationGroup(ctx context.Context, name string, version string, index uint64, wait time.Duration) (model.ConfigurationGroup, uint64, error) {
+	return s.Repo.WatchConfigurationGroup(ctx, name, version, index, min(wait, MaxWatchWait))
+}
+
 func (s *ConfigurationService) UpdateConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (model.ConfigurationGroup, error) {
+	if err := validation.Group(group); err != nil {
+		return model.ConfigurationGroup{}, err
+	}
 	existingGroup, err := s.Repo.GetConfigurationGroup(ctx, group.Name, group.Version)
 	if err != nil {
 		return model.ConfigurationGroup{}, err // Vraća "not found" ili drugu grešku
 	}
 
+	if err := requireDraft("configuration group", group.Name, group.Version, existingGroup.Lifecycle); err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+
 	group.ID = existingGroup.ID
+	group.Lifecycle = existingGroup.Lifecycle
+	group.Metadata = touchMetadata(ctx, existingGroup.Metadata)
 
 	if err := s.Repo.UpdateConfigurationGroup(ctx, group); err != nil {
 		return model.ConfigurationGroup{}, err
@@ -112,6 +199,13 @@ func (s *ConfigurationService) UpdateConfigurationGroup(ctx context.Context, gro
 }
 
 func (s *ConfigurationService) DeleteConfigurationGroup(ctx context.Context, name string, version string) error {
+	group, err := s.Repo.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		return err
+	}
+	if err := requireDeletable("configuration group", name, version, group.Lifecycle); err != nil {
+		return err
+	}
 	return s.Repo.DeleteConfigurationGroup(ctx, name, version)
 }
 
@@ -130,24 +224,21 @@ func (s *ConfigurationService) FilterConfigsByLabels(ctx context.Context, name,
 }
 
 func (s *ConfigurationService) DeleteConfigsByLabels(ctx context.Context, name, version string, want map[string]string) (int, error) {
-	g, err := s.Repo.GetConfigurationGroup(ctx, name, version)
-	if err != nil {
-		return 0, err
-	}
-	filtered := make([]model.Configuration, 0, len(g.Configurations))
 	deleted := 0
-	for _, cfg := range g.Configurations {
-		if labels.HasAll(cfg, want) {
-			deleted++
-			continue
+	_, err := s.updateGroup(ctx, name, version, func(g *model.ConfigurationGroup) (bool, error) {
+		filtered := make([]model.Configuration, 0, len(g.Configurations))
+		deleted = 0
+		for _, cfg := range g.Configurations {
+			if labels.HasAll(cfg, want) {
+				deleted++
+				continue
+			}
+			filtered = append(filtered, cfg)
 		}
-		filtered = append(filtered, cfg)
-	}
-	if deleted == 0 {
-		return 0, nil
-	}
-	g.Configurations = filtered
-	if err := s.Repo.AddConfigurationGroup(ctx, g); err != nil {
+		g.Configurations = filtered
+		return deleted > 0, nil
+	})
+	if err != nil {
 		return 0, err
 	}
 	return deleted, nil
@@ -2,9 +2,11 @@ package services
 
 import (
 	"alati_projekat/model"
+	"alati_projekat/repository"
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -14,6 +16,7 @@ type MockRepository struct {
 	configs         map[string]model.Configuration
 	groups          map[string]model.ConfigurationGroup
 	idempotencyKeys map[string]bool
+	webhooks        map[uuid.UUID]model.Webhook
 }
 
 func NewMockRepository() *MockRepository {
@@ -21,6 +24,7 @@ func NewMockRepository() *MockRepository {
 		configs:         make(map[string]model.Configuration),
 		groups:          make(map[string]model.ConfigurationGroup),
 		idempotencyKeys: make(map[string]bool),
+		webhooks:        make(map[uuid.UUID]model.Webhook),
 	}
 }
 
@@ -42,6 +46,14 @@ func (m *MockRepository) SaveIdempotencyKey(ctx context.Context, key string) err
 	return nil
 }
 
+func (m *MockRepository) ListIdempotencyKeys(ctx context.Context) ([]string, error) {
+	keys := make([]string, 0, len(m.idempotencyKeys))
+	for key := range m.idempotencyKeys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 func (m *MockRepository) AddConfiguration(ctx context.Context, config model.Configuration) error {
 	key := m.makeConfigKey(config.Name, config.Version)
 	if _, exists := m.configs[key]; exists {
@@ -76,6 +88,17 @@ func (m *MockRepository) UpdateConfiguration(ctx context.Context, config model.C
 	return nil
 }
 
+func (m *MockRepository) CompareAndSwapConfiguration(ctx context.Context, config model.Configuration, expectedRevision int64) error {
+	existing, exists := m.configs[m.makeConfigKey(config.Name, config.Version)]
+	if !exists {
+		return errors.New("configuration not found")
+	}
+	if existing.Metadata.Revision != expectedRevision {
+		return repository.ErrRevisionConflict
+	}
+	return m.UpdateConfiguration(ctx, config)
+}
+
 func (m *MockRepository) DeleteConfiguration(ctx context.Context, name, version string) error {
 	key := m.makeConfigKey(name, version)
 	if _, exists := m.configs[key]; !exists {
@@ -85,6 +108,16 @@ func (m *MockRepository) DeleteConfiguration(ctx context.Context, name, version
 	return nil
 }
 
+func (m *MockRepository) ListConfigurations(ctx context.Context, name string) ([]model.Configuration, error) {
+	var out []model.Configuration
+	for _, config := range m.configs {
+		if name == "" || config.Name == name {
+			out = append(out, config)
+		}
+	}
+	return out, nil
+}
+
 func (m *MockRepository) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup) error {
 	key := m.makeGroupKey(group.Name, group.Version)
 	if _, exists := m.groups[key]; exists {
@@ -119,6 +152,17 @@ func (m *MockRepository) UpdateConfigurationGroup(ctx context.Context, group mod
 	return nil
 }
 
+func (m *MockRepository) CompareAndSwapConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, expectedRevision int64) error {
+	existing, exists := m.groups[m.makeGroupKey(group.Name, group.Version)]
+	if !exists {
+		return errors.New("configuration group not found")
+	}
+	if existing.Metadata.Revision != expectedRevision {
+		return repository.ErrRevisionConflict
+	}
+	return m.UpdateConfigurationGroup(ctx, group)
+}
+
 func (m *MockRepository) DeleteConfigurationGroup(ctx context.Context, name, version string) error {
 	key := m.makeGroupKey(name, version)
 	if _, exists := m.groups[key]; !exists {
@@ -128,6 +172,89 @@ func (m *MockRepository) DeleteConfigurationGroup(ctx context.Context, name, ver
 	return nil
 }
 
+func (m *MockRepository) ListConfigurationGroups(ctx context.Context, name string) ([]model.ConfigurationGroup, error) {
+	var out []model.ConfigurationGroup
+	for _, group := range m.groups {
+		if name == "" || group.Name == name {
+			out = append(out, group)
+		}
+	}
+	return out, nil
+}
+
+// Mock ne blokira: vraća trenutno stanje, a revizija služi kao indeks
+func (m *MockRepository) WatchConfiguration(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.Configuration, uint64, error) {
+	config, err := m.GetConfiguration(ctx, name, version)
+	return config, uint64(config.Metadata.Revision), err
+}
+
+func (m *MockRepository) WatchConfigurationGroup(ctx context.Context, name, version string, index uint64, wait time.Duration) (model.ConfigurationGroup, uint64, error) {
+	group, err := m.GetConfigurationGroup(ctx, name, version)
+	return group, uint64(group.Metadata.Revision), err
+}
+
+// Transact proverava sve operacije pre nego sto bilo sta upise, kao Consul transakcija.
+func (m *MockRepository) Transact(ctx context.Context, ops []repository.TxnOp) error {
+	if len(ops) > repository.MaxTxnOps {
+		return repository.ErrTxnTooLarge
+	}
+	for _, op := range ops {
+		var revision int64
+		var exists bool
+		if op.Group != nil {
+			var g model.ConfigurationGroup
+			g, exists = m.groups[m.makeGroupKey(op.Name(), op.Version())]
+			revision = g.Metadata.Revision
+		} else {
+			var c model.Configuration
+			c, exists = m.configs[m.makeConfigKey(op.Name(), op.Version())]
+			revision = c.Metadata.Revision
+		}
+		if op.Verb == repository.TxnCreate && exists || op.Verb != repository.TxnCreate && (!exists || revision != op.ExpectedRevision) {
+			return repository.ErrRevisionConflict
+		}
+	}
+	for _, op := range ops {
+		switch {
+		case op.Group != nil && op.Verb == repository.TxnDelete:
+			delete(m.groups, m.makeGroupKey(op.Name(), op.Version()))
+		case op.Group != nil:
+			m.groups[m.makeGroupKey(op.Name(), op.Version())] = *op.Group
+		case op.Verb == repository.TxnDelete:
+			delete(m.configs, m.makeConfigKey(op.Name(), op.Version()))
+		default:
+			m.configs[m.makeConfigKey(op.Name(), op.Version())] = *op.Configuration
+		}
+	}
+	return nil
+}
+
+func (m *MockRepository) AddWebhook(ctx context.Context, hook model.Webhook) error {
+	m.webhooks[hook.ID] = hook
+	return nil
+}
+
+func (m *MockRepository) GetWebhook(ctx context.Context, id uuid.UUID) (model.Webhook, error) {
+	hook, exists := m.webhooks[id]
+	if !exists {
+		return model.Webhook{}, errors.New("webhook not found")
+	}
+	return hook, nil
+}
+
+func (m *MockRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	delete(m.webhooks, id)
+	return nil
+}
+
+func (m *MockRepository) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	hooks := make([]model.Webhook, 0, len(m.webhooks))
+	for _, hook := range m.webhooks {
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
+
 // Tests
 func TestConfigurationService_AddConfiguration(t *testing.T) {
 	mockRepo := NewMockRepository()
@@ -141,7 +268,7 @@ func TestConfigurationService_AddConfiguration(t *testing.T) {
 		Params:  []model.Parameter{{Key: "port", Value: "8080"}},
 	}
 
-	err := service.AddConfiguration(ctx, config, "test-key-1")
+	_, err := service.AddConfiguration(ctx, config, "test-key-1")
 	if err != nil {
 		t.Fatalf("AddConfiguration failed: %v", err)
 	}
@@ -154,12 +281,30 @@ func TestConfigurationService_AddConfiguration(t *testing.T) {
 		t.Error("Idempotency key should have been saved")
 	}
 
-	err = service.AddConfiguration(ctx, config, "test-key-2")
+	_, err = service.AddConfiguration(ctx, config, "test-key-2")
 	if err == nil {
 		t.Error("Expected error for duplicate configuration")
 	}
 }
 
+func TestConfigurationService_AddConfiguration_Invalid(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	// Ime sa '/' bi pokvarilo raspored kljuceva u Consulu
+	config := model.Configuration{Name: "team/api", Version: "v1", Params: []model.Parameter{{Key: "a"}, {Key: "a"}}}
+	if _, err := service.AddConfiguration(ctx, config, "invalid-key"); !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected ErrValidation, got: %v", err)
+	}
+	if len(mockRepo.configs) != 0 {
+		t.Error("Invalid configuration must not be stored")
+	}
+	if found, _ := service.CheckIdempotencyKey(ctx, "invalid-key"); found {
+		t.Error("Idempotency key must not be saved for a rejected request")
+	}
+}
+
 func TestConfigurationService_GetConfiguration(t *testing.T) {
 	mockRepo := NewMockRepository()
 	service := NewConfigurationService(mockRepo)
@@ -172,7 +317,7 @@ func TestConfigurationService_GetConfiguration(t *testing.T) {
 		Params:  []model.Parameter{{Key: "test", Value: "value"}},
 	}
 
-	err := service.AddConfiguration(ctx, config, "get-test-key")
+	_, err := service.AddConfiguration(ctx, config, "get-test-key")
 	if err != nil {
 		t.Fatalf("Setup failed: %v", err)
 	}
@@ -205,7 +350,7 @@ func TestConfigurationService_UpdateConfiguration(t *testing.T) {
 		Params:  []model.Parameter{{Key: "old", Value: "value"}},
 	}
 
-	err := service.AddConfiguration(ctx, config, "update-test-key-1")
+	_, err := service.AddConfiguration(ctx, config, "update-test-key-1")
 	if err != nil {
 		t.Fatalf("Setup failed: %v", err)
 	}
@@ -247,7 +392,7 @@ func TestConfigurationService_UpdateConfigurationGroup(t *testing.T) {
 	ctx := context.Background()
 
 	originalID := uuid.New()
-	originalConfig := model.Configuration{Params: []model.Parameter{{Key: "old", Value: "old-value"}}}
+	originalConfig := model.Configuration{Name: "member", Version: "v1", Params: []model.Parameter{{Key: "old", Value: "old-value"}}}
 	group := model.ConfigurationGroup{
 		ID:             originalID,
 		Name:           "update-group-test",
@@ -255,12 +400,12 @@ func TestConfigurationService_UpdateConfigurationGroup(t *testing.T) {
 		Configurations: []model.Configuration{originalConfig},
 	}
 
-	err := service.AddConfigurationGroup(ctx, group, "update-group-key-1")
+	_, err := service.AddConfigurationGroup(ctx, group, "update-group-key-1")
 	if err != nil {
 		t.Fatalf("Setup failed: %v", err)
 	}
 
-	updatedConfig := model.Configuration{Params: []model.Parameter{{Key: "new", Value: "updated-value"}}}
+	updatedConfig := model.Configuration{Name: "member", Version: "v1", Params: []model.Parameter{{Key: "new", Value: "updated-value"}}}
 	updatedGroupInput := group
 	updatedGroupInput.ID = uuid.Nil
 	updatedGroupInput.Configurations = []model.Configuration{updatedConfig}
@@ -328,7 +473,7 @@ func TestConfigurationService_ConfigurationGroup(t *testing.T) {
 	}
 
 	// Test add group
-	err := service.AddConfigurationGroup(ctx, group, "group-key-1")
+	_, err := service.AddConfigurationGroup(ctx, group, "group-key-1")
 	if err != nil {
 		t.Fatalf("AddConfigurationGroup failed: %v", err)
 	}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"alati_projekat/interpolate"
+	"alati_projekat/repository"
+	"alati_projekat/semver"
+	"alati_projekat/validation"
+	"errors"
+)
+
+var (
+	// ErrInvalidParent is returned when a configuration points to a parent that cannot be resolved.
+	ErrInvalidParent = errors.New("invalid parent configuration")
+	// ErrInheritanceCycle is returned when following parents leads back to an already visited configuration.
+	ErrInheritanceCycle = errors.New("configuration inheritance cycle detected")
+	// ErrReferenceCycle is returned when parameter references depend on each other.
+	ErrReferenceCycle = errors.New("parameter reference cycle detected")
+	// ErrUnresolvedReference is returned by strict interpolation when a reference has no value.
+	ErrUnresolvedReference = interpolate.ErrUnresolved
+	// ErrInvalidVersion is returned on create when StrictVersions is set and the version is not semver.
+	ErrInvalidVersion = semver.ErrInvalidVersion
+	// ErrInvalidVersionSelector is returned when a version selector such as "^1.2" cannot be parsed.
+	ErrInvalidVersionSelector = semver.ErrInvalidConstraint
+	// ErrImmutable is returned when modifying a configuration or group that is no longer a draft,
+	// or deleting one that is neither a draft nor archived.
+	ErrImmutable = errors.New("only draft versions can be modified")
+	// ErrInvalidTransition is returned for lifecycle transitions that are not allowed.
+	ErrInvalidTransition = errors.New("invalid lifecycle transition")
+	// ErrUnsupportedPatchType is returned for patch documents that are neither merge patch nor JSON patch.
+	ErrUnsupportedPatchType = errors.New("unsupported patch media type")
+	// ErrMalformedPatch is returned when the patch document itself cannot be parsed.
+	ErrMalformedPatch = errors.New("malformed patch document")
+	// ErrInvalidPatch is returned when a patch cannot be applied or produces an invalid entity.
+	ErrInvalidPatch = errors.New("patch cannot be applied")
+	// ErrPreconditionFailed is returned when If-Match does not match the stored entity.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrRevisionConflict is returned when concurrent writes keep winning over a compare-and-swap update.
+	ErrRevisionConflict = repository.ErrRevisionConflict
+	// ErrDuplicateMember is returned when a group already holds a configuration with the same name and version.
+	ErrDuplicateMember = errors.New("configuration is already a member of the group")
+	// ErrMemberNotFound is returned when a group holds no configuration with the given name and version.
+	ErrMemberNotFound = errors.New("group member not found")
+	// ErrNoMatchingMembers is returned when rendering and no group member matches the label selector.
+	ErrNoMatchingMembers = errors.New("no configuration matches the label selector")
+	// ErrImportConflict is returned by an import when entities of the snapshot already exist in
+	// fail-on-conflict mode, or would replace entities that are not drafts in overwrite mode.
+	ErrImportConflict = errors.New("snapshot conflicts with existing entities")
+	// ErrValidation matches the *validation.Error returned when a configuration or group is invalid.
+	ErrValidation = validation.ErrInvalid
+)
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"alati_projekat/actor"
+	"alati_projekat/events"
+	"alati_projekat/model"
+	"context"
+	"time"
+)
+
+// EventService publishes a change event to Log after every successful mutation of the
+// wrapped service. Reads are passed through unchanged.
+type EventService struct {
+	Service
+	Log *events.Log
+}
+
+func NewEventService(next Service, log *events.Log) *EventService {
+	return &EventService{
+		Service: next,
+		Log:     log,
+	}
+}
+
+var _ Service = (*EventService)(nil)
+
+func (s *EventService) publishConfiguration(ctx context.Context, t model.EventType, c model.Configuration) {
+	s.Log.Publish(model.Event{
+		Type:     t,
+		Kind:     model.EventConfiguration,
+		Name:     c.Name,
+		Version:  c.Version,
+		Revision: c.Metadata.Revision,
+		Labels:   c.Labels,
+		Actor:    actor.FromContext(ctx),
+	})
+}
+
+func (s *EventService) publishGroup(ctx context.Context, t model.EventType, g model.ConfigurationGroup) {
+	s.Log.Publish(model.Event{
+		Type:     t,
+		Kind:     model.EventGroup,
+		Name:     g.Name,
+		Version:  g.Version,
+		Revision: g.Metadata.Revision,
+		Actor:    actor.FromContext(ctx),
+	})
+}
+
+// --- CONFIGURATIONS ---
+
+func (s *EventService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error) {
+	out, err := s.Service.AddConfiguration(ctx, config, idempotencyKey)
+	if err == nil {
+		s.publishConfiguration(ctx, model.EventCreated, out)
+	}
+	return out, err
+}
+
+func (s *EventService) UpdateConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error) {
+	out, err := s.Service.UpdateConfiguration(ctx, config, idempotencyKey)
+	if err == nil {
+		s.publishConfiguration(ctx, model.EventUpdated, out)
+	}
+	return out, err
+}
+
+func (s *EventService) PatchConfiguration(ctx context.Context, name string, version string, patchType string, patch []byte, ifMatch string) (model.Configuration, error) {
+	out, err := s.Service.PatchConfiguration(ctx, name, version, patchType, patch, ifMatch)
+	if err == nil {
+		s.publishConfiguration(ctx, model.EventUpdated, out)
+	}
+	return out, err
+}
+
+// DeleteConfiguration reads the configuration first, so the event carries its last
+// revision and labels.
+func (s *EventService) DeleteConfiguration(ctx context.Context, name string, version string) error {
+	deleted, err := s.Service.GetConfiguration(ctx, name, version)
+	if err != nil {
+		deleted = model.Configuration{Name: name, Version: version}
+	}
+	if err := s.Service.DeleteConfiguration(ctx, name, version); err != nil {
+		return err
+	}
+	s.publishConfiguration(ctx, model.EventDeleted, deleted)
+	return nil
+}
+
+func (s *EventService) TransitionConfiguration(ctx context.Context, name string, version string, target model.LifecycleState, sunset *time.Time) (model.Configuration, error) {
+	out, err := s.Service.TransitionConfiguration(ctx, name, version, target, sunset)
+	if err == nil {
+		s.publishConfiguration(ctx, model.EventUpdated, out)
+	}
+	return out, err
+}
+
+func (s *EventService) CloneConfiguration(ctx context.Context, name string, version string, req model.CloneRequest, idempotencyKey string) (model.Configuration, error) {
+	out, err := s.Service.CloneConfiguration(ctx, name, version, req, idempotencyKey)
+	if err == nil {
+		s.publishConfiguration(ctx, model.EventCreated, out)
+	}
+	return out, err
+}
+
+// --- CONFIGURATION GROUPS ---
+
+func (s *EventService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (model.ConfigurationGroup, error) {
+	out, err := s.Service.AddConfigurationGroup(ctx, group, idempotencyKey)
+	if err == nil {
+		s.publishGroup(ctx, model.EventCreated, out)
+	}
+	return out, err
+}
+
+func (s *EventService) UpdateConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (model.ConfigurationGroup, error) {
+	out, err := s.Service.UpdateConfigurationGroup(ctx, group, idempotencyKey)
+	if err == nil {
+		s.publishGroup(ctx, model.EventUpdated, out)
+	}
+	return out, err
+}
+
+func (s *EventService) PatchConfigurationGroup(ctx context.Context, name string, version string, patchType string, patch []byte, ifMatch string) (model.ConfigurationGroup, error) {
+	out, err := s.Service.PatchConfigurationGroup(ctx, name, version, patchType, patch, ifMatch)
+	if err == nil {
+		s.publishGroup(ctx, model.EventUpdated, out)
+	}
+	return out, err
+}
+
+// DeleteConfigurationGroup reads the group first, so the event carries its last revision.
+func (s *EventService) DeleteConfigurationGroup(ctx context.Context, name string, version string) error {
+	deleted, err := s.Service.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		deleted = model.ConfigurationGroup{Name: name, Version: version}
+	}
+	if err := s.Service.DeleteConfigurationGroup(ctx, name, version); err != nil {
+		return err
+	}
+	s.publishGroup(ctx, model.EventDeleted, deleted)
+	return nil
+}
+
+func (s *EventService) AddGroupMember(ctx context.Context, name string, version string, member model.Configuration) (model.ConfigurationGroup, error) {
+	out, err := s.Service.AddGroupMember(ctx, name, version, member)
+	if err == nil {
+		s.publishGroup(ctx, model.EventUpdated, out)
+	}
+	return out, err
+}
+
+func (s *EventService) UpdateGroupMember(ctx context.Context, name string, version string, member model.Configuration) (model.ConfigurationGroup, error) {
+	out, err := s.Service.UpdateGroupMember(ctx, name, version, member)
+	if err == nil {
+		s.publishGroup(ctx, model.EventUpdated, out)
+	}
+	return out, err
+}
+
+func (s *EventService) RemoveGroupMember(ctx context.Context, name string, version string, memberName string, memberVersion string) (model.ConfigurationGroup, error) {
+	out, err := s.Service.RemoveGroupMember(ctx, name, version, memberName, memberVersion)
+	if err == nil {
+		s.publishGroup(ctx, model.EventUpdated, out)
+	}
+	return out, err
+}
+
+func (s *EventService) TransitionConfigurationGroup(ctx context.Context, name string, version string, target model.LifecycleState, sunset *time.Time) (model.ConfigurationGroup, error) {
+	out, err := s.Service.TransitionConfigurationGroup(ctx, name, version, target, sunset)
+	if err == nil {
+		s.publishGroup(ctx, model.EventUpdated, out)
+	}
+	return out, err
+}
+
+func (s *EventService) CloneConfigurationGroup(ctx context.Context, name string, version string, req model.CloneRequest, idempotencyKey string) (model.ConfigurationGroup, error) {
+	out, err := s.Service.CloneConfigurationGroup(ctx, name, version, req, idempotencyKey)
+	if err == nil {
+		s.publishGroup(ctx, model.EventCreated, out)
+	}
+	return out, err
+}
+
+// DeleteConfigsByLabels reads the group back after removing members, so the event carries
+// its new revision.
+func (s *EventService) DeleteConfigsByLabels(ctx context.Context, name, version string, want map[string]string) (int, error) {
+	deleted, err := s.Service.DeleteConfigsByLabels(ctx, name, version, want)
+	if err == nil && deleted > 0 {
+		group, getErr := s.Service.GetConfigurationGroup(ctx, name, version)
+		if getErr != nil {
+			group = model.ConfigurationGroup{Name: name, Version: version}
+		}
+		s.publishGroup(ctx, model.EventUpdated, group)
+	}
+	return deleted, err
+}
+
+// --- BATCH ---
+
+var batchEventTypes = map[model.BatchAction]model.EventType{
+	model.BatchCreate: model.EventCreated,
+	model.BatchUpdate: model.EventUpdated,
+	model.BatchDelete: model.EventDeleted,
+}
+
+// ExecuteBatch publishes one event per operation that succeeded.
+func (s *EventService) ExecuteBatch(ctx context.Context, req model.BatchRequest, idempotencyKey string) (model.BatchResponse, error) {
+	resp, err := s.Service.ExecuteBatch(ctx, req, idempotencyKey)
+	if err != nil {
+		return resp, err
+	}
+	for _, result := range resp.Results {
+		if result.Err != nil {
+			continue
+		}
+		t := batchEventTypes[result.Op]
+		switch {
+		case result.Configuration != nil:
+			s.publishConfiguration(ctx, t, *result.Configuration)
+		case result.Group != nil:
+			s.publishGroup(ctx, t, *result.Group)
+		case result.Kind == model.BatchConfiguration:
+			s.publishConfiguration(ctx, t, model.Configuration{Name: result.Name, Version: result.Version})
+		default:
+			s.publishGroup(ctx, t, model.ConfigurationGroup{Name: result.Name, Version: result.Version})
+		}
+	}
+	return resp, nil
+}
+
+// ImportSnapshot publishes one event per entity written by the import. Dry runs and
+// failed imports publish nothing.
+func (s *EventService) ImportSnapshot(ctx context.Context, snap model.Snapshot, opts model.ImportOptions) (model.ImportSummary, error) {
+	summary, err := s.Service.ImportSnapshot(ctx, snap, opts)
+	if err != nil || summary.DryRun {
+		return summary, err
+	}
+	for _, item := range summary.Items {
+		t := model.EventCreated
+		if item.Action == model.ImportOverwritten {
+			t = model.EventUpdated
+		}
+		switch {
+		case item.Configuration != nil:
+			s.publishConfiguration(ctx, t, *item.Configuration)
+		case item.Group != nil:
+			s.publishGroup(ctx, t, *item.Group)
+		}
+	}
+	return summary, nil
+}
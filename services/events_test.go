@@ -0,0 +1,103 @@
+package services
+
+import (
+	"alati_projekat/events"
+	"alati_projekat/labels"
+	"alati_projekat/model"
+	"context"
+	"testing"
+)
+
+func TestEventService_PublishesMutations(t *testing.T) {
+	log := events.NewLog(0)
+	service := NewEventService(NewConfigurationService(NewMockRepository()), log)
+	ctx := context.Background()
+	sub := log.Resume(0)
+	defer sub.Close()
+
+	config := model.Configuration{Name: "service-api", Version: "v1", Labels: []model.Parameter{{Key: "env", Value: "prod"}}}
+	if _, err := service.AddConfiguration(ctx, config, ""); err != nil {
+		t.Fatalf("AddConfiguration failed: %v", err)
+	}
+	// Neuspela izmena ne objavljuje događaj
+	if _, err := service.AddConfiguration(ctx, config, ""); err == nil {
+		t.Fatal("Expected a conflict for the duplicate configuration")
+	}
+	if _, err := service.UpdateConfiguration(ctx, config, ""); err != nil {
+		t.Fatalf("UpdateConfiguration failed: %v", err)
+	}
+	if err := service.DeleteConfiguration(ctx, "service-api", "v1"); err != nil {
+		t.Fatalf("DeleteConfiguration failed: %v", err)
+	}
+	if _, err := service.ExecuteBatch(ctx, model.BatchRequest{Operations: []model.BatchOperation{
+		{Op: model.BatchCreate, Kind: model.BatchGroup, Group: &model.CreateGroupRequest{Name: "cluster", Version: "v1"}},
+	}}, ""); err != nil {
+		t.Fatalf("ExecuteBatch failed: %v", err)
+	}
+	snap := model.Snapshot{Groups: []model.ConfigurationGroup{{Name: "cluster", Version: "v1"}}}
+	// Dry run ne objavljuje događaje
+	for _, dryRun := range []bool{true, false} {
+		if _, err := service.ImportSnapshot(ctx, snap, model.ImportOptions{Mode: model.ImportOverwrite, DryRun: dryRun}); err != nil {
+			t.Fatalf("ImportSnapshot failed: %v", err)
+		}
+	}
+
+	want := []model.Event{
+		{ID: 1, Type: model.EventCreated, Kind: model.EventConfiguration, Revision: 1},
+		{ID: 2, Type: model.EventUpdated, Kind: model.EventConfiguration, Revision: 2},
+		{ID: 3, Type: model.EventDeleted, Kind: model.EventConfiguration, Revision: 2},
+		{ID: 4, Type: model.EventCreated, Kind: model.EventGroup, Revision: 1},
+		{ID: 5, Type: model.EventUpdated, Kind: model.EventGroup, Revision: 2},
+	}
+	for _, w := range want {
+		got := <-sub.C
+		if got.ID != w.ID || got.Type != w.Type || got.Kind != w.Kind || got.Revision != w.Revision {
+			t.Errorf("Expected %s %s at revision %d, got %+v", w.Type, w.Kind, w.Revision, got)
+		}
+		if got.Kind == model.EventConfiguration && len(got.Labels) != 1 {
+			t.Errorf("Expected configuration labels in the event, got %+v", got.Labels)
+		}
+	}
+	select {
+	case extra := <-sub.C:
+		t.Errorf("Unexpected event %+v", extra)
+	default:
+	}
+}
+
+// Brisanje u batch-u nosi labele i reviziju, pa ga dobijaju i pretplatnici sa selektorom
+func TestEventService_BatchDeleteMatchesLabelSelector(t *testing.T) {
+	selector, err := labels.Parse("env:prod")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	for _, mode := range []model.BatchMode{model.BatchAtomic, model.BatchBestEffort} {
+		t.Run(string(mode), func(t *testing.T) {
+			log := events.NewLog(0)
+			service := NewEventService(NewConfigurationService(NewMockRepository()), log)
+			ctx := context.Background()
+
+			config := model.Configuration{Name: "service-api", Version: "v1", Labels: []model.Parameter{{Key: "env", Value: "prod"}}}
+			if _, err := service.AddConfiguration(ctx, config, ""); err != nil {
+				t.Fatalf("AddConfiguration failed: %v", err)
+			}
+			sub := log.Subscribe()
+			defer sub.Close()
+
+			if _, err := service.ExecuteBatch(ctx, model.BatchRequest{Mode: mode, Operations: []model.BatchOperation{
+				{Op: model.BatchDelete, Kind: model.BatchConfiguration, Name: "service-api", Version: "v1"},
+			}}, ""); err != nil {
+				t.Fatalf("ExecuteBatch failed: %v", err)
+			}
+
+			got := <-sub.C
+			if got.Type != model.EventDeleted || got.Revision != 1 {
+				t.Errorf("Expected a deletion at revision 1, got %+v", got)
+			}
+			if !labels.HasAll(model.Configuration{Labels: got.Labels}, selector) {
+				t.Errorf("Deletion does not match the env:prod selector, labels %+v", got.Labels)
+			}
+		})
+	}
+}
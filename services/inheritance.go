@@ -0,0 +1,119 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MaxInheritanceDepth limits how many parents are followed when resolving a configuration.
+const MaxInheritanceDepth = 16
+
+func refKey(name, version string) string {
+	return name + "/" + version
+}
+
+// resolveChain returns the configuration followed by all of its ancestors, nearest parent first.
+func (s *ConfigurationService) resolveChain(ctx context.Context, leaf model.Configuration) ([]model.Configuration, error) {
+	chain := []model.Configuration{leaf}
+	path := []string{refKey(leaf.Name, leaf.Version)}
+	visited := map[string]bool{path[0]: true}
+
+	current := leaf
+	for current.Parent != nil {
+		parentRef := *current.Parent
+		if parentRef.Name == "" || parentRef.Version == "" {
+			return nil, fmt.Errorf("%w: parent of %s must have a name and a version", ErrInvalidParent, refKey(current.Name, current.Version))
+		}
+
+		key := refKey(parentRef.Name, parentRef.Version)
+		path = append(path, key)
+		if visited[key] {
+			return nil, fmt.Errorf("%w: %s", ErrInheritanceCycle, strings.Join(path, " -> "))
+		}
+		if len(chain) > MaxInheritanceDepth {
+			return nil, fmt.Errorf("%w: inheritance chain is deeper than %d levels", ErrInvalidParent, MaxInheritanceDepth)
+		}
+
+		parent, err := s.Repo.GetConfiguration(ctx, parentRef.Name, parentRef.Version)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, fmt.Errorf("%w: %s does not exist", ErrInvalidParent, key)
+			}
+			return nil, err
+		}
+
+		visited[key] = true
+		chain = append(chain, parent)
+		current = parent
+	}
+
+	return chain, nil
+}
+
+// mergeChain applies the layers of a chain returned by resolveChain from the root ancestor down to the leaf.
+// Every layer first drops the inherited keys listed in RemoveParams and then overrides with its own params.
+func mergeChain(chain []model.Configuration) model.EffectiveConfiguration {
+	leaf := chain[0]
+	effective := model.EffectiveConfiguration{
+		Name:    leaf.Name,
+		Version: leaf.Version,
+		Layers:  make([]model.ConfigurationRef, 0, len(chain)),
+		Params:  []model.EffectiveParameter{},
+		Labels:  leaf.Labels,
+	}
+
+	index := map[string]int{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		layer := chain[i]
+		source := model.ConfigurationRef{Name: layer.Name, Version: layer.Version}
+		effective.Layers = append(effective.Layers, source)
+
+		if len(layer.RemoveParams) > 0 {
+			removed := make(map[string]bool, len(layer.RemoveParams))
+			for _, key := range layer.RemoveParams {
+				removed[key] = true
+			}
+			kept := effective.Params[:0]
+			for _, p := range effective.Params {
+				if !removed[p.Key] {
+					kept = append(kept, p)
+				}
+			}
+			effective.Params = kept
+			for k := range index {
+				delete(index, k)
+			}
+			for j, p := range effective.Params {
+				index[p.Key] = j
+			}
+		}
+
+		for _, p := range layer.Params {
+			param := model.EffectiveParameter{Key: p.Key, Value: p.Value, Source: source}
+			if j, ok := index[p.Key]; ok {
+				effective.Params[j] = param
+				continue
+			}
+			index[p.Key] = len(effective.Params)
+			effective.Params = append(effective.Params, param)
+		}
+	}
+
+	return effective
+}
+
+func (s *ConfigurationService) GetEffectiveConfiguration(ctx context.Context, name string, version string) (model.EffectiveConfiguration, error) {
+	leaf, err := s.Repo.GetConfiguration(ctx, name, version)
+	if err != nil {
+		return model.EffectiveConfiguration{}, err
+	}
+
+	chain, err := s.resolveChain(ctx, leaf)
+	if err != nil {
+		return model.EffectiveConfiguration{}, err
+	}
+
+	return mergeChain(chain), nil
+}
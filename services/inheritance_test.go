@@ -0,0 +1,105 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestConfigurationService_GetEffectiveConfiguration(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	base := model.Configuration{
+		ID:      uuid.New(),
+		Name:    "service-api-base",
+		Version: "v1",
+		Params: []model.Parameter{
+			{Key: "db.host", Value: "localhost"},
+			{Key: "db.port", Value: "5432"},
+			{Key: "debug", Value: "true"},
+		},
+	}
+	prod := model.Configuration{
+		ID:           uuid.New(),
+		Name:         "service-api",
+		Version:      "prod",
+		Params:       []model.Parameter{{Key: "db.host", Value: "db.prod"}, {Key: "replicas", Value: "3"}},
+		Parent:       &model.ConfigurationRef{Name: "service-api-base", Version: "v1"},
+		RemoveParams: []string{"debug"},
+	}
+
+	if _, err := service.AddConfiguration(ctx, base, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if _, err := service.AddConfiguration(ctx, prod, ""); err != nil {
+		t.Fatalf("AddConfiguration with parent failed: %v", err)
+	}
+
+	effective, err := service.GetEffectiveConfiguration(ctx, "service-api", "prod")
+	if err != nil {
+		t.Fatalf("GetEffectiveConfiguration failed: %v", err)
+	}
+
+	if len(effective.Layers) != 2 || effective.Layers[0].Name != "service-api-base" || effective.Layers[1].Name != "service-api" {
+		t.Errorf("Unexpected layers: %+v", effective.Layers)
+	}
+
+	expected := map[string]struct{ value, source string }{
+		"db.host":  {"db.prod", "service-api"},
+		"db.port":  {"5432", "service-api-base"},
+		"replicas": {"3", "service-api"},
+	}
+	if len(effective.Params) != len(expected) {
+		t.Fatalf("Expected %d params, got %d: %+v", len(expected), len(effective.Params), effective.Params)
+	}
+	for _, p := range effective.Params {
+		want, ok := expected[p.Key]
+		if !ok {
+			t.Errorf("Unexpected param %s (removed params must not be inherited)", p.Key)
+			continue
+		}
+		if p.Value != want.value || p.Source.Name != want.source {
+			t.Errorf("Param %s: expected %s from %s, got %s from %s", p.Key, want.value, want.source, p.Value, p.Source.Name)
+		}
+	}
+}
+
+func TestConfigurationService_InheritanceErrors(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	orphan := model.Configuration{
+		Name:    "orphan",
+		Version: "v1",
+		Parent:  &model.ConfigurationRef{Name: "missing", Version: "v1"},
+	}
+	if _, err := service.AddConfiguration(ctx, orphan, ""); !errors.Is(err, ErrInvalidParent) {
+		t.Errorf("Expected ErrInvalidParent for missing parent, got: %v", err)
+	}
+
+	a := model.Configuration{Name: "a", Version: "v1"}
+	b := model.Configuration{Name: "b", Version: "v1", Parent: &model.ConfigurationRef{Name: "a", Version: "v1"}}
+	if _, err := service.AddConfiguration(ctx, a, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if _, err := service.AddConfiguration(ctx, b, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	a.Parent = &model.ConfigurationRef{Name: "b", Version: "v1"}
+	if _, err := service.UpdateConfiguration(ctx, a, ""); !errors.Is(err, ErrInheritanceCycle) {
+		t.Errorf("Expected ErrInheritanceCycle on update, got: %v", err)
+	}
+
+	// Ciklus upisan direktno u repozitorijum mora biti otkriven i pri citanju
+	mockRepo.configs[mockRepo.makeConfigKey("a", "v1")] = a
+	if _, err := service.GetEffectiveConfiguration(ctx, "b", "v1"); !errors.Is(err, ErrInheritanceCycle) {
+		t.Errorf("Expected ErrInheritanceCycle on read, got: %v", err)
+	}
+}
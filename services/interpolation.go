@@ -0,0 +1,119 @@
+package services
+
+import (
+	"alati_projekat/interpolate"
+	"alati_projekat/model"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// paramResolver expands ${...} placeholders across configurations for a single request.
+// Effective params of every visited configuration and every resolved value are cached.
+type paramResolver struct {
+	service *ConfigurationService
+	ctx     context.Context
+	strict  bool
+	params  map[string]map[string]string
+	values  map[string]string
+	stack   []string
+}
+
+func (s *ConfigurationService) newParamResolver(ctx context.Context, strict bool) *paramResolver {
+	return &paramResolver{
+		service: s,
+		ctx:     ctx,
+		strict:  strict,
+		params:  map[string]map[string]string{},
+		values:  map[string]string{},
+	}
+}
+
+func (r *paramResolver) seed(effective model.EffectiveConfiguration) {
+	params := make(map[string]string, len(effective.Params))
+	for _, p := range effective.Params {
+		params[p.Key] = p.Value
+	}
+	r.params[refKey(effective.Name, effective.Version)] = params
+}
+
+// effectiveParams returns the merged params of a configuration, or nil when it does not exist.
+func (r *paramResolver) effectiveParams(ref model.ConfigurationRef) (map[string]string, error) {
+	key := refKey(ref.Name, ref.Version)
+	if params, ok := r.params[key]; ok {
+		return params, nil
+	}
+
+	effective, err := r.service.GetEffectiveConfiguration(r.ctx, ref.Name, ref.Version)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			r.params[key] = nil
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	r.seed(effective)
+	return r.params[key], nil
+}
+
+func (r *paramResolver) resolve(ref model.ConfigurationRef, key string) (string, bool, error) {
+	id := refKey(ref.Name, ref.Version) + "#" + key
+	if value, ok := r.values[id]; ok {
+		return value, true, nil
+	}
+	for i, visiting := range r.stack {
+		if visiting == id {
+			path := append(append([]string{}, r.stack[i:]...), id)
+			return "", false, fmt.Errorf("%w: %s", ErrReferenceCycle, strings.Join(path, " -> "))
+		}
+	}
+
+	params, err := r.effectiveParams(ref)
+	if err != nil {
+		return "", false, err
+	}
+	raw, ok := params[key]
+	if !ok {
+		return "", false, nil
+	}
+
+	r.stack = append(r.stack, id)
+	value, err := interpolate.Expand(raw, func(inner interpolate.Reference) (string, bool, error) {
+		target := ref
+		if inner.Config != nil {
+			target = *inner.Config
+		}
+		return r.resolve(target, inner.Key)
+	}, r.strict)
+	r.stack = r.stack[:len(r.stack)-1]
+	if err != nil {
+		return "", false, err
+	}
+
+	r.values[id] = value
+	return value, true, nil
+}
+
+// InterpolateConfiguration returns the effective configuration with every ${...} placeholder resolved.
+// References without a value are kept verbatim unless strict is set, in which case the read fails.
+func (s *ConfigurationService) InterpolateConfiguration(ctx context.Context, name string, version string, strict bool) (model.EffectiveConfiguration, error) {
+	effective, err := s.GetEffectiveConfiguration(ctx, name, version)
+	if err != nil {
+		return model.EffectiveConfiguration{}, err
+	}
+
+	resolver := s.newParamResolver(ctx, strict)
+	resolver.seed(effective)
+
+	self := model.ConfigurationRef{Name: name, Version: version}
+	for i, p := range effective.Params {
+		value, _, err := resolver.resolve(self, p.Key)
+		if err != nil {
+			return model.EffectiveConfiguration{}, err
+		}
+		effective.Params[i].Value = value
+	}
+
+	return effective, nil
+}
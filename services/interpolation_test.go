@@ -0,0 +1,74 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestConfigurationService_InterpolateConfiguration(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	shared := model.Configuration{
+		Name:    "shared-db",
+		Version: "v3",
+		Params:  []model.Parameter{{Key: "host", Value: "db.${domain}"}, {Key: "domain", Value: "internal"}},
+	}
+	api := model.Configuration{
+		Name:    "service-api",
+		Version: "v1",
+		Params: []model.Parameter{
+			{Key: "db.host", Value: "${ref:shared-db/v3#host}"},
+			{Key: "db.port", Value: "${port:-5432}"},
+			{Key: "db.url", Value: "${db.host}:${db.port}"},
+			{Key: "cache", Value: "${ref:cache/v1#host}"},
+		},
+	}
+	for _, c := range []model.Configuration{shared, api} {
+		if _, err := service.AddConfiguration(ctx, c, ""); err != nil {
+			t.Fatalf("Setup failed: %v", err)
+		}
+	}
+
+	resolved, err := service.InterpolateConfiguration(ctx, "service-api", "v1", false)
+	if err != nil {
+		t.Fatalf("InterpolateConfiguration failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"db.host": "db.internal",
+		"db.port": "5432",
+		"db.url":  "db.internal:5432",
+		"cache":   "${ref:cache/v1#host}",
+	}
+	for _, p := range resolved.Params {
+		if expected[p.Key] != p.Value {
+			t.Errorf("Param %s: expected %q, got %q", p.Key, expected[p.Key], p.Value)
+		}
+	}
+
+	if _, err := service.InterpolateConfiguration(ctx, "service-api", "v1", true); !errors.Is(err, ErrUnresolvedReference) {
+		t.Errorf("Expected ErrUnresolvedReference in strict mode, got: %v", err)
+	}
+}
+
+func TestConfigurationService_InterpolateConfiguration_Cycle(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	a := model.Configuration{Name: "a", Version: "v1", Params: []model.Parameter{{Key: "x", Value: "${ref:b/v1#y}"}}}
+	b := model.Configuration{Name: "b", Version: "v1", Params: []model.Parameter{{Key: "y", Value: "${ref:a/v1#x}"}}}
+	for _, c := range []model.Configuration{a, b} {
+		if _, err := service.AddConfiguration(ctx, c, ""); err != nil {
+			t.Fatalf("Setup failed: %v", err)
+		}
+	}
+
+	if _, err := service.InterpolateConfiguration(ctx, "a", "v1", false); !errors.Is(err, ErrReferenceCycle) {
+		t.Errorf("Expected ErrReferenceCycle, got: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/validation"
+	"context"
+	"fmt"
+	"time"
+)
+
+// allowedTransitions lists the lifecycle states reachable from each state.
+var allowedTransitions = map[model.LifecycleState][]model.LifecycleState{
+	model.StateDraft:      {model.StatePublished, model.StateArchived},
+	model.StatePublished:  {model.StateDeprecated, model.StateArchived},
+	model.StateDeprecated: {model.StatePublished, model.StateArchived},
+	model.StateArchived:   {},
+}
+
+// transition moves l to target, stamping deprecation data when entering StateDeprecated
+// and clearing it when leaving.
+func transition(l model.Lifecycle, target model.LifecycleState, sunset *time.Time, now time.Time) (model.Lifecycle, error) {
+	current := l.CurrentState()
+	if _, known := allowedTransitions[target]; !known {
+		return l, fmt.Errorf("%w: unknown state %q", ErrInvalidTransition, target)
+	}
+
+	allowed := false
+	for _, next := range allowedTransitions[current] {
+		if next == target {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return l, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, current, target)
+	}
+
+	l.State = target
+	switch target {
+	case model.StateDeprecated:
+		if sunset != nil && !sunset.After(now) {
+			return l, fmt.Errorf("%w: sunset must be in the future", ErrInvalidTransition)
+		}
+		deprecatedAt := now.UTC()
+		l.DeprecatedAt = &deprecatedAt
+		l.SunsetAt = sunset
+	case model.StatePublished:
+		l.DeprecatedAt = nil
+		l.SunsetAt = nil
+	}
+	return l, nil
+}
+
+// validateLifecycle checks lifecycle data that did not come from transition, such as an
+// imported snapshot: the state must be known and the deprecation times must match it.
+func validateLifecycle(v *validation.Validator, field string, l model.Lifecycle) {
+	state := l.CurrentState()
+	if _, known := allowedTransitions[state]; !known {
+		v.Add(field+".state", "must be %q, %q, %q or %q", model.StateDraft, model.StatePublished, model.StateDeprecated, model.StateArchived)
+		return
+	}
+	switch {
+	case state == model.StateDeprecated && l.DeprecatedAt == nil:
+		v.Add(field+".deprecatedAt", "is required for deprecated entities")
+	case (state == model.StateDraft || state == model.StatePublished) && l.DeprecatedAt != nil:
+		v.Add(field+".deprecatedAt", "must be empty for %s entities", state)
+	}
+	switch {
+	case l.SunsetAt == nil:
+	case state == model.StateDraft || state == model.StatePublished:
+		v.Add(field+".sunsetAt", "must be empty for %s entities", state)
+	case l.DeprecatedAt == nil:
+		v.Add(field+".sunsetAt", "requires deprecatedAt")
+	case !l.SunsetAt.After(*l.DeprecatedAt):
+		v.Add(field+".sunsetAt", "must be after deprecatedAt")
+	}
+}
+
+func requireDraft(kind, name, version string, l model.Lifecycle) error {
+	if state := l.CurrentState(); state != model.StateDraft {
+		return fmt.Errorf("%w: %s %s/%s is %s", ErrImmutable, kind, name, version, state)
+	}
+	return nil
+}
+
+// requireDeletable allows deleting drafts and archived entities only, so that published
+// and deprecated versions are archived before they disappear from under their consumers.
+func requireDeletable(kind, name, version string, l model.Lifecycle) error {
+	if state := l.CurrentState(); state != model.StateDraft && state != model.StateArchived {
+		return fmt.Errorf("%w: %s %s/%s is %s and must be archived before deletion", ErrImmutable, kind, name, version, state)
+	}
+	return nil
+}
+
+// TransitionConfiguration moves a configuration to another lifecycle state.
+func (s *ConfigurationService) TransitionConfiguration(ctx context.Context, name string, version string, target model.LifecycleState, sunset *time.Time) (model.Configuration, error) {
+	config, err := s.Repo.GetConfiguration(ctx, name, version)
+	if err != nil {
+		return model.Configuration{}, err
+	}
+
+	config.Lifecycle, err = transition(config.Lifecycle, target, sunset, now())
+	if err != nil {
+		return model.Configuration{}, err
+	}
+
+	config.Metadata = touchMetadata(ctx, config.Metadata)
+
+	if err := s.Repo.UpdateConfiguration(ctx, config); err != nil {
+		return model.Configuration{}, err
+	}
+	return config, nil
+}
+
+// TransitionConfigurationGroup moves a configuration group to another lifecycle state.
+func (s *ConfigurationService) TransitionConfigurationGroup(ctx context.Context, name string, version string, target model.LifecycleState, sunset *time.Time) (model.ConfigurationGroup, error) {
+	group, err := s.Repo.GetConfigurationGroup(ctx, name, version)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+
+	group.Lifecycle, err = transition(group.Lifecycle, target, sunset, now())
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+
+	group.Metadata = touchMetadata(ctx, group.Metadata)
+
+	if err := s.Repo.UpdateConfigurationGroup(ctx, group); err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	return group, nil
+}
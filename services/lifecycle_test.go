@@ -0,0 +1,91 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfigurationService_Lifecycle(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	config := model.Configuration{Name: "service-api", Version: "v1.0.0", Params: []model.Parameter{{Key: "port", Value: "8080"}}}
+	if _, err := service.AddConfiguration(ctx, config, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	stored, _ := service.GetConfiguration(ctx, "service-api", "v1.0.0")
+	if stored.State != model.StateDraft {
+		t.Fatalf("New configuration should be a draft, got %q", stored.State)
+	}
+
+	if _, err := service.UpdateConfiguration(ctx, config, ""); err != nil {
+		t.Fatalf("Draft update failed: %v", err)
+	}
+
+	if _, err := service.TransitionConfiguration(ctx, "service-api", "v1.0.0", model.StatePublished, nil); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if _, err := service.UpdateConfiguration(ctx, config, ""); !errors.Is(err, ErrImmutable) {
+		t.Errorf("Expected ErrImmutable when updating a published configuration, got: %v", err)
+	}
+
+	sunset := time.Now().Add(24 * time.Hour)
+	deprecated, err := service.TransitionConfiguration(ctx, "service-api", "v1.0.0", model.StateDeprecated, &sunset)
+	if err != nil {
+		t.Fatalf("Deprecate failed: %v", err)
+	}
+	if deprecated.DeprecatedAt == nil || deprecated.SunsetAt == nil {
+		t.Errorf("Deprecation timestamps not set: %+v", deprecated.Lifecycle)
+	}
+
+	if err := service.DeleteConfiguration(ctx, "service-api", "v1.0.0"); !errors.Is(err, ErrImmutable) {
+		t.Errorf("Expected ErrImmutable when deleting a deprecated configuration, got: %v", err)
+	}
+
+	if _, err := service.TransitionConfiguration(ctx, "service-api", "v1.0.0", model.StateArchived, nil); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	if _, err := service.TransitionConfiguration(ctx, "service-api", "v1.0.0", model.StatePublished, nil); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition out of archived, got: %v", err)
+	}
+	if err := service.DeleteConfiguration(ctx, "service-api", "v1.0.0"); err != nil {
+		t.Errorf("Deleting an archived configuration failed: %v", err)
+	}
+}
+
+func TestConfigurationService_GroupLifecycle(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	group := model.ConfigurationGroup{Name: "cluster", Version: "v1.0.0"}
+	if _, err := service.AddConfigurationGroup(ctx, group, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if _, err := service.TransitionConfigurationGroup(ctx, "cluster", "v1.0.0", model.StateDeprecated, nil); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition for draft -> deprecated, got: %v", err)
+	}
+	if _, err := service.TransitionConfigurationGroup(ctx, "cluster", "v1.0.0", model.StatePublished, nil); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if _, err := service.UpdateConfigurationGroup(ctx, group, ""); !errors.Is(err, ErrImmutable) {
+		t.Errorf("Expected ErrImmutable when updating a published group, got: %v", err)
+	}
+	if _, err := service.DeleteConfigsByLabels(ctx, "cluster", "v1.0.0", map[string]string{"env": "dev"}); !errors.Is(err, ErrImmutable) {
+		t.Errorf("Expected ErrImmutable when deleting members of a published group, got: %v", err)
+	}
+	if err := service.DeleteConfigurationGroup(ctx, "cluster", "v1.0.0"); !errors.Is(err, ErrImmutable) {
+		t.Errorf("Expected ErrImmutable when deleting a published group, got: %v", err)
+	}
+	if _, err := service.TransitionConfigurationGroup(ctx, "cluster", "v1.0.0", model.StateArchived, nil); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	if err := service.DeleteConfigurationGroup(ctx, "cluster", "v1.0.0"); err != nil {
+		t.Errorf("Deleting an archived group failed: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/repository"
+	"alati_projekat/validation"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// updateGroup reads a draft group, lets mutate change it and writes it back with a
+// compare-and-swap on the revision that was read, retrying when a concurrent writer got
+// in between. mutate must not modify the slices of the group in place and reports whether
+// it changed anything; unchanged groups are not written.
+func (s *ConfigurationService) updateGroup(ctx context.Context, name, version string, mutate func(*model.ConfigurationGroup) (bool, error)) (model.ConfigurationGroup, error) {
+	for attempt := 1; ; attempt++ {
+		group, err := s.Repo.GetConfigurationGroup(ctx, name, version)
+		if err != nil {
+			return model.ConfigurationGroup{}, err
+		}
+		if err := requireDraft("configuration group", name, version, group.Lifecycle); err != nil {
+			return model.ConfigurationGroup{}, err
+		}
+
+		revision := group.Metadata.Revision
+		changed, err := mutate(&group)
+		if err != nil || !changed {
+			return group, err
+		}
+		if err := validation.Group(group); err != nil {
+			return model.ConfigurationGroup{}, err
+		}
+		group.Metadata = touchMetadata(ctx, group.Metadata)
+
+		err = s.Repo.CompareAndSwapConfigurationGroup(ctx, group, revision)
+		if errors.Is(err, repository.ErrRevisionConflict) && attempt < maxPatchAttempts {
+			continue
+		}
+		if err != nil {
+			return model.ConfigurationGroup{}, err
+		}
+		return group, nil
+	}
+}
+
+// memberIndex returns the position of the member with the given name and version, or -1.
+func memberIndex(group model.ConfigurationGroup, name, version string) int {
+	for i, c := range group.Configurations {
+		if c.Name == name && c.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddGroupMember appends member to the group. A group holds at most one configuration
+// with a given name and version.
+func (s *ConfigurationService) AddGroupMember(ctx context.Context, name, version string, member model.Configuration) (model.ConfigurationGroup, error) {
+	return s.updateGroup(ctx, name, version, func(g *model.ConfigurationGroup) (bool, error) {
+		if memberIndex(*g, member.Name, member.Version) >= 0 {
+			return false, fmt.Errorf("%w: %s/%s in group %s/%s", ErrDuplicateMember, member.Name, member.Version, name, version)
+		}
+		members := make([]model.Configuration, 0, len(g.Configurations)+1)
+		g.Configurations = append(append(members, g.Configurations...), member)
+		return true, nil
+	})
+}
+
+// UpdateGroupMember replaces the member with the same name and version as member.
+func (s *ConfigurationService) UpdateGroupMember(ctx context.Context, name, version string, member model.Configuration) (model.ConfigurationGroup, error) {
+	return s.updateGroup(ctx, name, version, func(g *model.ConfigurationGroup) (bool, error) {
+		i := memberIndex(*g, member.Name, member.Version)
+		if i < 0 {
+			return false, fmt.Errorf("%w: %s/%s in group %s/%s", ErrMemberNotFound, member.Name, member.Version, name, version)
+		}
+		if member.ID == uuid.Nil {
+			member.ID = g.Configurations[i].ID
+		}
+		members := append([]model.Configuration(nil), g.Configurations...)
+		members[i] = member
+		g.Configurations = members
+		return true, nil
+	})
+}
+
+// RemoveGroupMember removes the member with the given name and version from the group.
+func (s *ConfigurationService) RemoveGroupMember(ctx context.Context, name, version, memberName, memberVersion string) (model.ConfigurationGroup, error) {
+	return s.updateGroup(ctx, name, version, func(g *model.ConfigurationGroup) (bool, error) {
+		i := memberIndex(*g, memberName, memberVersion)
+		if i < 0 {
+			return false, fmt.Errorf("%w: %s/%s in group %s/%s", ErrMemberNotFound, memberName, memberVersion, name, version)
+		}
+		members := make([]model.Configuration, 0, len(g.Configurations)-1)
+		g.Configurations = append(append(members, g.Configurations[:i]...), g.Configurations[i+1:]...)
+		return true, nil
+	})
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestConfigurationService_GroupMembership(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	if _, err := service.AddConfigurationGroup(ctx, model.ConfigurationGroup{Name: "cluster", Version: "v1"}, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	api := model.Configuration{Name: "api", Version: "v1", Params: []model.Parameter{{Key: "port", Value: "80"}}}
+	group, err := service.AddGroupMember(ctx, "cluster", "v1", api)
+	if err != nil {
+		t.Fatalf("AddGroupMember failed: %v", err)
+	}
+	if len(group.Configurations) != 1 || group.Metadata.Revision != 2 {
+		t.Errorf("Expected one member at revision 2, got %+v", group)
+	}
+	if _, err := service.AddGroupMember(ctx, "cluster", "v1", api); !errors.Is(err, ErrDuplicateMember) {
+		t.Errorf("Expected ErrDuplicateMember, got: %v", err)
+	}
+	if _, err := service.AddGroupMember(ctx, "cluster", "v1", model.Configuration{Name: "bad/name", Version: "v1"}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for invalid member, got: %v", err)
+	}
+
+	api.Params = []model.Parameter{{Key: "port", Value: "8080"}}
+	if _, err := service.UpdateGroupMember(ctx, "cluster", "v1", api); err != nil {
+		t.Fatalf("UpdateGroupMember failed: %v", err)
+	}
+	stored, _ := mockRepo.GetConfigurationGroup(ctx, "cluster", "v1")
+	if stored.Configurations[0].Params[0].Value != "8080" {
+		t.Errorf("Member was not updated: %+v", stored.Configurations)
+	}
+	if _, err := service.UpdateGroupMember(ctx, "cluster", "v1", model.Configuration{Name: "web", Version: "v1"}); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound, got: %v", err)
+	}
+
+	if _, err := service.RemoveGroupMember(ctx, "cluster", "v1", "api", "v1"); err != nil {
+		t.Fatalf("RemoveGroupMember failed: %v", err)
+	}
+	stored, _ = mockRepo.GetConfigurationGroup(ctx, "cluster", "v1")
+	if len(stored.Configurations) != 0 || stored.Metadata.Revision != 4 {
+		t.Errorf("Expected empty group at revision 4, got %+v", stored)
+	}
+
+	if _, err := service.TransitionConfigurationGroup(ctx, "cluster", "v1", model.StatePublished, nil); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if _, err := service.AddGroupMember(ctx, "cluster", "v1", api); !errors.Is(err, ErrImmutable) {
+		t.Errorf("Expected ErrImmutable for published group, got: %v", err)
+	}
+}
+
+func TestConfigurationService_AddConfigurationGroup_DuplicateMembers(t *testing.T) {
+	service := NewConfigurationService(NewMockRepository())
+	group := model.ConfigurationGroup{
+		Name:           "cluster",
+		Version:        "v1",
+		Configurations: []model.Configuration{{Name: "api", Version: "v1"}, {Name: "api", Version: "v1"}},
+	}
+	if _, err := service.AddConfigurationGroup(context.Background(), group, ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for duplicate members, got: %v", err)
+	}
+}
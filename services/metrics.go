@@ -44,7 +44,7 @@ func (s *MetricsService) measure(method string, start time.Time) {
 	s.RequestLatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
 }
 
-func (s *MetricsService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (err error) {
+func (s *MetricsService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (out model.Configuration, err error) {
 	defer s.measure("AddConfiguration", time.Now())
 	return s.Next.AddConfiguration(ctx, config, idempotencyKey)
 }
@@ -54,17 +54,52 @@ func (s *MetricsService) GetConfiguration(ctx context.Context, name string, vers
 	return s.Next.GetConfiguration(ctx, name, version)
 }
 
+func (s *MetricsService) WatchConfiguration(ctx context.Context, name string, version string, index uint64, wait time.Duration) (out model.Configuration, next uint64, err error) {
+	defer s.measure("WatchConfiguration", time.Now())
+	return s.Next.WatchConfiguration(ctx, name, version, index, wait)
+}
+
 func (s *MetricsService) UpdateConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (out model.Configuration, err error) {
 	defer s.measure("UpdateConfiguration", time.Now())
 	return s.Next.UpdateConfiguration(ctx, config, idempotencyKey)
 }
 
+func (s *MetricsService) PatchConfiguration(ctx context.Context, name string, version string, patchType string, patch []byte, ifMatch string) (out model.Configuration, err error) {
+	defer s.measure("PatchConfiguration", time.Now())
+	return s.Next.PatchConfiguration(ctx, name, version, patchType, patch, ifMatch)
+}
+
 func (s *MetricsService) DeleteConfiguration(ctx context.Context, name string, version string) (err error) {
 	defer s.measure("DeleteConfiguration", time.Now())
 	return s.Next.DeleteConfiguration(ctx, name, version)
 }
 
-func (s *MetricsService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (err error) {
+func (s *MetricsService) GetEffectiveConfiguration(ctx context.Context, name string, version string) (out model.EffectiveConfiguration, err error) {
+	defer s.measure("GetEffectiveConfiguration", time.Now())
+	return s.Next.GetEffectiveConfiguration(ctx, name, version)
+}
+
+func (s *MetricsService) InterpolateConfiguration(ctx context.Context, name string, version string, strict bool) (out model.EffectiveConfiguration, err error) {
+	defer s.measure("InterpolateConfiguration", time.Now())
+	return s.Next.InterpolateConfiguration(ctx, name, version, strict)
+}
+
+func (s *MetricsService) ResolveConfigurationVersion(ctx context.Context, name string, selector string, includePrerelease bool) (out model.Configuration, err error) {
+	defer s.measure("ResolveConfigurationVersion", time.Now())
+	return s.Next.ResolveConfigurationVersion(ctx, name, selector, includePrerelease)
+}
+
+func (s *MetricsService) TransitionConfiguration(ctx context.Context, name string, version string, target model.LifecycleState, sunset *time.Time) (out model.Configuration, err error) {
+	defer s.measure("TransitionConfiguration", time.Now())
+	return s.Next.TransitionConfiguration(ctx, name, version, target, sunset)
+}
+
+func (s *MetricsService) CloneConfiguration(ctx context.Context, name string, version string, req model.CloneRequest, idempotencyKey string) (out model.Configuration, err error) {
+	defer s.measure("CloneConfiguration", time.Now())
+	return s.Next.CloneConfiguration(ctx, name, version, req, idempotencyKey)
+}
+
+func (s *MetricsService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (out model.ConfigurationGroup, err error) {
 	defer s.measure("AddConfigurationGroup", time.Now())
 	return s.Next.AddConfigurationGroup(ctx, group, idempotencyKey)
 }
@@ -74,17 +109,57 @@ func (s *MetricsService) GetConfigurationGroup(ctx context.Context, name string,
 	return s.Next.GetConfigurationGroup(ctx, name, version)
 }
 
+func (s *MetricsService) WatchConfigurationGroup(ctx context.Context, name string, version string, index uint64, wait time.Duration) (out model.ConfigurationGroup, next uint64, err error) {
+	defer s.measure("WatchConfigurationGroup", time.Now())
+	return s.Next.WatchConfigurationGroup(ctx, name, version, index, wait)
+}
+
 func (s *MetricsService) UpdateConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (out model.ConfigurationGroup, err error) {
 	defer s.measure("UpdateConfigurationGroup", time.Now())
 	out, err = s.Next.UpdateConfigurationGroup(ctx, group, idempotencyKey)
 	return out, err
 }
 
+func (s *MetricsService) PatchConfigurationGroup(ctx context.Context, name string, version string, patchType string, patch []byte, ifMatch string) (out model.ConfigurationGroup, err error) {
+	defer s.measure("PatchConfigurationGroup", time.Now())
+	return s.Next.PatchConfigurationGroup(ctx, name, version, patchType, patch, ifMatch)
+}
+
 func (s *MetricsService) DeleteConfigurationGroup(ctx context.Context, name string, version string) (err error) {
 	defer s.measure("DeleteConfigurationGroup", time.Now())
 	return s.Next.DeleteConfigurationGroup(ctx, name, version)
 }
 
+func (s *MetricsService) AddGroupMember(ctx context.Context, name string, version string, member model.Configuration) (out model.ConfigurationGroup, err error) {
+	defer s.measure("AddGroupMember", time.Now())
+	return s.Next.AddGroupMember(ctx, name, version, member)
+}
+
+func (s *MetricsService) UpdateGroupMember(ctx context.Context, name string, version string, member model.Configuration) (out model.ConfigurationGroup, err error) {
+	defer s.measure("UpdateGroupMember", time.Now())
+	return s.Next.UpdateGroupMember(ctx, name, version, member)
+}
+
+func (s *MetricsService) RemoveGroupMember(ctx context.Context, name string, version string, memberName string, memberVersion string) (out model.ConfigurationGroup, err error) {
+	defer s.measure("RemoveGroupMember", time.Now())
+	return s.Next.RemoveGroupMember(ctx, name, version, memberName, memberVersion)
+}
+
+func (s *MetricsService) ResolveConfigurationGroupVersion(ctx context.Context, name string, selector string, includePrerelease bool) (out model.ConfigurationGroup, err error) {
+	defer s.measure("ResolveConfigurationGroupVersion", time.Now())
+	return s.Next.ResolveConfigurationGroupVersion(ctx, name, selector, includePrerelease)
+}
+
+func (s *MetricsService) TransitionConfigurationGroup(ctx context.Context, name string, version string, target model.LifecycleState, sunset *time.Time) (out model.ConfigurationGroup, err error) {
+	defer s.measure("TransitionConfigurationGroup", time.Now())
+	return s.Next.TransitionConfigurationGroup(ctx, name, version, target, sunset)
+}
+
+func (s *MetricsService) CloneConfigurationGroup(ctx context.Context, name string, version string, req model.CloneRequest, idempotencyKey string) (out model.ConfigurationGroup, err error) {
+	defer s.measure("CloneConfigurationGroup", time.Now())
+	return s.Next.CloneConfigurationGroup(ctx, name, version, req, idempotencyKey)
+}
+
 func (s *MetricsService) CheckIdempotencyKey(ctx context.Context, key string) (bool, error) {
 	return s.Next.CheckIdempotencyKey(ctx, key)
 }
@@ -93,6 +168,26 @@ func (s *MetricsService) SaveIdempotencyKey(ctx context.Context, key string) {
 	s.Next.SaveIdempotencyKey(ctx, key)
 }
 
+func (s *MetricsService) ExecuteBatch(ctx context.Context, req model.BatchRequest, idempotencyKey string) (out model.BatchResponse, err error) {
+	defer s.measure("ExecuteBatch", time.Now())
+	return s.Next.ExecuteBatch(ctx, req, idempotencyKey)
+}
+
+func (s *MetricsService) ExportSnapshot(ctx context.Context, opts model.ExportOptions) (out model.Snapshot, err error) {
+	defer s.measure("ExportSnapshot", time.Now())
+	return s.Next.ExportSnapshot(ctx, opts)
+}
+
+func (s *MetricsService) ImportSnapshot(ctx context.Context, snap model.Snapshot, opts model.ImportOptions) (out model.ImportSummary, err error) {
+	defer s.measure("ImportSnapshot", time.Now())
+	return s.Next.ImportSnapshot(ctx, snap, opts)
+}
+
+func (s *MetricsService) RenderConfiguration(ctx context.Context, name string, version string, selector map[string]string, includePrerelease bool) (out model.RenderedConfiguration, err error) {
+	defer s.measure("RenderConfiguration", time.Now())
+	return s.Next.RenderConfiguration(ctx, name, version, selector, includePrerelease)
+}
+
 func (s *MetricsService) FilterConfigsByLabels(ctx context.Context, name, version string, want map[string]string) (out []model.Configuration, err error) {
 	defer s.measure("FilterConfigsByLabels", time.Now())
 	return s.Next.FilterConfigsByLabels(ctx, name, version, want)
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"alati_projekat/etag"
+	"alati_projekat/model"
+	"alati_projekat/repository"
+	"alati_projekat/validation"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// Supported patch document media types.
+const (
+	MergePatchType = "application/merge-patch+json" // RFC 7396
+	JSONPatchType  = "application/json-patch+json"  // RFC 6902
+)
+
+// maxPatchAttempts bounds how often a patch is re-applied when a concurrent write wins the race.
+const maxPatchAttempts = 3
+
+// applyPatch applies a patch document to the JSON form of doc and decodes the result into out.
+func applyPatch(doc any, patchType string, patch []byte, out any) error {
+	original, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	switch patchType {
+	case MergePatchType:
+		if patched, err = jsonpatch.MergePatch(original, patch); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedPatch, err)
+		}
+	case JSONPatchType:
+		ops, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedPatch, err)
+		}
+		if patched, err = ops.Apply(original); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedPatchType, patchType)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return fmt.Errorf("%w: patched document is not valid: %v", ErrInvalidPatch, err)
+	}
+	return nil
+}
+
+func checkPatchedIdentity(kind, name, version, patchedName, patchedVersion string) error {
+	if patchedName != name || patchedVersion != version {
+		return fmt.Errorf("%w: the name and version of a %s cannot be patched", ErrInvalidPatch, kind)
+	}
+	return nil
+}
+
+// PatchConfiguration applies a JSON Merge Patch or JSON Patch to a stored configuration.
+// When ifMatch is set it must list the entity tag of the stored record. The write is a
+// compare-and-swap on the revision that was read, so concurrent updates are never lost.
+// Server-managed fields (id, lifecycle, metadata) are not affected by the patch.
+func (s *ConfigurationService) PatchConfiguration(ctx context.Context, name, version, patchType string, patch []byte, ifMatch string) (model.Configuration, error) {
+	for attempt := 1; ; attempt++ {
+		existing, err := s.Repo.GetConfiguration(ctx, name, version)
+		if err != nil {
+			return model.Configuration{}, err
+		}
+		if ifMatch != "" && !etag.Match(ifMatch, etag.Of(existing)) {
+			return model.Configuration{}, fmt.Errorf("%w: configuration %s/%s has changed", ErrPreconditionFailed, name, version)
+		}
+		if err := requireDraft("configuration", name, version, existing.Lifecycle); err != nil {
+			return model.Configuration{}, err
+		}
+
+		var patched model.Configuration
+		if err := applyPatch(existing, patchType, patch, &patched); err != nil {
+			return model.Configuration{}, err
+		}
+		if err := checkPatchedIdentity("configuration", name, version, patched.Name, patched.Version); err != nil {
+			return model.Configuration{}, err
+		}
+		if err := validation.Configuration(patched); err != nil {
+			return model.Configuration{}, err
+		}
+		patched.ID = existing.ID
+		patched.Lifecycle = existing.Lifecycle
+		patched.Metadata = touchMetadata(ctx, existing.Metadata)
+
+		if patched.Parent != nil {
+			if _, err := s.resolveChain(ctx, patched); err != nil {
+				return model.Configuration{}, err
+			}
+		}
+
+		err = s.Repo.CompareAndSwapConfiguration(ctx, patched, existing.Metadata.Revision)
+		if errors.Is(err, repository.ErrRevisionConflict) && attempt < maxPatchAttempts {
+			continue
+		}
+		if err != nil {
+			return model.Configuration{}, err
+		}
+		return patched, nil
+	}
+}
+
+// PatchConfigurationGroup applies a JSON Merge Patch or JSON Patch to a stored configuration group.
+// It follows the same rules as PatchConfiguration.
+func (s *ConfigurationService) PatchConfigurationGroup(ctx context.Context, name, version, patchType string, patch []byte, ifMatch string) (model.ConfigurationGroup, error) {
+	for attempt := 1; ; attempt++ {
+		existing, err := s.Repo.GetConfigurationGroup(ctx, name, version)
+		if err != nil {
+			return model.ConfigurationGroup{}, err
+		}
+		if ifMatch != "" && !etag.Match(ifMatch, etag.Of(existing)) {
+			return model.ConfigurationGroup{}, fmt.Errorf("%w: configuration group %s/%s has changed", ErrPreconditionFailed, name, version)
+		}
+		if err := requireDraft("configuration group", name, version, existing.Lifecycle); err != nil {
+			return model.ConfigurationGroup{}, err
+		}
+
+		var patched model.ConfigurationGroup
+		if err := applyPatch(existing, patchType, patch, &patched); err != nil {
+			return model.ConfigurationGroup{}, err
+		}
+		if err := checkPatchedIdentity("configuration group", name, version, patched.Name, patched.Version); err != nil {
+			return model.ConfigurationGroup{}, err
+		}
+		if err := validation.Group(patched); err != nil {
+			return model.ConfigurationGroup{}, err
+		}
+		patched.ID = existing.ID
+		patched.Lifecycle = existing.Lifecycle
+		patched.Metadata = touchMetadata(ctx, existing.Metadata)
+
+		err = s.Repo.CompareAndSwapConfigurationGroup(ctx, patched, existing.Metadata.Revision)
+		if errors.Is(err, repository.ErrRevisionConflict) && attempt < maxPatchAttempts {
+			continue
+		}
+		if err != nil {
+			return model.ConfigurationGroup{}, err
+		}
+		return patched, nil
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"alati_projekat/etag"
+	"alati_projekat/model"
+	"alati_projekat/repository"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestConfigurationService_PatchConfiguration(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	stored, err := service.AddConfiguration(ctx, model.Configuration{
+		Name:    "svc",
+		Version: "v1",
+		Params:  []model.Parameter{{Key: "port", Value: "8080"}, {Key: "debug", Value: "true"}},
+		Labels:  []model.Parameter{{Key: "env", Value: "dev"}},
+	}, "")
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	merged, err := service.PatchConfiguration(ctx, "svc", "v1", MergePatchType, []byte(`{"description":"api","id":"00000000-0000-0000-0000-000000000000"}`), etag.Of(stored))
+	if err != nil {
+		t.Fatalf("Merge patch failed: %v", err)
+	}
+	if merged.Description != "api" || merged.ID != stored.ID || len(merged.Labels) != 1 || merged.Metadata.Revision != 2 {
+		t.Errorf("Unexpected merge patch result: %+v", merged)
+	}
+
+	ops := `[{"op":"test","path":"/params/1/key","value":"debug"},{"op":"remove","path":"/params/1"},{"op":"add","path":"/labels/-","value":{"key":"team","value":"core"}}]`
+	patched, err := service.PatchConfiguration(ctx, "svc", "v1", JSONPatchType, []byte(ops), "")
+	if err != nil {
+		t.Fatalf("JSON patch failed: %v", err)
+	}
+	if len(patched.Params) != 1 || len(patched.Labels) != 2 || patched.Metadata.Revision != 3 {
+		t.Errorf("Unexpected JSON patch result: %+v", patched)
+	}
+
+	tests := []struct {
+		name      string
+		patchType string
+		patch     string
+		ifMatch   string
+		want      error
+	}{
+		{"stale If-Match", MergePatchType, `{"description":"x"}`, etag.Of(stored), ErrPreconditionFailed},
+		{"unsupported type", "application/json", `{}`, "", ErrUnsupportedPatchType},
+		{"malformed patch", JSONPatchType, `{"op":`, "", ErrMalformedPatch},
+		{"failed test op", JSONPatchType, `[{"op":"test","path":"/description","value":"other"}]`, "", ErrInvalidPatch},
+		{"rename", MergePatchType, `{"name":"other"}`, "", ErrInvalidPatch},
+		{"unknown field", MergePatchType, `{"parms":[]}`, "", ErrInvalidPatch},
+	}
+	for _, tt := range tests {
+		if _, err := service.PatchConfiguration(ctx, "svc", "v1", tt.patchType, []byte(tt.patch), tt.ifMatch); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	if _, err := service.TransitionConfiguration(ctx, "svc", "v1", model.StatePublished, nil); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if _, err := service.PatchConfiguration(ctx, "svc", "v1", MergePatchType, []byte(`{"description":"x"}`), ""); !errors.Is(err, ErrImmutable) {
+		t.Errorf("Expected ErrImmutable for published configuration, got %v", err)
+	}
+}
+
+// racingRepository simulates another writer that updates the group right before each compare-and-swap.
+type racingRepository struct {
+	*MockRepository
+	races int
+}
+
+func (r *racingRepository) CompareAndSwapConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, expectedRevision int64) error {
+	if r.races > 0 {
+		r.races--
+		current, _ := r.GetConfigurationGroup(ctx, group.Name, group.Version)
+		current.Metadata.Revision++
+		current.Description = "concurrent"
+		_ = r.UpdateConfigurationGroup(ctx, current)
+	}
+	return r.MockRepository.CompareAndSwapConfigurationGroup(ctx, group, expectedRevision)
+}
+
+func TestConfigurationService_PatchConfigurationGroup_Concurrent(t *testing.T) {
+	repo := &racingRepository{MockRepository: NewMockRepository()}
+	service := NewConfigurationService(repo)
+	ctx := context.Background()
+
+	if _, err := service.AddConfigurationGroup(ctx, model.ConfigurationGroup{Name: "grp", Version: "v1"}, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	// Jedan izgubljen CAS se ponavlja nad novim stanjem, bez gubljenja tudje izmene.
+	repo.races = 1
+	group, err := service.PatchConfigurationGroup(ctx, "grp", "v1", JSONPatchType, []byte(`[{"op":"add","path":"/configurations","value":[{"id":"00000000-0000-0000-0000-000000000000","name":"a","version":"v1","params":[]}]}]`), "")
+	if err != nil {
+		t.Fatalf("Patch after one lost race failed: %v", err)
+	}
+	if group.Description != "concurrent" || len(group.Configurations) != 1 {
+		t.Errorf("Expected patch to be re-applied on top of the concurrent write, got %+v", group)
+	}
+
+	repo.races = maxPatchAttempts
+	if _, err := service.PatchConfigurationGroup(ctx, "grp", "v1", MergePatchType, []byte(`{"description":"x"}`), ""); !errors.Is(err, repository.ErrRevisionConflict) {
+		t.Errorf("Expected ErrRevisionConflict after %d lost races, got %v", maxPatchAttempts, err)
+	}
+}
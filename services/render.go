@@ -0,0 +1,78 @@
+package services
+
+import (
+	"alati_projekat/labels"
+	"alati_projekat/model"
+	"alati_projekat/semver"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// RenderConfiguration merges the params of the members of group name/version whose labels
+// match selector into one flat configuration. Each member is first merged with its parents.
+// Members are then applied in precedence order: members with fewer labels are more generic
+// and are applied first, and members with the same number of labels are applied in group
+// order, so the last one wins. Keys that members set to different values are reported as
+// conflicts. The version may be a selector such as "latest" or "^1.2".
+func (s *ConfigurationService) RenderConfiguration(ctx context.Context, name, version string, selector map[string]string, includePrerelease bool) (model.RenderedConfiguration, error) {
+	var group model.ConfigurationGroup
+	var err error
+	if semver.IsSelector(version) {
+		group, err = s.ResolveConfigurationGroupVersion(ctx, name, version, includePrerelease)
+	} else {
+		group, err = s.Repo.GetConfigurationGroup(ctx, name, version)
+	}
+	if err != nil {
+		return model.RenderedConfiguration{}, err
+	}
+
+	var members []model.Configuration
+	for _, c := range group.Configurations {
+		if labels.HasAll(c, selector) {
+			members = append(members, c)
+		}
+	}
+	if len(members) == 0 {
+		return model.RenderedConfiguration{}, fmt.Errorf("%w in group %s/%s", ErrNoMatchingMembers, group.Name, group.Version)
+	}
+	sort.SliceStable(members, func(i, j int) bool { return len(members[i].Labels) < len(members[j].Labels) })
+
+	rendered := model.RenderedConfiguration{
+		Group:    model.ConfigurationRef{Name: group.Name, Version: group.Version},
+		Selector: selector,
+		Members:  make([]model.ConfigurationRef, 0, len(members)),
+		Params:   []model.EffectiveParameter{},
+	}
+	index := map[string]int{}
+	candidates := map[string][]model.ConflictingValue{}
+	for _, member := range members {
+		chain, err := s.resolveChain(ctx, member)
+		if err != nil {
+			return model.RenderedConfiguration{}, err
+		}
+		source := model.ConfigurationRef{Name: member.Name, Version: member.Version}
+		rendered.Members = append(rendered.Members, source)
+
+		for _, p := range mergeChain(chain).Params {
+			candidates[p.Key] = append(candidates[p.Key], model.ConflictingValue{Value: p.Value, Source: source})
+			if j, ok := index[p.Key]; ok {
+				rendered.Params[j] = p
+				continue
+			}
+			index[p.Key] = len(rendered.Params)
+			rendered.Params = append(rendered.Params, p)
+		}
+	}
+
+	for _, p := range rendered.Params {
+		values := candidates[p.Key]
+		for _, v := range values[1:] {
+			if v.Value != values[0].Value {
+				rendered.Conflicts = append(rendered.Conflicts, model.ParameterConflict{Key: p.Key, Values: values})
+				break
+			}
+		}
+	}
+	return rendered, nil
+}
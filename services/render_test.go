@@ -0,0 +1,69 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestConfigurationService_RenderConfiguration(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	if _, err := service.AddConfiguration(ctx, model.Configuration{
+		Name: "defaults", Version: "v1",
+		Params: []model.Parameter{{Key: "timeout", Value: "30s"}, {Key: "log.level", Value: "info"}},
+	}, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if _, err := service.AddConfigurationGroup(ctx, model.ConfigurationGroup{
+		Name: "service-api", Version: "1.0.0",
+		Configurations: []model.Configuration{
+			// Specifičniji član je prvi u grupi, ali se primenjuje poslednji
+			{Name: "prod-eu", Version: "v1",
+				Labels: []model.Parameter{{Key: "env", Value: "prod"}, {Key: "region", Value: "eu"}},
+				Params: []model.Parameter{{Key: "db.host", Value: "db.eu"}}},
+			{Name: "prod", Version: "v1",
+				Labels: []model.Parameter{{Key: "env", Value: "prod"}},
+				Parent: &model.ConfigurationRef{Name: "defaults", Version: "v1"},
+				Params: []model.Parameter{{Key: "db.host", Value: "db"}, {Key: "log.level", Value: "warn"}}},
+			{Name: "staging", Version: "v1",
+				Labels: []model.Parameter{{Key: "env", Value: "staging"}},
+				Params: []model.Parameter{{Key: "db.host", Value: "db.staging"}}},
+		},
+	}, ""); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	rendered, err := service.RenderConfiguration(ctx, "service-api", "1.0.0", map[string]string{"env": "prod"}, false)
+	if err != nil {
+		t.Fatalf("RenderConfiguration failed: %v", err)
+	}
+
+	if len(rendered.Members) != 2 || rendered.Members[0].Name != "prod" || rendered.Members[1].Name != "prod-eu" {
+		t.Fatalf("Expected members [prod prod-eu], got %+v", rendered.Members)
+	}
+	want := map[string]string{"timeout": "30s", "log.level": "warn", "db.host": "db.eu"}
+	if len(rendered.Params) != len(want) {
+		t.Errorf("Expected %d params, got %+v", len(want), rendered.Params)
+	}
+	for _, p := range rendered.Params {
+		if want[p.Key] != p.Value {
+			t.Errorf("Param %s: expected %q, got %q", p.Key, want[p.Key], p.Value)
+		}
+	}
+	if len(rendered.Conflicts) != 1 || rendered.Conflicts[0].Key != "db.host" || len(rendered.Conflicts[0].Values) != 2 {
+		t.Errorf("Expected a single db.host conflict, got %+v", rendered.Conflicts)
+	}
+
+	staging, err := service.RenderConfiguration(ctx, "service-api", "latest", map[string]string{"env": "staging"}, false)
+	if err != nil || len(staging.Conflicts) != 0 || len(staging.Params) != 1 {
+		t.Errorf("Expected conflict-free staging render, got %+v (%v)", staging, err)
+	}
+
+	if _, err := service.RenderConfiguration(ctx, "service-api", "1.0.0", map[string]string{"env": "dev"}, false); !errors.Is(err, ErrNoMatchingMembers) {
+		t.Errorf("Expected ErrNoMatchingMembers, got: %v", err)
+	}
+}
@@ -3,22 +3,45 @@ package services
 import (
 	"alati_projekat/model"
 	"context"
+	"time"
 )
 
 type Service interface {
-	AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) error
+	AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error)
 	GetConfiguration(ctx context.Context, name string, version string) (model.Configuration, error)
+	WatchConfiguration(ctx context.Context, name string, version string, index uint64, wait time.Duration) (model.Configuration, uint64, error)
 	UpdateConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (model.Configuration, error)
+	PatchConfiguration(ctx context.Context, name string, version string, patchType string, patch []byte, ifMatch string) (model.Configuration, error)
 	DeleteConfiguration(ctx context.Context, name string, version string) error
+	GetEffectiveConfiguration(ctx context.Context, name string, version string) (model.EffectiveConfiguration, error)
+	InterpolateConfiguration(ctx context.Context, name string, version string, strict bool) (model.EffectiveConfiguration, error)
+	ResolveConfigurationVersion(ctx context.Context, name string, selector string, includePrerelease bool) (model.Configuration, error)
+	TransitionConfiguration(ctx context.Context, name string, version string, target model.LifecycleState, sunset *time.Time) (model.Configuration, error)
+	CloneConfiguration(ctx context.Context, name string, version string, req model.CloneRequest, idempotencyKey string) (model.Configuration, error)
 
-	AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) error
+	AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (model.ConfigurationGroup, error)
 	GetConfigurationGroup(ctx context.Context, name string, version string) (model.ConfigurationGroup, error)
+	WatchConfigurationGroup(ctx context.Context, name string, version string, index uint64, wait time.Duration) (model.ConfigurationGroup, uint64, error)
 	UpdateConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (model.ConfigurationGroup, error)
+	PatchConfigurationGroup(ctx context.Context, name string, version string, patchType string, patch []byte, ifMatch string) (model.ConfigurationGroup, error)
 	DeleteConfigurationGroup(ctx context.Context, name string, version string) error
+	AddGroupMember(ctx context.Context, name string, version string, member model.Configuration) (model.ConfigurationGroup, error)
+	UpdateGroupMember(ctx context.Context, name string, version string, member model.Configuration) (model.ConfigurationGroup, error)
+	RemoveGroupMember(ctx context.Context, name string, version string, memberName string, memberVersion string) (model.ConfigurationGroup, error)
+	ResolveConfigurationGroupVersion(ctx context.Context, name string, selector string, includePrerelease bool) (model.ConfigurationGroup, error)
+	TransitionConfigurationGroup(ctx context.Context, name string, version string, target model.LifecycleState, sunset *time.Time) (model.ConfigurationGroup, error)
+	CloneConfigurationGroup(ctx context.Context, name string, version string, req model.CloneRequest, idempotencyKey string) (model.ConfigurationGroup, error)
 
 	CheckIdempotencyKey(ctx context.Context, key string) (bool, error)
 	SaveIdempotencyKey(ctx context.Context, key string)
 
+	ExecuteBatch(ctx context.Context, req model.BatchRequest, idempotencyKey string) (model.BatchResponse, error)
+
+	ExportSnapshot(ctx context.Context, opts model.ExportOptions) (model.Snapshot, error)
+	ImportSnapshot(ctx context.Context, snap model.Snapshot, opts model.ImportOptions) (model.ImportSummary, error)
+
+	RenderConfiguration(ctx context.Context, name string, version string, selector map[string]string, includePrerelease bool) (model.RenderedConfiguration, error)
+
 	FilterConfigsByLabels(ctx context.Context, name, version string, want map[string]string) ([]model.Configuration, error)
 	DeleteConfigsByLabels(ctx context.Context, name, version string, want map[string]string) (int, error)
 }
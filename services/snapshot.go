@@ -0,0 +1,312 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/repository"
+	"alati_projekat/validation"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExportSnapshot returns every configuration and group, sorted by name and version, and
+// optionally the processed idempotency keys. Configurations and groups are listed one after
+// the other, so writes made during an export may be missing from it.
+//
+// The snapshot is held in memory as a whole: the repository lists records as slices and
+// the snapshot header announces the record counts before the first record, so an export
+// needs memory proportional to the size of the store.
+func (s *ConfigurationService) ExportSnapshot(ctx context.Context, opts model.ExportOptions) (model.Snapshot, error) {
+	configs, err := s.Repo.ListConfigurations(ctx, "")
+	if err != nil {
+		return model.Snapshot{}, err
+	}
+	groups, err := s.Repo.ListConfigurationGroups(ctx, "")
+	if err != nil {
+		return model.Snapshot{}, err
+	}
+
+	sort.Slice(configs, func(i, j int) bool {
+		return refKey(configs[i].Name, configs[i].Version) < refKey(configs[j].Name, configs[j].Version)
+	})
+	sort.Slice(groups, func(i, j int) bool {
+		return refKey(groups[i].Name, groups[i].Version) < refKey(groups[j].Name, groups[j].Version)
+	})
+
+	if !opts.Revisions {
+		for i := range configs {
+			configs[i].Metadata.Revision = 0
+		}
+		for i := range groups {
+			groups[i].Metadata.Revision = 0
+			for j := range groups[i].Configurations {
+				groups[i].Configurations[j].Metadata.Revision = 0
+			}
+		}
+	}
+
+	snap := model.Snapshot{
+		Header: model.SnapshotHeader{
+			ExportedAt:      now(),
+			Revisions:       opts.Revisions,
+			IdempotencyKeys: opts.IdempotencyKeys,
+		},
+		Configurations: configs,
+		Groups:         groups,
+	}
+	if opts.IdempotencyKeys {
+		if snap.IdempotencyKeys, err = s.Repo.ListIdempotencyKeys(ctx); err != nil {
+			return model.Snapshot{}, err
+		}
+		sort.Strings(snap.IdempotencyKeys)
+	}
+	return snap, nil
+}
+
+// ImportSnapshot writes the content of snap according to opts.Mode. Every entity is validated
+// first, with the version and parent rules of create, and nothing is written when one is
+// invalid or when one conflicts with a stored entity: with ImportFailOnConflict any existing
+// entity conflicts, with ImportOverwrite one that is not a draft, unless the snapshot has its
+// stored revision. Conflicts return the summary listing them with ErrImportConflict.
+//
+// Imported entities keep their audit metadata. Their revision is the exported one, or 1 when
+// revisions were not exported, and an overwrite always moves it past the stored revision so
+// clients holding an ETag of the replaced entity notice the change. Writes are committed in
+// transactions of at most repository.MaxTxnOps operations that fail on concurrent modification;
+// if one fails, the transactions committed before it are kept.
+func (s *ConfigurationService) ImportSnapshot(ctx context.Context, snap model.Snapshot, opts model.ImportOptions) (model.ImportSummary, error) {
+	if opts.Mode == "" {
+		opts.Mode = model.ImportFailOnConflict
+	}
+	if err := s.validateSnapshot(snap, opts.Mode); err != nil {
+		return model.ImportSummary{}, err
+	}
+
+	summary := model.ImportSummary{
+		Mode:   opts.Mode,
+		DryRun: opts.DryRun,
+		Items:  make([]model.ImportItem, 0, len(snap.Configurations)+len(snap.Groups)),
+	}
+	var ops []repository.TxnOp
+	// Parents are checked against the store as it will be after the import.
+	stage := newStagingRepository(s.Repo)
+	var written []int
+
+	for i, c := range snap.Configurations {
+		item := model.ImportItem{Kind: model.EventConfiguration, Name: c.Name, Version: c.Version}
+		existing, err := s.Repo.GetConfiguration(ctx, c.Name, c.Version)
+		found, err := existsErr(err)
+		if err != nil {
+			return model.ImportSummary{}, err
+		}
+		item.Action, item.Reason = importAction(found, opts.Mode, existing.Lifecycle, existing.Metadata.Revision, c.Metadata.Revision)
+		item.Revision = existing.Metadata.Revision
+		if item.Action == model.ImportCreated || item.Action == model.ImportOverwritten {
+			c.Metadata = importMetadata(ctx, c.Metadata, found, existing.Metadata.Revision)
+			item.Revision, item.Configuration = c.Metadata.Revision, &c
+			ops = append(ops, importOp(found, existing.Metadata.Revision, repository.TxnOp{Configuration: &c}))
+			if found {
+				err = stage.UpdateConfiguration(ctx, c)
+			} else {
+				err = stage.AddConfiguration(ctx, c)
+			}
+			if err != nil {
+				return model.ImportSummary{}, err
+			}
+			written = append(written, i)
+		}
+		summary.Configurations.Add(item.Action)
+		summary.Items = append(summary.Items, item)
+	}
+	staged := &ConfigurationService{Repo: stage}
+	if err := staged.validateImportedParents(ctx, snap.Configurations, written); err != nil {
+		return model.ImportSummary{}, err
+	}
+
+	for _, g := range snap.Groups {
+		item := model.ImportItem{Kind: model.EventGroup, Name: g.Name, Version: g.Version}
+		existing, err := s.Repo.GetConfigurationGroup(ctx, g.Name, g.Version)
+		found, err := existsErr(err)
+		if err != nil {
+			return model.ImportSummary{}, err
+		}
+		item.Action, item.Reason = importAction(found, opts.Mode, existing.Lifecycle, existing.Metadata.Revision, g.Metadata.Revision)
+		item.Revision = existing.Metadata.Revision
+		if item.Action == model.ImportCreated || item.Action == model.ImportOverwritten {
+			g.Metadata = importMetadata(ctx, g.Metadata, found, existing.Metadata.Revision)
+			item.Revision, item.Group = g.Metadata.Revision, &g
+			ops = append(ops, importOp(found, existing.Metadata.Revision, repository.TxnOp{Group: &g}))
+		}
+		summary.Groups.Add(item.Action)
+		summary.Items = append(summary.Items, item)
+	}
+
+	// Idempotency keys are plain markers, so existing ones are skipped in every mode.
+	var newKeys []string
+	for _, key := range snap.IdempotencyKeys {
+		found, err := s.Repo.CheckIdempotencyKey(ctx, key)
+		if err != nil {
+			return model.ImportSummary{}, err
+		}
+		if found {
+			summary.IdempotencyKeys.Add(model.ImportSkipped)
+			continue
+		}
+		summary.IdempotencyKeys.Add(model.ImportCreated)
+		newKeys = append(newKeys, key)
+	}
+
+	if summary.Configurations.Conflicts+summary.Groups.Conflicts > 0 {
+		return summary, ErrImportConflict
+	}
+	if opts.DryRun {
+		return summary, nil
+	}
+
+	for start := 0; start < len(ops); start += repository.MaxTxnOps {
+		end := min(start+repository.MaxTxnOps, len(ops))
+		if err := s.Repo.Transact(ctx, ops[start:end]); err != nil {
+			return model.ImportSummary{}, fmt.Errorf("import stopped after %d of %d writes: %w", start, len(ops), err)
+		}
+	}
+	for _, key := range newKeys {
+		if err := s.Repo.SaveIdempotencyKey(ctx, key); err != nil {
+			return model.ImportSummary{}, err
+		}
+	}
+	return summary, nil
+}
+
+// existsErr turns the error of a repository read into whether the record exists;
+// "not found" is not an error.
+func existsErr(err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case strings.Contains(err.Error(), "not found"):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// importAction decides what happens to an imported entity, and why it is a conflict. Like
+// an update, an overwrite replaces only drafts; an entity in another state is overwritten
+// only when the snapshot was exported with its stored revision, which is the case when a
+// store is restored from its own export.
+func importAction(exists bool, mode model.ImportMode, stored model.Lifecycle, storedRevision, revision int64) (model.ImportAction, string) {
+	switch {
+	case !exists:
+		return model.ImportCreated, ""
+	case mode == model.ImportSkip:
+		return model.ImportSkipped, ""
+	case mode != model.ImportOverwrite:
+		return model.ImportConflict, "already exists"
+	case stored.CurrentState() != model.StateDraft && revision != storedRevision:
+		return model.ImportConflict, fmt.Sprintf("is %s and only drafts can be overwritten", stored.CurrentState())
+	default:
+		return model.ImportOverwritten, ""
+	}
+}
+
+// importMetadata returns the metadata an imported entity is stored with. Entities without
+// audit data, such as hand-written snapshots, are attributed to the importing caller.
+func importMetadata(ctx context.Context, m model.Metadata, exists bool, storedRevision int64) model.Metadata {
+	if m.CreatedAt.IsZero() {
+		created := createdMetadata(ctx)
+		m.CreatedAt, m.CreatedBy, m.UpdatedAt, m.UpdatedBy = created.CreatedAt, created.CreatedBy, created.UpdatedAt, created.UpdatedBy
+	}
+	if m.Revision < 1 {
+		m.Revision = 1
+	}
+	if exists && m.Revision <= storedRevision {
+		m.Revision = storedRevision + 1
+	}
+	return m
+}
+
+// importOp completes op as a create, or as an update of the record read with storedRevision.
+func importOp(exists bool, storedRevision int64, op repository.TxnOp) repository.TxnOp {
+	op.Verb = repository.TxnCreate
+	if exists {
+		op.Verb, op.ExpectedRevision = repository.TxnUpdate, storedRevision
+	}
+	return op
+}
+
+// validateImportedParents applies the inheritance rules of addConfiguration to the
+// configurations at the given indexes, reporting broken chains and cycles as violations.
+func (s *ConfigurationService) validateImportedParents(ctx context.Context, configs []model.Configuration, indexes []int) error {
+	var v validation.Validator
+	for _, i := range indexes {
+		if configs[i].Parent == nil {
+			continue
+		}
+		_, err := s.resolveChain(ctx, configs[i])
+		switch {
+		case errors.Is(err, ErrInvalidParent), errors.Is(err, ErrInheritanceCycle):
+			v.Add(fmt.Sprintf("configurations[%d].parent", i), "%v", err)
+		case err != nil:
+			return err
+		}
+	}
+	return v.Err()
+}
+
+// validateSnapshot checks the import mode and every entity of snap, and rejects entities
+// that appear twice. Versions follow the same rules as on create.
+func (s *ConfigurationService) validateSnapshot(snap model.Snapshot, mode model.ImportMode) error {
+	var v validation.Validator
+	switch mode {
+	case model.ImportSkip, model.ImportOverwrite, model.ImportFailOnConflict:
+	default:
+		v.Add("mode", "must be %q, %q or %q", model.ImportSkip, model.ImportOverwrite, model.ImportFailOnConflict)
+	}
+
+	seen := make(map[string]int, len(snap.Configurations))
+	for i, c := range snap.Configurations {
+		field := fmt.Sprintf("configurations[%d]", i)
+		v.Configuration(field+".", c)
+		validateLifecycle(&v, field+".lifecycle", c.Lifecycle)
+		s.validateVersion(&v, field+".version", c.Version)
+		key := refKey(c.Name, c.Version)
+		if first, ok := seen[key]; ok {
+			v.Add(field, "duplicate configuration %s, first defined at configurations[%d]", key, first)
+			continue
+		}
+		seen[key] = i
+	}
+
+	seen = make(map[string]int, len(snap.Groups))
+	for i, g := range snap.Groups {
+		field := fmt.Sprintf("groups[%d]", i)
+		v.Group(field+".", g)
+		validateLifecycle(&v, field+".lifecycle", g.Lifecycle)
+		s.validateVersion(&v, field+".version", g.Version)
+		for j, member := range g.Configurations {
+			s.validateVersion(&v, fmt.Sprintf("%s.configurations[%d].version", field, j), member.Version)
+		}
+		key := refKey(g.Name, g.Version)
+		if first, ok := seen[key]; ok {
+			v.Add(field, "duplicate group %s, first defined at groups[%d]", key, first)
+			continue
+		}
+		seen[key] = i
+	}
+
+	for i, key := range snap.IdempotencyKeys {
+		if key == "" {
+			v.Add(fmt.Sprintf("idempotencyKeys[%d]", i), "must not be empty")
+		}
+	}
+	return v.Err()
+}
+
+// validateVersion reports a version rejected by checkVersion as a violation of field.
+func (s *ConfigurationService) validateVersion(v *validation.Validator, field, version string) {
+	if err := s.checkVersion(version); err != nil {
+		v.Add(field, "%v", err)
+	}
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/validation"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestConfigurationService_ExportSnapshot(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	for _, c := range []model.Configuration{{Name: "web", Version: "v1"}, {Name: "api", Version: "v2"}, {Name: "api", Version: "v1"}} {
+		if _, err := service.AddConfiguration(ctx, c, ""); err != nil {
+			t.Fatalf("Setup failed: %v", err)
+		}
+	}
+	if _, err := service.AddConfigurationGroup(ctx, model.ConfigurationGroup{Name: "cluster", Version: "v1"}, "key-1"); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	snap, err := service.ExportSnapshot(ctx, model.ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportSnapshot failed: %v", err)
+	}
+	var order []string
+	for _, c := range snap.Configurations {
+		order = append(order, c.Name+"/"+c.Version)
+		if c.Metadata.Revision != 0 {
+			t.Errorf("Revision of %s/%s should be cleared, got %d", c.Name, c.Version, c.Metadata.Revision)
+		}
+	}
+	if fmt.Sprint(order) != "[api/v1 api/v2 web/v1]" {
+		t.Errorf("Expected configurations sorted by name and version, got %v", order)
+	}
+	if len(snap.Groups) != 1 || snap.IdempotencyKeys != nil {
+		t.Errorf("Expected one group and no idempotency keys, got %+v", snap)
+	}
+
+	snap, err = service.ExportSnapshot(ctx, model.ExportOptions{Revisions: true, IdempotencyKeys: true})
+	if err != nil {
+		t.Fatalf("ExportSnapshot failed: %v", err)
+	}
+	if snap.Configurations[0].Metadata.Revision != 1 || fmt.Sprint(snap.IdempotencyKeys) != "[key-1]" {
+		t.Errorf("Expected revisions and idempotency keys, got %+v", snap)
+	}
+}
+
+func TestConfigurationService_ImportSnapshot_Modes(t *testing.T) {
+	snap := model.Snapshot{
+		Configurations: []model.Configuration{
+			{Name: "api", Version: "v1", Params: []model.Parameter{{Key: "db.host", Value: "imported"}}},
+			{Name: "web", Version: "v1"},
+		},
+		Groups:          []model.ConfigurationGroup{{Name: "cluster", Version: "v1"}},
+		IdempotencyKeys: []string{"old-key", "new-key"},
+	}
+
+	tests := []struct {
+		mode      model.ImportMode
+		dryRun    bool
+		wantErr   error
+		wantHost  string
+		wantRev   int64
+		wantItems string
+	}{
+		{model.ImportSkip, false, nil, "stored", 3, "[skipped created created]"},
+		{model.ImportOverwrite, false, nil, "imported", 4, "[overwritten created created]"},
+		{model.ImportOverwrite, true, nil, "stored", 3, "[overwritten created created]"},
+		{model.ImportFailOnConflict, false, ErrImportConflict, "stored", 3, "[conflict created created]"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s dryRun=%v", tt.mode, tt.dryRun), func(t *testing.T) {
+			mockRepo := NewMockRepository()
+			service := NewConfigurationService(mockRepo)
+			ctx := context.Background()
+			mockRepo.configs[mockRepo.makeConfigKey("api", "v1")] = model.Configuration{
+				Name: "api", Version: "v1",
+				Params:   []model.Parameter{{Key: "db.host", Value: "stored"}},
+				Metadata: model.Metadata{Revision: 3},
+			}
+			mockRepo.idempotencyKeys["old-key"] = true
+
+			summary, err := service.ImportSnapshot(ctx, snap, model.ImportOptions{Mode: tt.mode, DryRun: tt.dryRun})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+
+			var actions []model.ImportAction
+			for _, item := range summary.Items {
+				actions = append(actions, item.Action)
+			}
+			if fmt.Sprint(actions) != tt.wantItems {
+				t.Errorf("Expected actions %s, got %v", tt.wantItems, actions)
+			}
+
+			api, _ := mockRepo.GetConfiguration(ctx, "api", "v1")
+			if api.Params[0].Value != tt.wantHost || api.Metadata.Revision != tt.wantRev {
+				t.Errorf("Expected api/v1 with %s at revision %d, got %+v", tt.wantHost, tt.wantRev, api)
+			}
+			_, err = mockRepo.GetConfiguration(ctx, "web", "v1")
+			if written := err == nil; written != (tt.wantErr == nil && !tt.dryRun) {
+				t.Errorf("web/v1 written = %v, want %v", written, !written)
+			}
+			if summary.IdempotencyKeys.Created != 1 || summary.IdempotencyKeys.Skipped != 1 {
+				t.Errorf("Expected one new and one skipped key, got %+v", summary.IdempotencyKeys)
+			}
+			if mockRepo.idempotencyKeys["new-key"] != (tt.wantErr == nil && !tt.dryRun) {
+				t.Errorf("new-key saved = %v", mockRepo.idempotencyKeys["new-key"])
+			}
+		})
+	}
+}
+
+// Overwrite ne menja objavljene verzije, osim pri vracanju sopstvenog izvoza
+func TestConfigurationService_ImportSnapshot_OverwriteImmutable(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    model.LifecycleState
+		revision int64
+		want     model.ImportAction
+	}{
+		{"draft", model.StateDraft, 0, model.ImportOverwritten},
+		{"published", model.StatePublished, 0, model.ImportConflict},
+		{"archived with another revision", model.StateArchived, 2, model.ImportConflict},
+		{"published with the stored revision", model.StatePublished, 3, model.ImportOverwritten},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockRepository()
+			service := NewConfigurationService(mockRepo)
+			ctx := context.Background()
+			stored := model.Configuration{
+				Name: "api", Version: "v1",
+				Params:    []model.Parameter{{Key: "db.host", Value: "stored"}},
+				Lifecycle: model.Lifecycle{State: tt.state},
+				Metadata:  model.Metadata{Revision: 3},
+			}
+			mockRepo.configs[mockRepo.makeConfigKey("api", "v1")] = stored
+			mockRepo.groups[mockRepo.makeGroupKey("cluster", "v1")] = model.ConfigurationGroup{
+				Name: "cluster", Version: "v1", Lifecycle: model.Lifecycle{State: tt.state}, Metadata: model.Metadata{Revision: 3},
+			}
+
+			imported := stored
+			imported.Params = []model.Parameter{{Key: "db.host", Value: "imported"}}
+			imported.Metadata.Revision = tt.revision
+			snap := model.Snapshot{
+				Configurations: []model.Configuration{imported},
+				Groups:         []model.ConfigurationGroup{{Name: "cluster", Version: "v1", Lifecycle: model.Lifecycle{State: tt.state}, Metadata: model.Metadata{Revision: tt.revision}}},
+			}
+			summary, err := service.ImportSnapshot(ctx, snap, model.ImportOptions{Mode: model.ImportOverwrite})
+
+			if len(summary.Items) != 2 || summary.Items[0].Action != tt.want || summary.Items[1].Action != tt.want {
+				t.Fatalf("Expected %s for both entities, got %+v", tt.want, summary.Items)
+			}
+			api, _ := mockRepo.GetConfiguration(ctx, "api", "v1")
+			if tt.want == model.ImportConflict {
+				if !errors.Is(err, ErrImportConflict) || summary.Items[0].Reason == "" {
+					t.Errorf("Expected ErrImportConflict with a reason, got %v %+v", err, summary.Items[0])
+				}
+				if api.Params[0].Value != "stored" {
+					t.Errorf("A %s configuration must not be overwritten, got %+v", tt.state, api.Params)
+				}
+				return
+			}
+			if err != nil || api.Params[0].Value != "imported" {
+				t.Errorf("Expected the configuration to be overwritten, got %+v (%v)", api.Params, err)
+			}
+		})
+	}
+}
+
+func TestConfigurationService_ImportSnapshot_Validation(t *testing.T) {
+	service := NewConfigurationService(NewMockRepository())
+	snap := model.Snapshot{
+		Configurations: []model.Configuration{{Name: "api", Version: "v1"}, {Name: "api", Version: "v1"}},
+		Groups:         []model.ConfigurationGroup{{Name: "bad name", Version: "v1"}},
+	}
+
+	_, err := service.ImportSnapshot(context.Background(), snap, model.ImportOptions{Mode: "merge"})
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+	got := make(map[string]bool)
+	for _, v := range verr.Violations {
+		got[v.Field] = true
+	}
+	for _, field := range []string{"mode", "configurations[1]", "groups[0].name"} {
+		if !got[field] {
+			t.Errorf("Expected a violation of %s, got %v", field, got)
+		}
+	}
+}
+
+func TestConfigurationService_ImportSnapshot_Lifecycle(t *testing.T) {
+	deprecatedAt := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	sunset := deprecatedAt.Add(24 * time.Hour)
+	early := deprecatedAt.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		lifecycle model.Lifecycle
+		field     string
+	}{
+		{"unset", model.Lifecycle{}, ""},
+		{"archived after deprecation", model.Lifecycle{State: model.StateArchived, DeprecatedAt: &deprecatedAt, SunsetAt: &sunset}, ""},
+		{"unknown state", model.Lifecycle{State: "bogus"}, "lifecycle.state"},
+		{"deprecated without time", model.Lifecycle{State: model.StateDeprecated}, "lifecycle.deprecatedAt"},
+		{"published with deprecation", model.Lifecycle{State: model.StatePublished, DeprecatedAt: &deprecatedAt}, "lifecycle.deprecatedAt"},
+		{"draft with sunset", model.Lifecycle{State: model.StateDraft, SunsetAt: &sunset}, "lifecycle.sunsetAt"},
+		{"sunset before deprecation", model.Lifecycle{State: model.StateDeprecated, DeprecatedAt: &deprecatedAt, SunsetAt: &early}, "lifecycle.sunsetAt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewConfigurationService(NewMockRepository())
+			snap := model.Snapshot{
+				Configurations: []model.Configuration{{Name: "api", Version: "v1", Lifecycle: tt.lifecycle}},
+				Groups:         []model.ConfigurationGroup{{Name: "cluster", Version: "v1", Lifecycle: tt.lifecycle}},
+			}
+			_, err := service.ImportSnapshot(context.Background(), snap, model.ImportOptions{})
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("ImportSnapshot failed: %v", err)
+				}
+				return
+			}
+			got := map[string]bool{}
+			var verr *validation.Error
+			if errors.As(err, &verr) {
+				for _, v := range verr.Violations {
+					got[v.Field] = true
+				}
+			}
+			if !got["configurations[0]."+tt.field] || !got["groups[0]."+tt.field] {
+				t.Errorf("Expected violations of %s, got %v (%v)", tt.field, got, err)
+			}
+		})
+	}
+}
+
+// Uvoz primenjuje ista pravila za verzije i roditelje kao kreiranje
+func TestConfigurationService_ImportSnapshot_CreateRules(t *testing.T) {
+	ctx := context.Background()
+	violationsOf := func(err error) map[string]bool {
+		got := map[string]bool{}
+		var verr *validation.Error
+		if errors.As(err, &verr) {
+			for _, v := range verr.Violations {
+				got[v.Field] = true
+			}
+		}
+		return got
+	}
+
+	service := NewConfigurationService(NewMockRepository())
+	service.StrictVersions = true
+	snap := model.Snapshot{
+		Configurations: []model.Configuration{{Name: "api", Version: "not-semver"}},
+		Groups: []model.ConfigurationGroup{{
+			Name:           "cluster",
+			Version:        "v1",
+			Configurations: []model.Configuration{{Name: "api", Version: "1.0.0"}, {Name: "web", Version: "latest-build"}},
+		}},
+	}
+	_, err := service.ImportSnapshot(ctx, snap, model.ImportOptions{})
+	got := violationsOf(err)
+	for _, field := range []string{"configurations[0].version", "groups[0].version", "groups[0].configurations[1].version"} {
+		if !got[field] {
+			t.Errorf("Expected a violation of %s in strict mode, got %v (%v)", field, got, err)
+		}
+	}
+	if got["groups[0].configurations[0].version"] {
+		t.Errorf("Semantic member version must be accepted, got %v", got)
+	}
+
+	mockRepo := NewMockRepository()
+	service = NewConfigurationService(mockRepo)
+	parent := func(name string) *model.ConfigurationRef { return &model.ConfigurationRef{Name: name, Version: "v1"} }
+	snap = model.Snapshot{Configurations: []model.Configuration{
+		{Name: "child", Version: "v1", Parent: parent("base")},
+		{Name: "base", Version: "v1"},
+		{Name: "a", Version: "v1", Parent: parent("b")},
+		{Name: "b", Version: "v1", Parent: parent("a")},
+		{Name: "orphan", Version: "v1", Parent: parent("missing")},
+	}}
+	_, err = service.ImportSnapshot(ctx, snap, model.ImportOptions{})
+	got = violationsOf(err)
+	for _, field := range []string{"configurations[2].parent", "configurations[3].parent", "configurations[4].parent"} {
+		if !got[field] {
+			t.Errorf("Expected a violation of %s, got %v (%v)", field, got, err)
+		}
+	}
+	if got["configurations[0].parent"] {
+		t.Errorf("A parent imported in the same snapshot must be accepted, got %v", got)
+	}
+	if len(mockRepo.configs) != 0 {
+		t.Errorf("Nothing may be stored when a chain is invalid, got %v", mockRepo.configs)
+	}
+}
+
+func TestConfigurationService_ImportSnapshot_LargeSnapshot(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+
+	// Vise zapisa nego sto staje u jednu transakciju
+	var snap model.Snapshot
+	for i := range 150 {
+		snap.Configurations = append(snap.Configurations, model.Configuration{Name: fmt.Sprintf("svc-%d", i), Version: "v1"})
+	}
+	summary, err := service.ImportSnapshot(context.Background(), snap, model.ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportSnapshot failed: %v", err)
+	}
+	if summary.Mode != model.ImportFailOnConflict || summary.Configurations.Created != 150 || len(mockRepo.configs) != 150 {
+		t.Errorf("Expected 150 created configurations, got %+v with %d stored", summary.Configurations, len(mockRepo.configs))
+	}
+	if c := mockRepo.configs[mockRepo.makeConfigKey("svc-0", "v1")]; c.Metadata.Revision != 1 || c.Metadata.CreatedAt.IsZero() {
+		t.Errorf("Expected audit metadata for an entity without it, got %+v", c.Metadata)
+	}
+}
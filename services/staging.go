@@ -0,0 +1,289 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+// stagedRecord is the state of one record as seen by a staged batch.
+type stagedRecord[T any] struct {
+	value    *T    // nil when the record does not exist (anymore)
+	base     *T    // the record as first read from the underlying repository, nil if it did not exist
+	revision int64 // revision of base
+	dirty    bool
+}
+
+// stagedSet holds the staged records of one kind, in the order they were first touched.
+type stagedSet[T any] struct {
+	records  map[string]*stagedRecord[T]
+	order    []string
+	revision func(T) int64
+}
+
+func newStagedSet[T any](revision func(T) int64) *stagedSet[T] {
+	return &stagedSet[T]{records: make(map[string]*stagedRecord[T]), revision: revision}
+}
+
+// load returns the staged record for key, reading it from the underlying repository on first use.
+func (s *stagedSet[T]) load(key string, get func() (T, error)) (*stagedRecord[T], error) {
+	if rec, ok := s.records[key]; ok {
+		return rec, nil
+	}
+	rec := &stagedRecord[T]{}
+	v, err := get()
+	switch {
+	case err == nil:
+		stored := v
+		rec.value, rec.base, rec.revision = &v, &stored, s.revision(v)
+	case !strings.Contains(err.Error(), "not found"):
+		return nil, err
+	}
+	s.records[key] = rec
+	s.order = append(s.order, key)
+	return rec, nil
+}
+
+// list merges the staged records into the records listed by the underlying repository.
+func (s *stagedSet[T]) list(base []T, keyOf func(T) string, match func(T) bool) []T {
+	out := make([]T, 0, len(base))
+	for _, v := range base {
+		if rec, ok := s.records[keyOf(v)]; ok {
+			if rec.value != nil {
+				out = append(out, *rec.value)
+			}
+			continue
+		}
+		out = append(out, v)
+	}
+	for _, key := range s.order {
+		if rec := s.records[key]; rec.base == nil && rec.value != nil && match(*rec.value) {
+			out = append(out, *rec.value)
+		}
+	}
+	return out
+}
+
+// stagingRepository records the writes of an atomic batch instead of applying them.
+// Reads see the staged writes, so later operations of a batch can build on earlier ones;
+// ops returns the writes to commit with a single repository transaction.
+type stagingRepository struct {
+	repository.Repository
+	configs *stagedSet[model.Configuration]
+	groups  *stagedSet[model.ConfigurationGroup]
+}
+
+var errNestedTxn = errors.New("transactions cannot be nested")
+
+func newStagingRepository(base repository.Repository) *stagingRepository {
+	return &stagingRepository{
+		Repository: base,
+		configs:    newStagedSet(func(c model.Configuration) int64 { return c.Metadata.Revision }),
+		groups:     newStagedSet(func(g model.ConfigurationGroup) int64 { return g.Metadata.Revision }),
+	}
+}
+
+func (r *stagingRepository) config(ctx context.Context, name, version string) (*stagedRecord[model.Configuration], error) {
+	return r.configs.load(refKey(name, version), func() (model.Configuration, error) {
+		return r.Repository.GetConfiguration(ctx, name, version)
+	})
+}
+
+func (r *stagingRepository) group(ctx context.Context, name, version string) (*stagedRecord[model.ConfigurationGroup], error) {
+	return r.groups.load(refKey(name, version), func() (model.ConfigurationGroup, error) {
+		return r.Repository.GetConfigurationGroup(ctx, name, version)
+	})
+}
+
+func (r *stagingRepository) AddConfiguration(ctx context.Context, config model.Configuration) error {
+	rec, err := r.config(ctx, config.Name, config.Version)
+	if err != nil {
+		return err
+	}
+	if rec.value != nil {
+		return errors.New("configuration already exists")
+	}
+	rec.value, rec.dirty = &config, true
+	return nil
+}
+
+func (r *stagingRepository) GetConfiguration(ctx context.Context, name, version string) (model.Configuration, error) {
+	rec, err := r.config(ctx, name, version)
+	if err != nil {
+		return model.Configuration{}, err
+	}
+	if rec.value == nil {
+		return model.Configuration{}, errors.New("configuration not found")
+	}
+	return *rec.value, nil
+}
+
+func (r *stagingRepository) UpdateConfiguration(ctx context.Context, config model.Configuration) error {
+	rec, err := r.config(ctx, config.Name, config.Version)
+	if err != nil {
+		return err
+	}
+	if rec.value == nil {
+		return errors.New("configuration not found")
+	}
+	rec.value, rec.dirty = &config, true
+	return nil
+}
+
+func (r *stagingRepository) CompareAndSwapConfiguration(ctx context.Context, config model.Configuration, expectedRevision int64) error {
+	current, err := r.GetConfiguration(ctx, config.Name, config.Version)
+	if err != nil {
+		return err
+	}
+	if current.Metadata.Revision != expectedRevision {
+		return repository.ErrRevisionConflict
+	}
+	return r.UpdateConfiguration(ctx, config)
+}
+
+func (r *stagingRepository) DeleteConfiguration(ctx context.Context, name, version string) error {
+	rec, err := r.config(ctx, name, version)
+	if err != nil {
+		return err
+	}
+	if rec.value == nil {
+		return errors.New("configuration not found")
+	}
+	rec.value, rec.dirty = nil, true
+	return nil
+}
+
+func (r *stagingRepository) ListConfigurations(ctx context.Context, name string) ([]model.Configuration, error) {
+	base, err := r.Repository.ListConfigurations(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return r.configs.list(base,
+		func(c model.Configuration) string { return refKey(c.Name, c.Version) },
+		func(c model.Configuration) bool { return name == "" || c.Name == name },
+	), nil
+}
+
+func (r *stagingRepository) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup) error {
+	rec, err := r.group(ctx, group.Name, group.Version)
+	if err != nil {
+		return err
+	}
+	if rec.value != nil {
+		return errors.New("configuration group already exists")
+	}
+	rec.value, rec.dirty = &group, true
+	return nil
+}
+
+func (r *stagingRepository) GetConfigurationGroup(ctx context.Context, name, version string) (model.ConfigurationGroup, error) {
+	rec, err := r.group(ctx, name, version)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+	if rec.value == nil {
+		return model.ConfigurationGroup{}, errors.New("configuration group not found")
+	}
+	return *rec.value, nil
+}
+
+func (r *stagingRepository) UpdateConfigurationGroup(ctx context.Context, group model.ConfigurationGroup) error {
+	rec, err := r.group(ctx, group.Name, group.Version)
+	if err != nil {
+		return err
+	}
+	if rec.value == nil {
+		return errors.New("configuration group not found")
+	}
+	rec.value, rec.dirty = &group, true
+	return nil
+}
+
+func (r *stagingRepository) CompareAndSwapConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, expectedRevision int64) error {
+	current, err := r.GetConfigurationGroup(ctx, group.Name, group.Version)
+	if err != nil {
+		return err
+	}
+	if current.Metadata.Revision != expectedRevision {
+		return repository.ErrRevisionConflict
+	}
+	return r.UpdateConfigurationGroup(ctx, group)
+}
+
+func (r *stagingRepository) DeleteConfigurationGroup(ctx context.Context, name, version string) error {
+	rec, err := r.group(ctx, name, version)
+	if err != nil {
+		return err
+	}
+	if rec.value == nil {
+		return errors.New("configuration group not found")
+	}
+	rec.value, rec.dirty = nil, true
+	return nil
+}
+
+func (r *stagingRepository) ListConfigurationGroups(ctx context.Context, name string) ([]model.ConfigurationGroup, error) {
+	base, err := r.Repository.ListConfigurationGroups(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return r.groups.list(base,
+		func(g model.ConfigurationGroup) string { return refKey(g.Name, g.Version) },
+		func(g model.ConfigurationGroup) bool { return name == "" || g.Name == name },
+	), nil
+}
+
+// SaveIdempotencyKey is a no-op; the batch saves its own key once it is committed.
+func (r *stagingRepository) SaveIdempotencyKey(ctx context.Context, key string) error {
+	return nil
+}
+
+func (r *stagingRepository) Transact(ctx context.Context, ops []repository.TxnOp) error {
+	return errNestedTxn
+}
+
+// ops returns one transaction operation per record changed by the batch.
+func (r *stagingRepository) ops() []repository.TxnOp {
+	var ops []repository.TxnOp
+	for _, key := range r.configs.order {
+		rec := r.configs.records[key]
+		if op, ok := txnOpFor(rec, func(c *model.Configuration) repository.TxnOp {
+			return repository.TxnOp{Configuration: c}
+		}); ok {
+			ops = append(ops, op)
+		}
+	}
+	for _, key := range r.groups.order {
+		rec := r.groups.records[key]
+		if op, ok := txnOpFor(rec, func(g *model.ConfigurationGroup) repository.TxnOp {
+			return repository.TxnOp{Group: g}
+		}); ok {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// txnOpFor turns the final state of a staged record into a create, update or delete.
+// Records that were only read, or created and deleted again, need no operation.
+func txnOpFor[T any](rec *stagedRecord[T], wrap func(*T) repository.TxnOp) (repository.TxnOp, bool) {
+	var op repository.TxnOp
+	switch {
+	case !rec.dirty:
+		return op, false
+	case rec.value != nil && rec.base == nil:
+		op = wrap(rec.value)
+		op.Verb = repository.TxnCreate
+	case rec.value != nil:
+		op = wrap(rec.value)
+		op.Verb, op.ExpectedRevision = repository.TxnUpdate, rec.revision
+	case rec.base != nil:
+		op = wrap(rec.base)
+		op.Verb, op.ExpectedRevision = repository.TxnDelete, rec.revision
+	default:
+		return op, false
+	}
+	return op, true
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"alati_projekat/model"
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -31,7 +32,7 @@ func endSpan(span trace.Span, err error) {
 
 // --- CONFIGURATIONS ---
 
-func (s *TracingService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (err error) {
+func (s *TracingService) AddConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (out model.Configuration, err error) {
 	ctx, span := tracer.Start(ctx, "AddConfigurationService")
 	defer endSpan(span, err)
 	span.SetAttributes(attribute.String("config.name", config.Name), attribute.String("config.version", config.Version))
@@ -45,6 +46,13 @@ func (s *TracingService) GetConfiguration(ctx context.Context, name string, vers
 	return s.Next.GetConfiguration(ctx, name, version)
 }
 
+func (s *TracingService) WatchConfiguration(ctx context.Context, name string, version string, index uint64, wait time.Duration) (out model.Configuration, next uint64, err error) {
+	ctx, span := tracer.Start(ctx, "WatchConfigurationService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("config.name", name), attribute.String("config.version", version), attribute.Int64("watch.index", int64(index)), attribute.String("watch.wait", wait.String()))
+	return s.Next.WatchConfiguration(ctx, name, version, index, wait)
+}
+
 func (s *TracingService) UpdateConfiguration(ctx context.Context, config model.Configuration, idempotencyKey string) (out model.Configuration, err error) {
 	ctx, span := tracer.Start(ctx, "UpdateConfigurationService")
 	defer endSpan(span, err)
@@ -53,6 +61,14 @@ func (s *TracingService) UpdateConfiguration(ctx context.Context, config model.C
 	return out, err
 }
 
+func (s *TracingService) PatchConfiguration(ctx context.Context, name string, version string, patchType string, patch []byte, ifMatch string) (out model.Configuration, err error) {
+	ctx, span := tracer.Start(ctx, "PatchConfigurationService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("config.name", name), attribute.String("config.version", version), attribute.String("patch.type", patchType))
+	out, err = s.Next.PatchConfiguration(ctx, name, version, patchType, patch, ifMatch)
+	return out, err
+}
+
 func (s *TracingService) DeleteConfiguration(ctx context.Context, name string, version string) (err error) {
 	ctx, span := tracer.Start(ctx, "DeleteConfigurationService")
 	defer endSpan(span, err)
@@ -60,9 +76,44 @@ func (s *TracingService) DeleteConfiguration(ctx context.Context, name string, v
 	return s.Next.DeleteConfiguration(ctx, name, version)
 }
 
+func (s *TracingService) GetEffectiveConfiguration(ctx context.Context, name string, version string) (out model.EffectiveConfiguration, err error) {
+	ctx, span := tracer.Start(ctx, "GetEffectiveConfigurationService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("config.name", name), attribute.String("config.version", version))
+	return s.Next.GetEffectiveConfiguration(ctx, name, version)
+}
+
+func (s *TracingService) InterpolateConfiguration(ctx context.Context, name string, version string, strict bool) (out model.EffectiveConfiguration, err error) {
+	ctx, span := tracer.Start(ctx, "InterpolateConfigurationService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("config.name", name), attribute.String("config.version", version), attribute.Bool("interpolation.strict", strict))
+	return s.Next.InterpolateConfiguration(ctx, name, version, strict)
+}
+
+func (s *TracingService) ResolveConfigurationVersion(ctx context.Context, name string, selector string, includePrerelease bool) (out model.Configuration, err error) {
+	ctx, span := tracer.Start(ctx, "ResolveConfigurationVersionService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("config.name", name), attribute.String("config.version_selector", selector))
+	return s.Next.ResolveConfigurationVersion(ctx, name, selector, includePrerelease)
+}
+
+func (s *TracingService) TransitionConfiguration(ctx context.Context, name string, version string, target model.LifecycleState, sunset *time.Time) (out model.Configuration, err error) {
+	ctx, span := tracer.Start(ctx, "TransitionConfigurationService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("config.name", name), attribute.String("config.version", version), attribute.String("lifecycle.target", string(target)))
+	return s.Next.TransitionConfiguration(ctx, name, version, target, sunset)
+}
+
+func (s *TracingService) CloneConfiguration(ctx context.Context, name string, version string, req model.CloneRequest, idempotencyKey string) (out model.Configuration, err error) {
+	ctx, span := tracer.Start(ctx, "CloneConfigurationService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("config.name", name), attribute.String("config.version", version), attribute.String("clone.name", req.Name), attribute.String("clone.version", req.Version))
+	return s.Next.CloneConfiguration(ctx, name, version, req, idempotencyKey)
+}
+
 // --- CONFIGURATION GROUPS ---
 
-func (s *TracingService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (err error) {
+func (s *TracingService) AddConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (out model.ConfigurationGroup, err error) {
 	ctx, span := tracer.Start(ctx, "AddConfigurationGroupService")
 	defer endSpan(span, err)
 	span.SetAttributes(attribute.String("group.name", group.Name), attribute.String("group.version", group.Version))
@@ -76,6 +127,13 @@ func (s *TracingService) GetConfigurationGroup(ctx context.Context, name string,
 	return s.Next.GetConfigurationGroup(ctx, name, version)
 }
 
+func (s *TracingService) WatchConfigurationGroup(ctx context.Context, name string, version string, index uint64, wait time.Duration) (out model.ConfigurationGroup, next uint64, err error) {
+	ctx, span := tracer.Start(ctx, "WatchConfigurationGroupService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("group.name", name), attribute.String("group.version", version), attribute.Int64("watch.index", int64(index)), attribute.String("watch.wait", wait.String()))
+	return s.Next.WatchConfigurationGroup(ctx, name, version, index, wait)
+}
+
 func (s *TracingService) UpdateConfigurationGroup(ctx context.Context, group model.ConfigurationGroup, idempotencyKey string) (out model.ConfigurationGroup, err error) {
 	ctx, span := tracer.Start(ctx, "UpdateConfigurationGroupService")
 	defer endSpan(span, err)
@@ -84,6 +142,14 @@ func (s *TracingService) UpdateConfigurationGroup(ctx context.Context, group mod
 	return out, err
 }
 
+func (s *TracingService) PatchConfigurationGroup(ctx context.Context, name string, version string, patchType string, patch []byte, ifMatch string) (out model.ConfigurationGroup, err error) {
+	ctx, span := tracer.Start(ctx, "PatchConfigurationGroupService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("group.name", name), attribute.String("group.version", version), attribute.String("patch.type", patchType))
+	out, err = s.Next.PatchConfigurationGroup(ctx, name, version, patchType, patch, ifMatch)
+	return out, err
+}
+
 func (s *TracingService) DeleteConfigurationGroup(ctx context.Context, name string, version string) (err error) {
 	ctx, span := tracer.Start(ctx, "DeleteConfigurationGroupService")
 	defer endSpan(span, err)
@@ -91,6 +157,51 @@ func (s *TracingService) DeleteConfigurationGroup(ctx context.Context, name stri
 	return s.Next.DeleteConfigurationGroup(ctx, name, version)
 }
 
+func (s *TracingService) AddGroupMember(ctx context.Context, name string, version string, member model.Configuration) (out model.ConfigurationGroup, err error) {
+	ctx, span := tracer.Start(ctx, "AddGroupMemberService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("group.name", name), attribute.String("group.version", version), attribute.String("config.name", member.Name), attribute.String("config.version", member.Version))
+	out, err = s.Next.AddGroupMember(ctx, name, version, member)
+	return out, err
+}
+
+func (s *TracingService) UpdateGroupMember(ctx context.Context, name string, version string, member model.Configuration) (out model.ConfigurationGroup, err error) {
+	ctx, span := tracer.Start(ctx, "UpdateGroupMemberService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("group.name", name), attribute.String("group.version", version), attribute.String("config.name", member.Name), attribute.String("config.version", member.Version))
+	out, err = s.Next.UpdateGroupMember(ctx, name, version, member)
+	return out, err
+}
+
+func (s *TracingService) RemoveGroupMember(ctx context.Context, name string, version string, memberName string, memberVersion string) (out model.ConfigurationGroup, err error) {
+	ctx, span := tracer.Start(ctx, "RemoveGroupMemberService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("group.name", name), attribute.String("group.version", version), attribute.String("config.name", memberName), attribute.String("config.version", memberVersion))
+	out, err = s.Next.RemoveGroupMember(ctx, name, version, memberName, memberVersion)
+	return out, err
+}
+
+func (s *TracingService) ResolveConfigurationGroupVersion(ctx context.Context, name string, selector string, includePrerelease bool) (out model.ConfigurationGroup, err error) {
+	ctx, span := tracer.Start(ctx, "ResolveConfigurationGroupVersionService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("group.name", name), attribute.String("group.version_selector", selector))
+	return s.Next.ResolveConfigurationGroupVersion(ctx, name, selector, includePrerelease)
+}
+
+func (s *TracingService) TransitionConfigurationGroup(ctx context.Context, name string, version string, target model.LifecycleState, sunset *time.Time) (out model.ConfigurationGroup, err error) {
+	ctx, span := tracer.Start(ctx, "TransitionConfigurationGroupService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("group.name", name), attribute.String("group.version", version), attribute.String("lifecycle.target", string(target)))
+	return s.Next.TransitionConfigurationGroup(ctx, name, version, target, sunset)
+}
+
+func (s *TracingService) CloneConfigurationGroup(ctx context.Context, name string, version string, req model.CloneRequest, idempotencyKey string) (out model.ConfigurationGroup, err error) {
+	ctx, span := tracer.Start(ctx, "CloneConfigurationGroupService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("group.name", name), attribute.String("group.version", version), attribute.String("clone.name", req.Name), attribute.String("clone.version", req.Version))
+	return s.Next.CloneConfigurationGroup(ctx, name, version, req, idempotencyKey)
+}
+
 // --- IDEMPOTENCY ---
 
 func (s *TracingService) CheckIdempotencyKey(ctx context.Context, key string) (bool, error) {
@@ -107,8 +218,47 @@ func (s *TracingService) SaveIdempotencyKey(ctx context.Context, key string) {
 	s.Next.SaveIdempotencyKey(ctx, key)
 }
 
+// --- BATCH ---
+
+func (s *TracingService) ExecuteBatch(ctx context.Context, req model.BatchRequest, idempotencyKey string) (out model.BatchResponse, err error) {
+	ctx, span := tracer.Start(ctx, "ExecuteBatchService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("batch.mode", string(req.Mode)), attribute.Int("batch.operations", len(req.Operations)))
+	out, err = s.Next.ExecuteBatch(ctx, req, idempotencyKey)
+	span.SetAttributes(attribute.Int("batch.failed", out.Failed))
+	return out, err
+}
+
+func (s *TracingService) ExportSnapshot(ctx context.Context, opts model.ExportOptions) (out model.Snapshot, err error) {
+	ctx, span := tracer.Start(ctx, "ExportSnapshotService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.Bool("export.revisions", opts.Revisions), attribute.Bool("export.idempotency_keys", opts.IdempotencyKeys))
+	out, err = s.Next.ExportSnapshot(ctx, opts)
+	span.SetAttributes(attribute.Int("export.configurations", len(out.Configurations)), attribute.Int("export.groups", len(out.Groups)))
+	return out, err
+}
+
+func (s *TracingService) ImportSnapshot(ctx context.Context, snap model.Snapshot, opts model.ImportOptions) (out model.ImportSummary, err error) {
+	ctx, span := tracer.Start(ctx, "ImportSnapshotService")
+	defer endSpan(span, err)
+	span.SetAttributes(
+		attribute.String("import.mode", string(opts.Mode)),
+		attribute.Bool("import.dry_run", opts.DryRun),
+		attribute.Int("import.configurations", len(snap.Configurations)),
+		attribute.Int("import.groups", len(snap.Groups)),
+	)
+	return s.Next.ImportSnapshot(ctx, snap, opts)
+}
+
 // --- LABELS ---
 
+func (s *TracingService) RenderConfiguration(ctx context.Context, name string, version string, selector map[string]string, includePrerelease bool) (out model.RenderedConfiguration, err error) {
+	ctx, span := tracer.Start(ctx, "RenderConfigurationService")
+	defer endSpan(span, err)
+	span.SetAttributes(attribute.String("group.name", name), attribute.String("group.version", version), attribute.Int("selector.size", len(selector)))
+	return s.Next.RenderConfiguration(ctx, name, version, selector, includePrerelease)
+}
+
 func (s *TracingService) FilterConfigsByLabels(ctx context.Context, name, version string, want map[string]string) (out []model.Configuration, err error) {
 	ctx, span := tracer.Start(ctx, "FilterConfigsByLabelsService")
 	defer endSpan(span, err)
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/semver"
+	"context"
+	"fmt"
+)
+
+// checkVersion rejects non-semver versions when the service runs with StrictVersions.
+func (s *ConfigurationService) checkVersion(version string) error {
+	if !s.StrictVersions {
+		return nil
+	}
+	if _, err := semver.Parse(version); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidVersion, err)
+	}
+	return nil
+}
+
+// ResolveConfigurationVersion returns the highest version of the named configuration matching selector
+// ("latest", "^1.2", "~1.2.3", ">=1.0.0 <2.0.0", "1.x", ...). Archived versions and versions that are not
+// valid semver are ignored.
+func (s *ConfigurationService) ResolveConfigurationVersion(ctx context.Context, name string, selector string, includePrerelease bool) (model.Configuration, error) {
+	constraint, err := semver.ParseConstraint(selector)
+	if err != nil {
+		return model.Configuration{}, fmt.Errorf("%w: %v", ErrInvalidVersionSelector, err)
+	}
+
+	configs, err := s.Repo.ListConfigurations(ctx, name)
+	if err != nil {
+		return model.Configuration{}, err
+	}
+
+	versions := make([]string, len(configs))
+	for i, config := range configs {
+		// Archived versions are never picked by a selector; an empty string is skipped as invalid semver.
+		if config.CurrentState() != model.StateArchived {
+			versions[i] = config.Version
+		}
+	}
+
+	best := semver.Select(versions, constraint, includePrerelease)
+	if best < 0 {
+		return model.Configuration{}, fmt.Errorf("configuration not found: no version of %s matches %q", name, selector)
+	}
+	return configs[best], nil
+}
+
+// ResolveConfigurationGroupVersion is the configuration group counterpart of ResolveConfigurationVersion.
+func (s *ConfigurationService) ResolveConfigurationGroupVersion(ctx context.Context, name string, selector string, includePrerelease bool) (model.ConfigurationGroup, error) {
+	constraint, err := semver.ParseConstraint(selector)
+	if err != nil {
+		return model.ConfigurationGroup{}, fmt.Errorf("%w: %v", ErrInvalidVersionSelector, err)
+	}
+
+	groups, err := s.Repo.ListConfigurationGroups(ctx, name)
+	if err != nil {
+		return model.ConfigurationGroup{}, err
+	}
+
+	versions := make([]string, len(groups))
+	for i, group := range groups {
+		if group.CurrentState() != model.StateArchived {
+			versions[i] = group.Version
+		}
+	}
+
+	best := semver.Select(versions, constraint, includePrerelease)
+	if best < 0 {
+		return model.ConfigurationGroup{}, fmt.Errorf("configuration group not found: no version of %s matches %q", name, selector)
+	}
+	return groups[best], nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"alati_projekat/model"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestConfigurationService_ResolveConfigurationVersion(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	ctx := context.Background()
+
+	for _, version := range []string{"v1.0.0", "v1.2.0", "v1.10.0", "v2.0.0-rc.1", "legacy"} {
+		if _, err := service.AddConfiguration(ctx, model.Configuration{Name: "service-api", Version: version}, ""); err != nil {
+			t.Fatalf("Setup failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		selector   string
+		prerelease bool
+		want       string
+	}{
+		{"latest", false, "v1.10.0"},
+		{"latest", true, "v2.0.0-rc.1"},
+		{"^1.2", false, "v1.10.0"},
+		{"~1.2", false, "v1.2.0"},
+	}
+	for _, tt := range tests {
+		config, err := service.ResolveConfigurationVersion(ctx, "service-api", tt.selector, tt.prerelease)
+		if err != nil {
+			t.Errorf("Resolve %q failed: %v", tt.selector, err)
+			continue
+		}
+		if config.Version != tt.want {
+			t.Errorf("Resolve %q (prerelease=%v) = %s, want %s", tt.selector, tt.prerelease, config.Version, tt.want)
+		}
+	}
+
+	if _, err := service.ResolveConfigurationVersion(ctx, "service-api", "^3", false); err == nil {
+		t.Error("Expected not found error when no version matches")
+	}
+	if _, err := service.ResolveConfigurationVersion(ctx, "service-api", "^x.1", false); !errors.Is(err, ErrInvalidVersionSelector) {
+		t.Errorf("Expected ErrInvalidVersionSelector, got: %v", err)
+	}
+}
+
+func TestConfigurationService_StrictVersions(t *testing.T) {
+	mockRepo := NewMockRepository()
+	service := NewConfigurationService(mockRepo)
+	service.StrictVersions = true
+	ctx := context.Background()
+
+	if _, err := service.AddConfiguration(ctx, model.Configuration{Name: "strict", Version: "prod"}, ""); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Expected ErrInvalidVersion for configuration, got: %v", err)
+	}
+	if _, err := service.AddConfigurationGroup(ctx, model.ConfigurationGroup{Name: "strict", Version: "1.0"}, ""); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Expected ErrInvalidVersion for group, got: %v", err)
+	}
+	if _, err := service.AddConfiguration(ctx, model.Configuration{Name: "strict", Version: "v1.0.0"}, ""); err != nil {
+		t.Errorf("Valid semver version rejected: %v", err)
+	}
+}
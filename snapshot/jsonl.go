@@ -0,0 +1,94 @@
+package snapshot
+
+import (
+	"alati_projekat/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Record kinds of the JSON lines encoding.
+const (
+	kindHeader         = "header"
+	kindConfiguration  = "configuration"
+	kindGroup          = "group"
+	kindIdempotencyKey = "idempotencyKey"
+)
+
+// record is one line of a JSON lines snapshot; exactly one payload field is set.
+type record struct {
+	Kind          string                    `json:"kind"`
+	Header        *model.SnapshotHeader     `json:"header,omitempty"`
+	Configuration *model.Configuration      `json:"configuration,omitempty"`
+	Group         *model.ConfigurationGroup `json:"group,omitempty"`
+	Key           string                    `json:"key,omitempty"`
+}
+
+func writeJSONLines(w io.Writer, snap model.Snapshot) error {
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(record{Kind: kindHeader, Header: &snap.Header}); err != nil {
+		return err
+	}
+	for i := range snap.Configurations {
+		if err := enc.Encode(record{Kind: kindConfiguration, Configuration: &snap.Configurations[i]}); err != nil {
+			return err
+		}
+	}
+	for i := range snap.Groups {
+		if err := enc.Encode(record{Kind: kindGroup, Group: &snap.Groups[i]}); err != nil {
+			return err
+		}
+	}
+	for _, key := range snap.IdempotencyKeys {
+		if err := enc.Encode(record{Kind: kindIdempotencyKey, Key: key}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readJSONLines(r io.Reader) (model.Snapshot, error) {
+	var snap model.Snapshot
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var rec record
+		err := dec.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			if n == 1 {
+				return snap, fmt.Errorf("%w: empty document", ErrInvalid)
+			}
+			return snap, nil
+		}
+		if errors.Is(err, ErrTooLarge) {
+			return snap, err
+		}
+		if err != nil {
+			return snap, fmt.Errorf("%w: record %d: %v", ErrInvalid, n, err)
+		}
+
+		if n == 1 {
+			if rec.Kind != kindHeader || rec.Header == nil {
+				return snap, fmt.Errorf("%w: the first record must be the header", ErrInvalid)
+			}
+			if err := checkHeader(*rec.Header); err != nil {
+				return snap, err
+			}
+			snap.Header = *rec.Header
+			continue
+		}
+
+		switch {
+		case rec.Kind == kindConfiguration && rec.Configuration != nil:
+			snap.Configurations = append(snap.Configurations, *rec.Configuration)
+		case rec.Kind == kindGroup && rec.Group != nil:
+			snap.Groups = append(snap.Groups, *rec.Group)
+		case rec.Kind == kindIdempotencyKey && rec.Key != "":
+			snap.IdempotencyKeys = append(snap.IdempotencyKeys, rec.Key)
+		case rec.Kind == kindHeader:
+			return snap, fmt.Errorf("%w: record %d: duplicate header", ErrInvalid, n)
+		default:
+			return snap, fmt.Errorf("%w: record %d: unknown kind %q or missing payload", ErrInvalid, n, rec.Kind)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package snapshot encodes and decodes store snapshots. A snapshot is written either as
+// JSON lines, one record per line starting with the header, or as a gzip-compressed tar
+// archive with one JSON file per entity. Both carry the same records, and Read detects
+// which one it was given.
+package snapshot
+
+import (
+	"alati_projekat/model"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format identifies a snapshot encoding.
+type Format string
+
+const (
+	// JSONLines writes one JSON record per line; it is the default.
+	JSONLines Format = "jsonl"
+	// TarGz writes a gzip-compressed tar archive with one file per entity.
+	TarGz Format = "tar.gz"
+)
+
+// MaxDecompressedSize limits how much data Read accepts after decompression.
+const MaxDecompressedSize = 256 << 20
+
+var (
+	// ErrUnknownFormat is returned for a ?format= value that is not supported.
+	ErrUnknownFormat = errors.New("unknown snapshot format")
+	// ErrInvalid is returned, wrapped, for documents that are not valid snapshots.
+	ErrInvalid = errors.New("invalid snapshot")
+	// ErrTooLarge is returned when a snapshot exceeds MaxDecompressedSize.
+	ErrTooLarge = errors.New("snapshot is too large")
+)
+
+// ParseFormat returns the format named by s; an empty s selects JSONLines.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "", "jsonl", "ndjson":
+		return JSONLines, nil
+	case "tar.gz", "tgz", "tar":
+		return TarGz, nil
+	}
+	return "", fmt.Errorf("%w %q, expected jsonl or tar.gz", ErrUnknownFormat, s)
+}
+
+// ContentType returns the media type of f.
+func (f Format) ContentType() string {
+	if f == TarGz {
+		return "application/gzip"
+	}
+	return "application/x-ndjson"
+}
+
+// Extension returns the file name extension of f, without the leading dot.
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Header returns the header of a snapshot holding the given records.
+func Header(snap model.Snapshot) model.SnapshotHeader {
+	h := snap.Header
+	h.Format = model.SnapshotFormat
+	h.Version = model.SnapshotVersion
+	h.Counts = model.SnapshotCounts{
+		Configurations:  len(snap.Configurations),
+		Groups:          len(snap.Groups),
+		IdempotencyKeys: len(snap.IdempotencyKeys),
+	}
+	return h
+}
+
+// Write encodes snap to w in format f. The header is filled in from the records, so snap
+// is complete before the first byte is written; records are then encoded to w one by one.
+func Write(w io.Writer, f Format, snap model.Snapshot) error {
+	snap.Header = Header(snap)
+	if f == TarGz {
+		return writeTarGz(w, snap)
+	}
+	return writeJSONLines(w, snap)
+}
+
+// Read decodes a snapshot in either format. It checks the header and that the number of
+// records matches the counts it announces.
+func Read(r io.Reader) (model.Snapshot, error) {
+	var magic [2]byte
+	n, err := io.ReadFull(r, magic[:])
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		if errors.Is(err, io.EOF) {
+			return model.Snapshot{}, fmt.Errorf("%w: empty document", ErrInvalid)
+		}
+		return model.Snapshot{}, err
+	}
+	r = io.MultiReader(bytes.NewReader(magic[:n]), r)
+
+	var snap model.Snapshot
+	if n == 2 && magic == gzipMagic {
+		snap, err = readTarGz(r)
+	} else {
+		snap, err = readJSONLines(limit(r))
+	}
+	if err != nil {
+		return model.Snapshot{}, err
+	}
+	return snap, checkCounts(snap)
+}
+
+func checkHeader(h model.SnapshotHeader) error {
+	if h.Format != model.SnapshotFormat {
+		return fmt.Errorf("%w: format %q, expected %q", ErrInvalid, h.Format, model.SnapshotFormat)
+	}
+	if h.Version < 1 || h.Version > model.SnapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d, this server reads versions up to %d", ErrInvalid, h.Version, model.SnapshotVersion)
+	}
+	return nil
+}
+
+func checkCounts(snap model.Snapshot) error {
+	want := snap.Header.Counts
+	got := Header(snap).Counts
+	if got != want {
+		return fmt.Errorf("%w: header announces %d configurations, %d groups and %d idempotency keys but %d, %d and %d were read; the snapshot is probably truncated",
+			ErrInvalid, want.Configurations, want.Groups, want.IdempotencyKeys, got.Configurations, got.Groups, got.IdempotencyKeys)
+	}
+	return nil
+}
+
+// limitedReader fails with ErrTooLarge once more than MaxDecompressedSize bytes were read.
+type limitedReader struct {
+	r    io.Reader
+	left int64
+}
+
+func limit(r io.Reader) io.Reader {
+	return &limitedReader{r: r, left: MaxDecompressedSize}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.left < 0 {
+		return 0, ErrTooLarge
+	}
+	if int64(len(p)) > l.left+1 {
+		p = p[:l.left+1]
+	}
+	n, err := l.r.Read(p)
+	l.left -= int64(n)
+	if l.left < 0 {
+		return n, ErrTooLarge
+	}
+	return n, err
+}
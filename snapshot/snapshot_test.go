@@ -0,0 +1,100 @@
+package snapshot
+
+import (
+	"alati_projekat/model"
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSnapshot() model.Snapshot {
+	exported := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+	return model.Snapshot{
+		Header: model.SnapshotHeader{ExportedAt: exported, Revisions: true, IdempotencyKeys: true},
+		Configurations: []model.Configuration{
+			{Name: "api", Version: "v1", Params: []model.Parameter{{Key: "db.host", Value: "localhost"}}, Metadata: model.Metadata{Revision: 3}},
+			{Name: "api", Version: "v2", Labels: []model.Parameter{{Key: "env", Value: "prod"}}},
+		},
+		Groups: []model.ConfigurationGroup{
+			{Name: "cluster", Version: "v1", Configurations: []model.Configuration{{Name: "api", Version: "v1"}}},
+		},
+		IdempotencyKeys: []string{"key-1", "key-2"},
+	}
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	for _, format := range []Format{JSONLines, TarGz} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, format, testSnapshot()); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+
+			got, err := Read(&buf)
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+			want := testSnapshot()
+			want.Header = Header(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Round trip mismatch:\n got %+v\nwant %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRead_Rejects(t *testing.T) {
+	var valid bytes.Buffer
+	_ = Write(&valid, JSONLines, testSnapshot())
+	lines := strings.SplitAfter(valid.String(), "\n")
+
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"empty", ""},
+		{"no header", lines[1]},
+		{"wrong format", `{"kind":"header","header":{"format":"other","version":1}}` + "\n"},
+		{"future version", `{"kind":"header","header":{"format":"alati-config-snapshot","version":99}}` + "\n"},
+		{"truncated", strings.Join(lines[:3], "")},
+		{"unknown kind", lines[0] + `{"kind":"secret","key":"x"}` + "\n"},
+		{"malformed", lines[0] + "{not json\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(tt.doc)); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Expected ErrInvalid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRead_RejectsUnexpectedArchiveEntries(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	_ = tw.WriteHeader(&tar.Header{Name: "../etc/passwd", Mode: 0o644, Size: 2})
+	_, _ = tw.Write([]byte("{}"))
+	_ = tw.Close()
+	_ = gz.Close()
+
+	if _, err := Read(&buf); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"": JSONLines, "jsonl": JSONLines, "tar.gz": TarGz, "TGZ": TarGz} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("zip"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Expected ErrUnknownFormat, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package snapshot
+
+import (
+	"alati_projekat/model"
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Files of the tar.gz encoding. Entities are stored under the same prefixes they have in
+// Consul, as <prefix><name>/<version>.json.
+const (
+	headerFile          = "snapshot.json"
+	configurationsDir   = "configurations/"
+	groupsDir           = "configgroups/"
+	idempotencyKeysFile = "idempotency-keys.json"
+)
+
+var gzipMagic = [2]byte{0x1f, 0x8b}
+
+func writeTarGz(w io.Writer, snap model.Snapshot) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	add := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: snap.Header.ExportedAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}
+
+	if err := add(headerFile, snap.Header); err != nil {
+		return err
+	}
+	for _, c := range snap.Configurations {
+		if err := add(configurationsDir+c.Name+"/"+c.Version+".json", c); err != nil {
+			return err
+		}
+	}
+	for _, g := range snap.Groups {
+		if err := add(groupsDir+g.Name+"/"+g.Version+".json", g); err != nil {
+			return err
+		}
+	}
+	if snap.Header.IdempotencyKeys {
+		keys := snap.IdempotencyKeys
+		if keys == nil {
+			keys = []string{}
+		}
+		if err := add(idempotencyKeysFile, keys); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func readTarGz(r io.Reader) (model.Snapshot, error) {
+	var snap model.Snapshot
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return snap, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(limit(gz))
+
+	for n := 0; ; n++ {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			if n == 0 {
+				return snap, fmt.Errorf("%w: empty archive", ErrInvalid)
+			}
+			return snap, nil
+		}
+		if errors.Is(err, ErrTooLarge) {
+			return snap, err
+		}
+		if err != nil {
+			return snap, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return snap, fmt.Errorf("%w: %s: only regular files are allowed", ErrInvalid, hdr.Name)
+		}
+
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if n == 0 {
+			if name != headerFile {
+				return snap, fmt.Errorf("%w: the first file must be %s", ErrInvalid, headerFile)
+			}
+			if err := decodeFile(tr, name, &snap.Header); err != nil {
+				return snap, err
+			}
+			if err := checkHeader(snap.Header); err != nil {
+				return snap, err
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(name, configurationsDir) && path.Ext(name) == ".json":
+			var c model.Configuration
+			if err := decodeFile(tr, name, &c); err != nil {
+				return snap, err
+			}
+			snap.Configurations = append(snap.Configurations, c)
+		case strings.HasPrefix(name, groupsDir) && path.Ext(name) == ".json":
+			var g model.ConfigurationGroup
+			if err := decodeFile(tr, name, &g); err != nil {
+				return snap, err
+			}
+			snap.Groups = append(snap.Groups, g)
+		case name == idempotencyKeysFile:
+			if err := decodeFile(tr, name, &snap.IdempotencyKeys); err != nil {
+				return snap, err
+			}
+		default:
+			return snap, fmt.Errorf("%w: unexpected file %s", ErrInvalid, hdr.Name)
+		}
+	}
+}
+
+func decodeFile(r io.Reader, name string, v any) error {
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		if errors.Is(err, ErrTooLarge) {
+			return err
+		}
+		return fmt.Errorf("%w: %s: %v", ErrInvalid, name, err)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Package validation checks configurations and groups before they are stored.
+// Names and versions become Consul key segments, so they are restricted to a safe
+// character set; every violation is collected so clients can fix a request in one go.
+package validation
+
+import (
+	"alati_projekat/model"
+	"alati_projekat/semver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Limits enforced on requests.
+const (
+	MaxBodySize          = 1 << 20
+	MaxNameLength        = 128
+	MaxVersionLength     = 64
+	MaxKeyLength         = 256
+	MaxValueLength       = 64 << 10
+	MaxLabelKeyLength    = 63
+	MaxLabelValueLength  = 256
+	MaxDescriptionLength = 4096
+	MaxParams            = 1000
+	MaxLabels            = 64
+	MaxGroupMembers      = 500
+	MaxBatchOperations   = 1000
+	MaxBatchBodySize     = 16 << 20
+	MaxSnapshotSize      = 64 << 20
+)
+
+// ErrInvalid matches every *Error with errors.Is.
+var ErrInvalid = errors.New("validation failed")
+
+var (
+	namePattern     = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	versionPattern  = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*$`)
+	labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+)
+
+// Violation is a single invalid field, addressed with a JSON path such as "params[2].key".
+type Violation struct {
+	Field   string
+	Message string
+}
+
+// Error lists every violation found in a request.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + ": " + v.Message
+	}
+	return ErrInvalid.Error() + ": " + strings.Join(parts, "; ")
+}
+
+// Is makes errors.Is(err, ErrInvalid) true for validation errors.
+func (e *Error) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+// Validator collects violations; the zero value is ready to use.
+type Validator struct {
+	violations []Violation
+}
+
+// Add records a violation of field.
+func (v *Validator) Add(field, format string, args ...any) {
+	v.violations = append(v.violations, Violation{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Err returns an *Error with the collected violations, or nil when there are none.
+func (v *Validator) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &Error{Violations: v.violations}
+}
+
+// Name checks a configuration or group name.
+func (v *Validator) Name(field, name string) {
+	switch {
+	case name == "":
+		v.Add(field, "must not be empty")
+	case len(name) > MaxNameLength:
+		v.Add(field, "must be at most %d characters long", MaxNameLength)
+	case !namePattern.MatchString(name):
+		v.Add(field, "must start with a letter or digit and contain only letters, digits, '.', '_' and '-'")
+	}
+}
+
+// Version checks a configuration or group version.
+func (v *Validator) Version(field, version string) {
+	switch {
+	case version == "":
+		v.Add(field, "must not be empty")
+	case len(version) > MaxVersionLength:
+		v.Add(field, "must be at most %d characters long", MaxVersionLength)
+	case !versionPattern.MatchString(version):
+		v.Add(field, "must start with a letter or digit and contain only letters, digits, '.', '_', '+' and '-'")
+	case semver.IsSelector(version):
+		v.Add(field, "%q is reserved as a version selector", version)
+	}
+}
+
+// Ref checks a reference to another configuration.
+func (v *Validator) Ref(field string, ref model.ConfigurationRef) {
+	v.Name(field+".name", ref.Name)
+	v.Version(field+".version", ref.Version)
+}
+
+// Params checks parameter keys and values and rejects duplicate keys.
+func (v *Validator) Params(field string, params []model.Parameter) {
+	if len(params) > MaxParams {
+		v.Add(field, "must contain at most %d parameters", MaxParams)
+		return
+	}
+	seen := make(map[string]int, len(params))
+	for i, p := range params {
+		f := fmt.Sprintf("%s[%d]", field, i)
+		v.paramKey(f+".key", p.Key)
+		if len(p.Value) > MaxValueLength {
+			v.Add(f+".value", "must be at most %d bytes long", MaxValueLength)
+		}
+		if first, ok := seen[p.Key]; ok && p.Key != "" {
+			v.Add(f+".key", "duplicate key %q, first defined at %s[%d]", p.Key, field, first)
+			continue
+		}
+		seen[p.Key] = i
+	}
+}
+
+// Labels checks label keys and values. Labels are queried as "k:v;k2:v2", so ':'
+// and ';' cannot appear in keys and ';' cannot appear in values.
+func (v *Validator) Labels(field string, labels []model.Parameter) {
+	if len(labels) > MaxLabels {
+		v.Add(field, "must contain at most %d labels", MaxLabels)
+		return
+	}
+	seen := make(map[string]int, len(labels))
+	for i, l := range labels {
+		f := fmt.Sprintf("%s[%d]", field, i)
+		switch {
+		case l.Key == "":
+			v.Add(f+".key", "must not be empty")
+		case len(l.Key) > MaxLabelKeyLength:
+			v.Add(f+".key", "must be at most %d characters long", MaxLabelKeyLength)
+		case !labelKeyPattern.MatchString(l.Key):
+			v.Add(f+".key", "must start with a letter or digit and contain only letters, digits, '.', '_', '/' and '-'")
+		}
+		switch {
+		case strings.TrimSpace(l.Value) == "":
+			v.Add(f+".value", "must not be empty")
+		case len(l.Value) > MaxLabelValueLength:
+			v.Add(f+".value", "must be at most %d characters long", MaxLabelValueLength)
+		case strings.ContainsRune(l.Value, ';') || l.Value != strings.TrimSpace(l.Value) || hasControl(l.Value):
+			v.Add(f+".value", "must not contain ';', control characters or surrounding whitespace")
+		}
+		if first, ok := seen[l.Key]; ok && l.Key != "" {
+			v.Add(f+".key", "duplicate label %q, first defined at %s[%d]", l.Key, field, first)
+			continue
+		}
+		seen[l.Key] = i
+	}
+}
+
+// Description checks the free-form description.
+func (v *Validator) Description(field, description string) {
+	if len(description) > MaxDescriptionLength {
+		v.Add(field, "must be at most %d characters long", MaxDescriptionLength)
+	}
+}
+
+func (v *Validator) paramKey(field, key string) {
+	switch {
+	case key == "":
+		v.Add(field, "must not be empty")
+	case len(key) > MaxKeyLength:
+		v.Add(field, "must be at most %d characters long", MaxKeyLength)
+	case key != strings.TrimSpace(key) || hasControl(key):
+		v.Add(field, "must not contain control characters or surrounding whitespace")
+	}
+}
+
+// Configuration validates c, prefixing every field path with prefix.
+func (v *Validator) Configuration(prefix string, c model.Configuration) {
+	v.Name(prefix+"name", c.Name)
+	v.Version(prefix+"version", c.Version)
+	v.Params(prefix+"params", c.Params)
+	v.Labels(prefix+"labels", c.Labels)
+	if c.Parent != nil {
+		v.Ref(prefix+"parent", *c.Parent)
+	}
+	for i, key := range c.RemoveParams {
+		v.paramKey(fmt.Sprintf("%sremoveParams[%d]", prefix, i), key)
+	}
+	v.Description(prefix+"description", c.Description)
+}
+
+// Configuration returns an *Error describing everything wrong with c, or nil.
+func Configuration(c model.Configuration) error {
+	var v Validator
+	v.Configuration("", c)
+	return v.Err()
+}
+
+// Group validates g and its member configurations, prefixing every field path with prefix.
+func (v *Validator) Group(prefix string, g model.ConfigurationGroup) {
+	v.Name(prefix+"name", g.Name)
+	v.Version(prefix+"version", g.Version)
+	v.Description(prefix+"description", g.Description)
+	if len(g.Configurations) > MaxGroupMembers {
+		v.Add(prefix+"configurations", "must contain at most %d configurations", MaxGroupMembers)
+		return
+	}
+	seen := make(map[string]int, len(g.Configurations))
+	for i, c := range g.Configurations {
+		field := fmt.Sprintf("%sconfigurations[%d]", prefix, i)
+		v.Configuration(field+".", c)
+		key := c.Name + "/" + c.Version
+		if first, ok := seen[key]; ok {
+			v.Add(field, "duplicate member %s, first defined at configurations[%d]", key, first)
+			continue
+		}
+		seen[key] = i
+	}
+}
+
+// Group returns an *Error describing everything wrong with g and its member configurations, or nil.
+func Group(g model.ConfigurationGroup) error {
+	var v Validator
+	v.Group("", g)
+	return v.Err()
+}
+
+func hasControl(s string) bool {
+	return strings.IndexFunc(s, unicode.IsControl) >= 0
+}
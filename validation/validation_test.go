@@ -0,0 +1,131 @@
+package validation
+
+import (
+	"alati_projekat/model"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func violations(err error) map[string]string {
+	var verr *Error
+	if !errors.As(err, &verr) {
+		return nil
+	}
+	m := map[string]string{}
+	for _, v := range verr.Violations {
+		m[v.Field] = v.Message
+	}
+	return m
+}
+
+func TestConfiguration_Valid(t *testing.T) {
+	c := model.Configuration{
+		Name:         "service-api",
+		Version:      "1.2.0-rc.1+build.5",
+		Params:       []model.Parameter{{Key: "db.host", Value: "localhost"}, {Key: "servers.0", Value: ""}},
+		Labels:       []model.Parameter{{Key: "app.kubernetes.io/name", Value: "api"}},
+		Parent:       &model.ConfigurationRef{Name: "service-api-base", Version: "v1"},
+		RemoveParams: []string{"debug"},
+	}
+	if err := Configuration(c); err != nil {
+		t.Errorf("Expected a valid configuration, got %v", err)
+	}
+}
+
+func TestConfiguration_ReportsAllViolations(t *testing.T) {
+	c := model.Configuration{
+		Name:    "team/api",
+		Version: "latest",
+		Params: []model.Parameter{
+			{Key: "a", Value: "1"},
+			{Key: "", Value: "2"},
+			{Key: "a", Value: "3"},
+			{Key: " b", Value: "4"},
+		},
+		Labels: []model.Parameter{
+			{Key: "env:prod", Value: "x"},
+			{Key: "tier", Value: "a;b"},
+			{Key: "tier", Value: "web"},
+		},
+		Parent:       &model.ConfigurationRef{Name: "", Version: "v1"},
+		RemoveParams: []string{""},
+		Description:  strings.Repeat("d", MaxDescriptionLength+1),
+	}
+
+	err := Configuration(c)
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Expected ErrInvalid, got %v", err)
+	}
+	got := violations(err)
+	for _, field := range []string{
+		"name", "version", "params[1].key", "params[2].key", "params[3].key",
+		"labels[0].key", "labels[1].value", "labels[2].key", "parent.name", "removeParams[0]", "description",
+	} {
+		if _, ok := got[field]; !ok {
+			t.Errorf("Expected a violation for %s, got %v", field, got)
+		}
+	}
+	if len(got) != 11 {
+		t.Errorf("Expected 11 violations, got %d: %v", len(got), got)
+	}
+}
+
+// Verzije koje bi se citale kao selektori ne mogu da se adresiraju
+func TestVersion_RejectsSelectors(t *testing.T) {
+	tests := []struct {
+		version string
+		valid   bool
+	}{
+		{"latest", false},
+		{"x", false},
+		{"1.x", false},
+		{"v2.X", false},
+		{"1.2.x", false},
+		{"1.2", true},
+		{"v1", true},
+		{"1.0.0-rc.1", true},
+		{"xray", true},
+	}
+	for _, tt := range tests {
+		var v Validator
+		v.Version("version", tt.version)
+		if err := v.Err(); (err == nil) != tt.valid {
+			t.Errorf("Version %q: expected valid=%v, got %v", tt.version, tt.valid, err)
+		}
+	}
+}
+
+func TestConfiguration_Limits(t *testing.T) {
+	params := make([]model.Parameter, MaxParams+1)
+	for i := range params {
+		params[i] = model.Parameter{Key: strings.Repeat("k", i+1)}
+	}
+	c := model.Configuration{
+		Name:    strings.Repeat("n", MaxNameLength+1),
+		Version: "v1",
+		Params:  params,
+	}
+	got := violations(Configuration(c))
+	if _, ok := got["name"]; !ok {
+		t.Errorf("Expected a length violation for name, got %v", got)
+	}
+	if _, ok := got["params"]; !ok {
+		t.Errorf("Expected a count violation for params, got %v", got)
+	}
+}
+
+func TestGroup_PrefixesMemberFields(t *testing.T) {
+	g := model.ConfigurationGroup{
+		Name:    "cluster",
+		Version: "v1",
+		Configurations: []model.Configuration{
+			{Name: "api", Version: "v1"},
+			{Name: "", Version: "v1", Params: []model.Parameter{{Key: "x"}, {Key: "x"}}},
+		},
+	}
+	got := violations(Group(g))
+	if len(got) != 2 || got["configurations[1].name"] == "" || got["configurations[1].params[1].key"] == "" {
+		t.Errorf("Unexpected violations: %v", got)
+	}
+}
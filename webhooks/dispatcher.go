@@ -0,0 +1,310 @@
+// Package webhooks manages webhook subscriptions and delivers change events from the event
+// log to them. Deliveries are signed with HMAC-SHA256, retried with exponential backoff and
+// moved to a dead-letter list once every attempt failed.
+package webhooks
+
+import (
+	"alati_projekat/events"
+	"alati_projekat/labels"
+	"alati_projekat/model"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Defaults used by NewDispatcher.
+const (
+	DefaultMaxAttempts  = 5
+	DefaultBaseBackoff  = time.Second
+	DefaultMaxBackoff   = 5 * time.Minute
+	DefaultTimeout      = 10 * time.Second
+	DefaultHistorySize  = 1000
+	SignatureHeader     = "X-Webhook-Signature"
+	signaturePrefix     = "sha256="
+	maxReceiverResponse = 64 << 10
+)
+
+// Store persists webhook subscriptions; repository.Repository implements it.
+type Store interface {
+	AddWebhook(ctx context.Context, hook model.Webhook) error
+	GetWebhook(ctx context.Context, id uuid.UUID) (model.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	ListWebhooks(ctx context.Context) ([]model.Webhook, error)
+}
+
+// Dispatcher delivers the events of an event log to the stored webhooks. Delivery attempts
+// and dead letters are kept in memory, bounded by HistorySize.
+type Dispatcher struct {
+	Store       Store
+	Client      *http.Client
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Timeout     time.Duration // per attempt
+	HistorySize int
+
+	mu          sync.Mutex
+	deliveries  []model.WebhookDelivery // oldest first
+	deadLetters []model.DeadLetter      // oldest first
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher(store Store) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		Store:       store,
+		Client:      &http.Client{},
+		MaxAttempts: DefaultMaxAttempts,
+		BaseBackoff: DefaultBaseBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+		Timeout:     DefaultTimeout,
+		HistorySize: DefaultHistorySize,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Sign returns the X-Webhook-Signature value for body: "sha256=" followed by the hex
+// encoded HMAC-SHA256 of body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of body for secret. Receivers can use
+// it to check deliveries.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Start delivers the events published to l from now on, until Close is called or l is closed.
+func (d *Dispatcher) Start(l *events.Log) {
+	sub := l.Subscribe()
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(l, sub)
+	}()
+}
+
+// Close stops dispatching, cancels pending retries and waits for running attempts to end.
+func (d *Dispatcher) Close() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+// run dispatches events until the dispatcher or the log is closed. A dispatcher that falls
+// behind is dropped by the log; it then resumes after the last event it dispatched.
+func (d *Dispatcher) run(l *events.Log, sub *events.Subscription) {
+	var lastID uint64
+	for {
+		for _, e := range sub.Backlog {
+			d.dispatch(e)
+			lastID = e.ID
+		}
+	consume:
+		for {
+			select {
+			case <-d.ctx.Done():
+				sub.Close()
+				return
+			case e, ok := <-sub.C:
+				if !ok {
+					break consume
+				}
+				d.dispatch(e)
+				lastID = e.ID
+			}
+		}
+		if l.Closed() {
+			return
+		}
+		sub = l.Resume(lastID)
+		if sub.Missed {
+			log.Printf("Webhooks: events after %d were evicted before they could be dispatched", lastID)
+		}
+	}
+}
+
+// dispatch starts a delivery of e to every webhook that subscribed to it.
+func (d *Dispatcher) dispatch(e model.Event) {
+	ctx, cancel := context.WithTimeout(d.ctx, d.Timeout)
+	hooks, err := d.Store.ListWebhooks(ctx)
+	cancel()
+	if err != nil {
+		log.Printf("Webhooks: failed to list webhooks for event %d: %v", e.ID, err)
+		return
+	}
+	for _, hook := range hooks {
+		if !matches(hook, e) {
+			continue
+		}
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.deliver(hook, e)
+		}()
+	}
+}
+
+// matches applies the event type and label filters of hook. Like the event stream, a label
+// selector only matches configuration events.
+func matches(hook model.Webhook, e model.Event) bool {
+	if len(hook.Events) > 0 && !slices.Contains(hook.Events, e.Type) {
+		return false
+	}
+	selector, err := labels.Parse(hook.Labels)
+	if err != nil {
+		return false
+	}
+	return labels.HasAll(model.Configuration{Labels: e.Labels}, selector)
+}
+
+// deliver POSTs e to hook until the receiver accepts it or MaxAttempts is reached, waiting
+// BaseBackoff, 2*BaseBackoff, ... (at most MaxBackoff) between attempts.
+func (d *Dispatcher) deliver(hook model.Webhook, e model.Event) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("Webhooks: failed to encode event %d: %v", e.ID, err)
+		return
+	}
+
+	deliveryID := uuid.New()
+	var last model.WebhookDelivery
+	for attempt := 1; attempt <= d.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(d.backoff(attempt - 1))
+			select {
+			case <-d.ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+		last = d.attempt(hook, e, body, deliveryID, attempt)
+		d.record(last)
+		if last.Succeeded {
+			return
+		}
+	}
+
+	d.mu.Lock()
+	d.deadLetters = appendBounded(d.deadLetters, model.DeadLetter{
+		DeliveryID: deliveryID,
+		WebhookID:  hook.ID,
+		Event:      e,
+		Attempts:   d.MaxAttempts,
+		LastError:  last.Error,
+		Time:       time.Now().UTC(),
+	}, d.HistorySize)
+	d.mu.Unlock()
+}
+
+// backoff returns the wait before retry n, starting at 1.
+func (d *Dispatcher) backoff(n int) time.Duration {
+	wait := d.BaseBackoff
+	for i := 1; i < n && wait < d.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.MaxBackoff)
+}
+
+// attempt makes a single delivery attempt. Any 2xx response counts as accepted.
+func (d *Dispatcher) attempt(hook model.Webhook, e model.Event, body []byte, deliveryID uuid.UUID, n int) model.WebhookDelivery {
+	result := model.WebhookDelivery{
+		DeliveryID: deliveryID,
+		WebhookID:  hook.ID,
+		EventID:    e.ID,
+		EventType:  e.Type,
+		Attempt:    n,
+		Time:       time.Now().UTC(),
+	}
+	defer func() {
+		result.DurationMs = time.Since(result.Time).Milliseconds()
+	}()
+
+	ctx, cancel := context.WithTimeout(d.ctx, d.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "configuration-service-webhooks")
+	req.Header.Set("X-Webhook-Id", hook.ID.String())
+	req.Header.Set("X-Webhook-Delivery", deliveryID.String())
+	req.Header.Set("X-Webhook-Event", string(e.Type))
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxReceiverResponse))
+
+	result.StatusCode = resp.StatusCode
+	result.Succeeded = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !result.Succeeded {
+		result.Error = fmt.Sprintf("receiver responded with %s", resp.Status)
+	}
+	return result
+}
+
+func (d *Dispatcher) record(delivery model.WebhookDelivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deliveries = appendBounded(d.deliveries, delivery, d.HistorySize)
+}
+
+// appendBounded appends v and drops the oldest entries beyond size.
+func appendBounded[T any](s []T, v T, size int) []T {
+	s = append(s, v)
+	if len(s) > size {
+		s = append(s[:0], s[len(s)-size:]...)
+	}
+	return s
+}
+
+// Deliveries returns the recorded delivery attempts to the webhook, newest first.
+func (d *Dispatcher) Deliveries(id uuid.UUID) []model.WebhookDelivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := []model.WebhookDelivery{}
+	for i := len(d.deliveries) - 1; i >= 0; i-- {
+		if d.deliveries[i].WebhookID == id {
+			out = append(out, d.deliveries[i])
+		}
+	}
+	return out
+}
+
+// DeadLetters returns the events that could not be delivered, newest first.
+func (d *Dispatcher) DeadLetters() []model.DeadLetter {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := slices.Clone(d.deadLetters)
+	slices.Reverse(out)
+	if out == nil {
+		out = []model.DeadLetter{}
+	}
+	return out
+}
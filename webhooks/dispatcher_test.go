@@ -0,0 +1,238 @@
+package webhooks
+
+import (
+	"alati_projekat/events"
+	"alati_projekat/model"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryStore čuva webhook-ove u mapi
+type memoryStore struct {
+	mu    sync.Mutex
+	hooks map[uuid.UUID]model.Webhook
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{hooks: make(map[uuid.UUID]model.Webhook)}
+}
+
+func (s *memoryStore) AddWebhook(ctx context.Context, hook model.Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks[hook.ID] = hook
+	return nil
+}
+
+func (s *memoryStore) GetWebhook(ctx context.Context, id uuid.UUID) (model.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hook, ok := s.hooks[id]
+	if !ok {
+		return model.Webhook{}, errors.New("webhook not found")
+	}
+	return hook, nil
+}
+
+func (s *memoryStore) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.hooks, id)
+	return nil
+}
+
+func (s *memoryStore) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hooks := make([]model.Webhook, 0, len(s.hooks))
+	for _, hook := range s.hooks {
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
+
+// receivedRequest je zahtev koji je primio test receiver
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver vraća server koji odgovara statusima iz statuses redom (poslednji se ponavlja)
+func newReceiver(t *testing.T, statuses ...int) (*httptest.Server, <-chan receivedRequest) {
+	t.Helper()
+	received := make(chan receivedRequest, 16)
+	var mu sync.Mutex
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		mu.Unlock()
+		received <- receivedRequest{header: r.Header, body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, received
+}
+
+func newTestDispatcher(t *testing.T) (*Dispatcher, *events.Log) {
+	t.Helper()
+	d := NewDispatcher(newMemoryStore())
+	d.BaseBackoff = time.Millisecond
+	d.MaxBackoff = 5 * time.Millisecond
+	d.MaxAttempts = 3
+	d.Timeout = time.Second
+	log := events.NewLog(0)
+	d.Start(log)
+	t.Cleanup(d.Close)
+	return d, log
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the delivery")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDispatcher_DeliversSignedEvents(t *testing.T) {
+	d, log := newTestDispatcher(t)
+	srv, received := newReceiver(t, http.StatusNoContent)
+	hook, err := d.Create(context.Background(), model.CreateWebhookRequest{URL: srv.URL, Secret: "top-secret"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	e := log.Publish(model.Event{Type: model.EventUpdated, Kind: model.EventConfiguration, Name: "service-api", Version: "v1", Revision: 2})
+
+	select {
+	case req := <-received:
+		if !Verify("top-secret", req.body, req.header.Get(SignatureHeader)) {
+			t.Errorf("Invalid signature %q", req.header.Get(SignatureHeader))
+		}
+		if req.header.Get("X-Webhook-Event") != "updated" || req.header.Get("X-Webhook-Id") != hook.ID.String() {
+			t.Errorf("Unexpected headers %v", req.header)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("The receiver got no delivery")
+	}
+
+	waitFor(t, func() bool { return len(d.Deliveries(hook.ID)) == 1 })
+	delivery := d.Deliveries(hook.ID)[0]
+	if !delivery.Succeeded || delivery.StatusCode != http.StatusNoContent || delivery.EventID != e.ID || delivery.Attempt != 1 {
+		t.Errorf("Unexpected delivery record %+v", delivery)
+	}
+}
+
+func TestDispatcher_RetriesWithBackoff(t *testing.T) {
+	d, log := newTestDispatcher(t)
+	srv, received := newReceiver(t, http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK)
+	hook, _ := d.Create(context.Background(), model.CreateWebhookRequest{URL: srv.URL, Secret: "s"})
+
+	log.Publish(model.Event{Type: model.EventCreated, Kind: model.EventGroup, Name: "cluster", Version: "v1"})
+
+	waitFor(t, func() bool { return len(received) == 3 && len(d.Deliveries(hook.ID)) == 3 })
+	deliveries := d.Deliveries(hook.ID)
+	// Najnoviji pokušaj je prvi
+	if !deliveries[0].Succeeded || deliveries[0].Attempt != 3 || deliveries[2].StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Unexpected delivery log %+v", deliveries)
+	}
+	if deliveries[0].DeliveryID != deliveries[2].DeliveryID {
+		t.Error("Retries must share the delivery id")
+	}
+	if len(d.DeadLetters()) != 0 {
+		t.Errorf("Expected no dead letters, got %+v", d.DeadLetters())
+	}
+}
+
+func TestDispatcher_DeadLetters(t *testing.T) {
+	d, log := newTestDispatcher(t)
+	srv, _ := newReceiver(t, http.StatusInternalServerError)
+	hook, _ := d.Create(context.Background(), model.CreateWebhookRequest{URL: srv.URL, Secret: "s"})
+
+	e := log.Publish(model.Event{Type: model.EventDeleted, Kind: model.EventConfiguration, Name: "service-api", Version: "v1"})
+
+	waitFor(t, func() bool { return len(d.DeadLetters()) == 1 })
+	dead := d.DeadLetters()[0]
+	if dead.WebhookID != hook.ID || dead.Event.ID != e.ID || dead.Attempts != 3 || dead.LastError == "" {
+		t.Errorf("Unexpected dead letter %+v", dead)
+	}
+	if got := len(d.Deliveries(hook.ID)); got != 3 {
+		t.Errorf("Expected 3 recorded attempts, got %d", got)
+	}
+}
+
+func TestDispatcher_Filters(t *testing.T) {
+	d, log := newTestDispatcher(t)
+	srv, received := newReceiver(t, http.StatusOK)
+	d.Create(context.Background(), model.CreateWebhookRequest{URL: srv.URL, Secret: "s", Events: []model.EventType{model.EventUpdated}, Labels: "env:prod"})
+
+	log.Publish(model.Event{Type: model.EventCreated, Name: "a", Labels: []model.Parameter{{Key: "env", Value: "prod"}}})
+	log.Publish(model.Event{Type: model.EventUpdated, Name: "b", Labels: []model.Parameter{{Key: "env", Value: "dev"}}})
+	log.Publish(model.Event{Type: model.EventUpdated, Kind: model.EventGroup, Name: "c"})
+	log.Publish(model.Event{Type: model.EventUpdated, Name: "d", Labels: []model.Parameter{{Key: "env", Value: "prod"}}})
+
+	select {
+	case req := <-received:
+		if !strings.Contains(string(req.body), `"name":"d"`) {
+			t.Errorf("Expected only event d, got %s", req.body)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("The receiver got no delivery")
+	}
+	select {
+	case req := <-received:
+		t.Errorf("Unexpected delivery %s", req.body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	d := NewDispatcher(newMemoryStore())
+	d.BaseBackoff, d.MaxBackoff = time.Second, 5*time.Second
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := d.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d): expected %v, got %v", i+1, w, got)
+		}
+	}
+}
+
+func TestDispatcher_Create_Validation(t *testing.T) {
+	d := NewDispatcher(newMemoryStore())
+	tests := []model.CreateWebhookRequest{
+		{URL: "", Secret: "s"},
+		{URL: "ftp://example.com/hook", Secret: "s"},
+		{URL: "/relative", Secret: "s"},
+		{URL: "http://example.com/hook"},
+		{URL: "http://example.com/hook", Secret: "s", Events: []model.EventType{"renamed"}},
+		{URL: "http://example.com/hook", Secret: "s", Labels: "env"},
+	}
+	for _, req := range tests {
+		if _, err := d.Create(context.Background(), req); err == nil {
+			t.Errorf("Expected a validation error for %+v", req)
+		}
+	}
+
+	hook, err := d.Create(context.Background(), model.CreateWebhookRequest{URL: "https://example.com/hook", Secret: "s"})
+	if err != nil || hook.Secret != "s" {
+		t.Fatalf("Expected the created webhook with its secret, got %+v (%v)", hook, err)
+	}
+	if stored, _ := d.Get(context.Background(), hook.ID); stored.Secret != "" {
+		t.Error("Get must not return the secret")
+	}
+}
@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"alati_projekat/labels"
+	"alati_projekat/model"
+	"alati_projekat/validation"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits enforced on webhook subscriptions.
+const (
+	MaxURLLength    = 2048
+	MaxSecretLength = 256
+)
+
+var eventTypes = []model.EventType{model.EventCreated, model.EventUpdated, model.EventDeleted}
+
+func validate(req model.CreateWebhookRequest) error {
+	var v validation.Validator
+
+	u, err := url.Parse(req.URL)
+	switch {
+	case req.URL == "":
+		v.Add("url", "must not be empty")
+	case len(req.URL) > MaxURLLength:
+		v.Add("url", "must be at most %d characters", MaxURLLength)
+	case err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "":
+		v.Add("url", "must be an absolute http or https URL")
+	}
+
+	for i, t := range req.Events {
+		if !slices.Contains(eventTypes, t) {
+			v.Add(fmt.Sprintf("events[%d]", i), "must be %q, %q or %q", model.EventCreated, model.EventUpdated, model.EventDeleted)
+		}
+	}
+	if _, err := labels.Parse(req.Labels); err != nil {
+		v.Add("labels", "%s", err.Error())
+	}
+
+	switch {
+	case req.Secret == "":
+		v.Add("secret", "must not be empty")
+	case len(req.Secret) > MaxSecretLength:
+		v.Add("secret", "must be at most %d characters", MaxSecretLength)
+	}
+	return v.Err()
+}
+
+// Create validates and stores a new webhook. The returned webhook still carries its secret;
+// Get and List leave it out.
+func (d *Dispatcher) Create(ctx context.Context, req model.CreateWebhookRequest) (model.Webhook, error) {
+	if err := validate(req); err != nil {
+		return model.Webhook{}, err
+	}
+	hook := model.Webhook{
+		ID:        uuid.New(),
+		URL:       req.URL,
+		Events:    req.Events,
+		Labels:    req.Labels,
+		Secret:    req.Secret,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := d.Store.AddWebhook(ctx, hook); err != nil {
+		return model.Webhook{}, err
+	}
+	return hook, nil
+}
+
+func (d *Dispatcher) Get(ctx context.Context, id uuid.UUID) (model.Webhook, error) {
+	hook, err := d.Store.GetWebhook(ctx, id)
+	hook.Secret = ""
+	return hook, err
+}
+
+// List returns every webhook, oldest first.
+func (d *Dispatcher) List(ctx context.Context) ([]model.Webhook, error) {
+	hooks, err := d.Store.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range hooks {
+		hooks[i].Secret = ""
+	}
+	slices.SortFunc(hooks, func(a, b model.Webhook) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return hooks, nil
+}
+
+// Delete removes a webhook; deliveries already in progress are still completed.
+func (d *Dispatcher) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := d.Store.GetWebhook(ctx, id); err != nil {
+		return err
+	}
+	return d.Store.DeleteWebhook(ctx, id)
+}